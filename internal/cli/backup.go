@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/gomdlint/internal/ui/pretty"
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
+)
+
+// backupPruneFlags holds the flags for the backup prune command.
+type backupPruneFlags struct {
+	olderThan string
+	dryRun    bool
+}
+
+func newBackupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "List, restore and prune fix backups",
+		Long: `Manage the snapshots that "lint --fix" takes with backups.mode set to
+directory. Each run that fixes files saves their original content in
+.gomdlint/backups/<run-id>/ under the working directory, with a manifest
+listing the files. Run these commands from the same directory.
+
+Examples:
+  gomdlint backup list
+  gomdlint backup restore                       Undo the last fix run
+  gomdlint backup restore 20260102T150405Z docs/
+  gomdlint backup prune --older-than 7d`,
+	}
+
+	cmd.AddCommand(newBackupListCommand())
+	cmd.AddCommand(newBackupRestoreCommand())
+	cmd.AddCommand(newBackupPruneCommand())
+
+	return cmd
+}
+
+func newBackupListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List backup snapshots, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			workDir, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("get working directory: %w", err)
+			}
+			manifests, err := fsutil.ListBackups(workDir)
+			if err != nil {
+				return fmt.Errorf("list backups: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			styles := backupStyles(cmd, out)
+			if len(manifests) == 0 {
+				fmt.Fprintln(out, styles.Dim.Render("No backups in "+fsutil.BackupDir))
+				return nil
+			}
+			for _, manifest := range manifests {
+				fmt.Fprintf(out, "%s  %s  %s\n", styles.FilePath.Render(manifest.ID),
+					manifest.Created.Local().Format(time.DateTime),
+					styles.Dim.Render(fmt.Sprintf("%d %s", len(manifest.Files), pluralize(len(manifest.Files), "file", "files"))))
+			}
+			return nil
+		},
+	}
+}
+
+func newBackupRestoreCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [run-id] [paths...]",
+		Short: "Restore files from a backup snapshot",
+		Long: `Write the files of a snapshot back to their original paths. Without a
+run ID the newest snapshot is used. Paths limit the restore to files at or
+below them; the first argument is taken as a path if no snapshot has that ID.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workDir, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("get working directory: %w", err)
+			}
+
+			id, paths, err := backupRestoreTarget(workDir, args)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			restored, err := fsutil.RestoreBackups(ctx, workDir, id, paths)
+			out := cmd.OutOrStdout()
+			styles := backupStyles(cmd, out)
+			for _, entry := range restored {
+				fmt.Fprintf(out, "restored %s\n", styles.FilePath.Render(entry.Path))
+			}
+			if err != nil {
+				return fmt.Errorf("restore backup: %w", err)
+			}
+			if len(restored) == 0 {
+				return fmt.Errorf("no files of backup %s match the given paths", id)
+			}
+
+			fmt.Fprintln(out, styles.Success.Render(fmt.Sprintf("Restored %d %s from %s",
+				len(restored), pluralize(len(restored), "file", "files"), id)))
+			return nil
+		},
+	}
+}
+
+// backupRestoreTarget returns the snapshot ID and the paths that the
+// arguments of backup restore select.
+func backupRestoreTarget(workDir string, args []string) (string, []string, error) {
+	if len(args) > 0 {
+		_, err := fsutil.ReadBackupManifest(workDir, args[0])
+		if err == nil {
+			return args[0], args[1:], nil
+		}
+		if !errors.Is(err, fsutil.ErrBackupNotFound) {
+			return "", nil, fmt.Errorf("read backup: %w", err)
+		}
+	}
+
+	manifests, err := fsutil.ListBackups(workDir)
+	if err != nil {
+		return "", nil, fmt.Errorf("list backups: %w", err)
+	}
+	if len(manifests) == 0 {
+		return "", nil, fmt.Errorf("no backups in %s", fsutil.BackupDir)
+	}
+	return manifests[0].ID, args, nil
+}
+
+func newBackupPruneCommand() *cobra.Command {
+	flags := &backupPruneFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old backup snapshots",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			age, err := parseAge(flags.olderThan)
+			if err != nil {
+				return fmt.Errorf("invalid --older-than: %w", err)
+			}
+			workDir, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("get working directory: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			styles := backupStyles(cmd, out)
+			cutoff := time.Now().Add(-age)
+
+			var pruned []fsutil.BackupManifest
+			if flags.dryRun {
+				manifests, err := fsutil.ListBackups(workDir)
+				if err != nil {
+					return fmt.Errorf("list backups: %w", err)
+				}
+				for _, manifest := range manifests {
+					if manifest.Created.Before(cutoff) {
+						pruned = append(pruned, manifest)
+					}
+				}
+			} else {
+				pruned, err = fsutil.PruneBackups(workDir, cutoff)
+				if err != nil {
+					return fmt.Errorf("prune backups: %w", err)
+				}
+			}
+
+			verb := "Removed"
+			if flags.dryRun {
+				verb = "Would remove"
+			}
+			for _, manifest := range pruned {
+				fmt.Fprintf(out, "%s %s\n", strings.ToLower(verb), styles.FilePath.Render(manifest.ID))
+			}
+			fmt.Fprintln(out, styles.Success.Render(fmt.Sprintf("%s %d %s", verb,
+				len(pruned), pluralize(len(pruned), "backup", "backups"))))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.olderThan, "older-than", "",
+		"remove snapshots older than this age, e.g. 7d, 2w or 12h")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "list the snapshots that would be removed")
+	_ = cmd.MarkFlagRequired("older-than")
+
+	return cmd
+}
+
+// parseAge parses an age such as "7d" or "2w", or any time.ParseDuration
+// value.
+func parseAge(value string) (time.Duration, error) {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("%q is not an age", value)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("%q is not an age", value)
+	}
+	return age, nil
+}
+
+// backupStyles returns the output styles for the backup commands.
+func backupStyles(cmd *cobra.Command, out io.Writer) *pretty.Styles {
+	colorMode, err := cmd.Flags().GetString("color")
+	if err != nil {
+		colorMode = "auto"
+	}
+	return pretty.NewStyles(pretty.IsColorEnabled(colorMode, out))
+}
@@ -2,6 +2,7 @@ package cli_test
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/yaklabco/gomdlint/internal/cli"
@@ -46,7 +47,7 @@ func TestRootCommandHasSubcommands(t *testing.T) {
 
 	cmd := cli.NewRootCommand(info)
 
-	expectedSubcommands := []string{"lint", "rules", "init", "version"}
+	expectedSubcommands := []string{"lint", "rules", "init", "detect-lang", "orphans", "report", "version"}
 
 	for _, name := range expectedSubcommands {
 		subCmd, _, err := cmd.Find([]string{name})
@@ -164,3 +165,18 @@ func TestLintCommandAcceptsArbitraryArgs(t *testing.T) {
 		t.Errorf("lint command should accept arbitrary args, got error: %v", err)
 	}
 }
+
+func TestDoctorCommandRequiresCheck(t *testing.T) {
+	t.Parallel()
+
+	cmd := cli.NewRootCommand(cli.BuildInfo{Version: "test", Commit: "test", Date: "test"})
+	cmd.SetArgs([]string{"doctor", t.TempDir()})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--fix-stability") {
+		t.Errorf("doctor without a check: error = %v, want one naming --fix-stability", err)
+	}
+}
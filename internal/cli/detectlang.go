@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/gomdlint/internal/configloader"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/langdetect"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	goldmarkparser "github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+// stdinPath is the display path used for content read from standard input.
+const stdinPath = "<stdin>"
+
+// detectLangFlags holds the flags for the detect-lang command.
+type detectLangFlags struct {
+	format        string
+	minConfidence float64
+	markdown      bool
+}
+
+// detection is one language detection reported by detect-lang.
+type detection struct {
+	Path string `json:"path"`
+
+	// Line is the line of the opening fence, or 0 for a whole-file detection.
+	Line int `json:"line,omitempty"`
+
+	// Info is the existing info string of the fenced code block.
+	Info string `json:"info,omitempty"`
+
+	langdetect.Result
+
+	// Accepted reports whether the detection meets the minimum confidence,
+	// i.e. whether MD040 would insert it as the fence language.
+	Accepted bool `json:"accepted"`
+}
+
+func newDetectLangCommand() *cobra.Command {
+	flags := &detectLangFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "detect-lang [files...]",
+		Short: "Show how code block languages are detected",
+		Long: `Run the language detector used by the MD040 autofix and explain its decisions.
+
+For Markdown files, every fenced code block is classified. Other files, and
+standard input, are classified as a single snippet; use --markdown to treat
+standard input as Markdown. The langdetect section of the configuration
+(candidates, aliases, min_confidence) is applied.
+
+Examples:
+  gomdlint detect-lang README.md              Classify each fenced code block
+  gomdlint detect-lang script.txt             Classify a whole file
+  pbpaste | gomdlint detect-lang              Classify a snippet from stdin
+  gomdlint detect-lang --format json doc.md   Machine-readable output`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDetectLang(cmd, args, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.format, "format", "text", "Output format: text or json")
+	cmd.Flags().Float64Var(&flags.minConfidence, "min-confidence", langdetect.DefaultMinConfidence,
+		"Minimum confidence to accept a detection (overrides langdetect.min_confidence)")
+	cmd.Flags().BoolVar(&flags.markdown, "markdown", false, "Treat standard input as Markdown")
+
+	return cmd
+}
+
+func runDetectLang(cmd *cobra.Command, args []string, flags *detectLangFlags) error {
+	if flags.format != "text" && flags.format != "json" {
+		return fmt.Errorf("invalid format %q: must be text or json", flags.format)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cfg, err := loadProjectConfig(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	minConfidence := langdetect.DefaultMinConfidence
+	if cfg.LangDetect.MinConfidence != nil {
+		minConfidence = *cfg.LangDetect.MinConfidence
+	}
+	if cmd.Flags().Changed("min-confidence") {
+		minConfidence = flags.minConfidence
+	}
+
+	detector := langdetect.New(langdetect.Options{
+		Candidates: cfg.LangDetect.Candidates,
+		Aliases:    cfg.LangDetect.Aliases,
+	})
+
+	if len(args) == 0 {
+		args = []string{"-"}
+	}
+
+	var results []detection
+	for _, arg := range args {
+		path, content, err := readDetectLangInput(cmd, arg)
+		if err != nil {
+			return err
+		}
+
+		isMarkdown := slices.Contains(cfg.Flavor.FileExtensions(), strings.ToLower(filepath.Ext(path)))
+		if arg == "-" {
+			isMarkdown = flags.markdown
+		}
+
+		if !isMarkdown {
+			result := detector.Detect(content)
+			results = append(results, detection{
+				Path:     path,
+				Result:   result,
+				Accepted: isAccepted(result, minConfidence),
+			})
+			continue
+		}
+
+		blocks, err := detectCodeBlocks(ctx, cfg, detector, path, content, minConfidence)
+		if err != nil {
+			return err
+		}
+		results = append(results, blocks...)
+	}
+
+	out := cmd.OutOrStdout()
+	if flags.format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return fmt.Errorf("encode results: %w", err)
+		}
+		return nil
+	}
+
+	writeDetections(out, results, minConfidence)
+	return nil
+}
+
+// loadProjectConfig resolves the configuration the same way lint does,
+// without CLI overrides.
+func loadProjectConfig(ctx context.Context, cmd *cobra.Command) (*config.Config, error) {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, fmt.Errorf("get config flag: %w", err)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("get working directory: %w", err)
+	}
+
+	loadResult, err := configloader.Load(ctx, configloader.LoadOptions{
+		WorkingDir:   workDir,
+		ExplicitPath: configPath,
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to load configuration"), err)
+	}
+
+	return loadResult.Config, nil
+}
+
+// readDetectLangInput reads a file argument, or standard input for "-".
+func readDetectLangInput(cmd *cobra.Command, arg string) (string, []byte, error) {
+	if arg == "-" {
+		content, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", nil, fmt.Errorf("read stdin: %w", err)
+		}
+		return stdinPath, content, nil
+	}
+
+	content, err := os.ReadFile(arg)
+	if err != nil {
+		return "", nil, fmt.Errorf("read %s: %w", arg, err)
+	}
+	return arg, content, nil
+}
+
+// detectCodeBlocks classifies every fenced code block in a Markdown file.
+func detectCodeBlocks(
+	ctx context.Context,
+	cfg *config.Config,
+	detector *langdetect.Detector,
+	path string,
+	content []byte,
+	minConfidence float64,
+) ([]detection, error) {
+	parser := goldmarkparser.New(string(cfg.Flavor))
+	snapshot, err := parser.Parse(ctx, path, content)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	var results []detection
+	for _, cb := range lint.CodeBlocks(snapshot.Root) {
+		if !lint.IsFencedCodeBlock(cb) {
+			continue
+		}
+
+		// SourcePosition starts at the first content line; the fence is above it.
+		line := cb.SourcePosition().StartLine - 1
+		result := detector.Detect(lint.CodeBlockContent(snapshot, cb))
+		results = append(results, detection{
+			Path:     path,
+			Line:     max(line, 0),
+			Info:     lint.CodeBlockInfo(cb),
+			Result:   result,
+			Accepted: isAccepted(result, minConfidence),
+		})
+	}
+
+	return results, nil
+}
+
+// isAccepted reports whether a detection would be acted upon.
+func isAccepted(result langdetect.Result, minConfidence float64) bool {
+	return result.Method != langdetect.MethodNone && result.Confidence >= minConfidence
+}
+
+// writeDetections prints detections in human-readable form.
+func writeDetections(w io.Writer, results []detection, minConfidence float64) {
+	for _, d := range results {
+		location := d.Path
+		if d.Line > 0 {
+			location = fmt.Sprintf("%s:%d", d.Path, d.Line)
+		}
+
+		verdict := "rejected"
+		if d.Accepted {
+			verdict = "accepted"
+		}
+
+		fmt.Fprintf(w, "%s: %s (confidence %.2f, method %s, %s at %.2f)\n",
+			location, d.Language, d.Confidence, d.Method, verdict, minConfidence)
+		if d.Line > 0 {
+			info := d.Info
+			if info == "" {
+				info = "(none)"
+			}
+			fmt.Fprintf(w, "  info string: %s\n", info)
+		}
+		if len(d.Scores) > 0 {
+			scores := make([]string, len(d.Scores))
+			for i, s := range d.Scores {
+				scores[i] = fmt.Sprintf("%s=%.2f", s.Language, s.Score)
+			}
+			fmt.Fprintf(w, "  scores: %s\n", strings.Join(scores, " "))
+		}
+	}
+}
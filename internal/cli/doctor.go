@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/gomdlint/internal/ui/pretty"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	goldmarkparser "github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// errDoctorNoCheck is returned when the doctor command is run without a
+// check selected.
+var errDoctorNoCheck = errors.New("no check selected; use --fix-stability")
+
+// doctorFlags holds the flags for the doctor command.
+type doctorFlags struct {
+	fixStability bool
+	ignore       []string
+	ruleFormat   string
+}
+
+func newDoctorCommand() *cobra.Command {
+	flags := &doctorFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor [paths...]",
+		Short: "Check the configured rules against a corpus",
+		Long: `Run checks that catch problems with the configured rules rather than
+with the documents.
+
+--fix-stability fixes each file in memory and reports fixes that do not
+settle: rules whose fixes undo each other, rules that still change the file
+when the fix loop runs out of passes, and rules whose fixes are not
+idempotent, meaning that linting the output of a rule's fixes yields new
+fixes from the same rule. Files are read as lint reads them, following the
+encoding policy, and are never written.
+
+Select the checks to run; at least one is required.
+
+Examples:
+  gomdlint doctor --fix-stability        Check the current directory
+  gomdlint doctor --fix-stability docs/  Check the docs directory`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd, args, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.fixStability, "fix-stability", false,
+		"report fixes that undo each other, do not converge, or are not idempotent")
+	cmd.Flags().StringSliceVar(&flags.ignore, "ignore", nil, "glob patterns to ignore (repeatable)")
+	cmd.Flags().StringVar(&flags.ruleFormat, "rule-format", "name",
+		"rule identifier format in output: name, id, or combined")
+
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, args []string, flags *doctorFlags) error {
+	if !flags.fixStability {
+		return errDoctorNoCheck
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cfg, err := loadProjectConfig(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	files, err := runner.Discover(ctx, runner.Options{
+		Paths:        args,
+		WorkingDir:   workDir,
+		Extensions:   runner.DefaultExtensionsForFlavor(cfg.Flavor),
+		ExcludeGlobs: slices.Concat(cfg.Ignore, flags.ignore),
+		Config:       cfg,
+	})
+	if err != nil {
+		return fmt.Errorf("discover files: %w", err)
+	}
+
+	colorMode, err := cmd.Flags().GetString("color")
+	if err != nil {
+		colorMode = "auto"
+	}
+	out := cmd.OutOrStdout()
+	styles := pretty.NewStyles(pretty.IsColorEnabled(colorMode, out))
+
+	pipeline := lint.NewPipeline(lint.NewEngine(goldmarkparser.New(string(cfg.Flavor)), lint.DefaultRegistry))
+	ruleFormat := config.RuleFormat(flags.ruleFormat)
+
+	var unstable, unstableFiles int
+	for _, path := range files {
+		issues, err := pipeline.CheckFileFixStability(ctx, path, cfg)
+		if err != nil {
+			return fmt.Errorf("check %s: %w", path, err)
+		}
+		if len(issues) == 0 {
+			continue
+		}
+
+		unstableFiles++
+		unstable += len(issues)
+		display := path
+		if rel, err := filepath.Rel(workDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			display = rel
+		}
+		for _, issue := range issues {
+			fmt.Fprintf(out, "%s:%d  %s  %s\n", styles.FilePath.Render(display), issue.Line,
+				styles.Warning.Render(string(issue.Kind)), describeUnstableFix(issue, ruleFormat))
+		}
+	}
+
+	if unstable == 0 {
+		fmt.Fprintln(out, styles.Success.Render("All fixes are stable")+
+			styles.Dim.Render(fmt.Sprintf(" (%d files checked)", len(files))))
+		return nil
+	}
+	fmt.Fprintln(out, styles.Failure.Render(fmt.Sprintf("%d unstable %s in %d of %d files",
+		unstable, pluralize(unstable, "fix", "fixes"), unstableFiles, len(files))))
+	return ErrLintIssuesFound
+}
+
+// describeUnstableFix explains an unstable fix.
+func describeUnstableFix(issue lint.UnstableFix, ruleFormat config.RuleFormat) string {
+	rules := make([]string, 0, len(issue.Rules))
+	for _, id := range issue.Rules {
+		name := ""
+		if rule, ok := lint.DefaultRegistry.GetByID(id); ok {
+			name = rule.Name()
+		}
+		rules = append(rules, config.FormatRuleID(ruleFormat, id, name))
+	}
+	list := strings.Join(rules, ", ")
+
+	switch issue.Kind {
+	case lint.FixCycles:
+		return "fixes of " + list + " undo each other"
+	case lint.FixDoesNotConverge:
+		return "fixes of " + list + " still change the file when the fix loop runs out of passes"
+	case lint.FixNotIdempotent:
+		return "fixing the output of " + list + " fixes changes it again"
+	default:
+		return list
+	}
+}
+
+// pluralize returns singular for 1 and plural otherwise.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
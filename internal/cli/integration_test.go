@@ -2,6 +2,8 @@ package cli_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/yaklabco/gomdlint/internal/cli"
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
 )
 
 // testMarkdownWithTrailingSpaces is a test markdown file with trailing spaces on line 1.
@@ -699,3 +703,613 @@ func TestIntegration_SummaryFormatNoIssues(t *testing.T) {
 	assert.NotContains(t, output, "Files Summary",
 		"summary format should not show Files Summary when there are no issues")
 }
+
+// TestIntegration_DetectLang tests the detect-lang command on Markdown files and stdin.
+func TestIntegration_DetectLang(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	mdFile := filepath.Join(tmpDir, "doc.md")
+	content := "# Doc\n\n```\nfrom os import path\n```\n\n```go\npackage main\n```\n"
+	require.NoError(t, os.WriteFile(mdFile, []byte(content), 0644))
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	cfgContent := "flavor: commonmark\nlangdetect:\n  aliases:\n    python: py\n"
+	require.NoError(t, os.WriteFile(cfgFile, []byte(cfgContent), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+
+	t.Run("markdown text output", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"detect-lang", "--config", cfgFile, mdFile})
+		require.NoError(t, cmd.Execute())
+
+		output := stdout.String()
+		assert.Contains(t, output, mdFile+":3: py")
+		assert.Contains(t, output, "info string: (none)")
+		assert.Contains(t, output, mdFile+":7: go")
+		assert.Contains(t, output, "info string: go")
+	})
+
+	t.Run("stdin json output", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetIn(strings.NewReader("x := 1\n"))
+		cmd.SetArgs([]string{"detect-lang", "--config", cfgFile, "--format", "json", "--min-confidence", "0.99"})
+		require.NoError(t, cmd.Execute())
+
+		var results []struct {
+			Path     string `json:"path"`
+			Language string `json:"language"`
+			Accepted bool   `json:"accepted"`
+		}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+		require.Len(t, results, 1)
+		assert.Equal(t, "<stdin>", results[0].Path)
+		assert.Equal(t, "go", results[0].Language)
+		assert.False(t, results[0].Accepted)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := cli.NewRootCommand(info)
+		cmd.SetArgs([]string{"detect-lang", "--config", cfgFile, "--format", "xml", mdFile})
+		require.Error(t, cmd.Execute())
+	})
+}
+
+// TestIntegration_Orphans tests the orphans command with diagnostics and JSON report output.
+func TestIntegration_Orphans(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"index.md":     "# Home\n\n[Guide](guide.md)\n\n![Logo](img/logo.png)\n",
+		"guide.md":     "# Guide\n",
+		"stale.md":     "# Stale\n",
+		"img/logo.png": "png",
+		"img/old.png":  "png",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	entry := filepath.Join(tmpDir, "index.md")
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+
+	t.Run("diagnostics", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"orphans", "--config", cfgFile, "--color", "never", "--entry", entry, tmpDir})
+		require.NoError(t, cmd.Execute())
+
+		output := stdout.String()
+		assert.Contains(t, output, "stale.md")
+		assert.Contains(t, output, "orphaned-page")
+		assert.Contains(t, output, "old.png")
+		assert.Contains(t, output, "unused-asset")
+		assert.NotContains(t, output, "guide.md")
+		assert.NotContains(t, output, "logo.png")
+	})
+
+	t.Run("json report", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"orphans", "--config", cfgFile, "--report", "--strict", "--entry", entry, tmpDir})
+
+		require.ErrorIs(t, cmd.Execute(), cli.ErrLintIssuesFound)
+
+		var report struct {
+			Pages         int      `json:"pages"`
+			Assets        int      `json:"assets"`
+			OrphanedPages []string `json:"orphaned_pages"`
+			UnusedAssets  []string `json:"unused_assets"`
+		}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+		assert.Equal(t, 3, report.Pages)
+		assert.Equal(t, 2, report.Assets)
+		require.Len(t, report.OrphanedPages, 1)
+		assert.True(t, strings.HasSuffix(report.OrphanedPages[0], "stale.md"))
+		require.Len(t, report.UnusedAssets, 1)
+		assert.True(t, strings.HasSuffix(report.UnusedAssets[0], "img/old.png"))
+	})
+
+	t.Run("missing entry point", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := cli.NewRootCommand(info)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"orphans", "--config", cfgFile, "--entry", filepath.Join(tmpDir, "nope.md"), tmpDir})
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, cli.ErrLintIssuesFound)
+	})
+}
+
+func TestIntegration_MultipleOutputs(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	mdFile := filepath.Join(tmpDir, "test.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte(testMarkdownWithTrailingSpaces), 0644))
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	sarifFile := filepath.Join(tmpDir, "reports", "results.sarif")
+	jsonFile := filepath.Join(tmpDir, "report.json")
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+
+	cmd := cli.NewRootCommand(info)
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{
+		"lint", "--config", cfgFile, "--color", "never", "--strict",
+		"--output", "text",
+		"--output", "sarif=" + sarifFile,
+		"--output", "json=" + jsonFile,
+		mdFile,
+	})
+
+	// The exit status reflects the result, not the number of outputs.
+	require.ErrorIs(t, cmd.Execute(), cli.ErrLintIssuesFound)
+	assert.Contains(t, stdout.String(), "no-trailing-spaces")
+
+	sarifData, err := os.ReadFile(sarifFile)
+	require.NoError(t, err)
+	var sarif struct {
+		Runs []struct {
+			Results []json.RawMessage `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(sarifData, &sarif))
+	require.Len(t, sarif.Runs, 1)
+	assert.NotEmpty(t, sarif.Runs[0].Results)
+
+	jsonData, err := os.ReadFile(jsonFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonData), `"ruleId": "MD009"`)
+
+	t.Run("format and output are exclusive", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := cli.NewRootCommand(info)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"lint", "--config", cfgFile, "--format", "json", "--output", "text", mdFile})
+		require.Error(t, cmd.Execute())
+	})
+}
+
+// TestIntegration_Report tests converting, merging and diffing JSON reports.
+func TestIntegration_Report(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	mdFile := filepath.Join(tmpDir, "test.md")
+	cleanFile := filepath.Join(tmpDir, "clean.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte(testMarkdownWithTrailingSpaces), 0644))
+	require.NoError(t, os.WriteFile(cleanFile, []byte("# Clean\n"), 0644))
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+	run := func(args ...string) (string, error) {
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	// Two "shards", each linting one file.
+	shardA := filepath.Join(tmpDir, "shard-a.json")
+	shardB := filepath.Join(tmpDir, "shard-b.json")
+	_, err := run("lint", "--config", cfgFile, "--output", "json="+shardA, mdFile)
+	require.NoError(t, err)
+	_, err = run("lint", "--config", cfgFile, "--output", "json="+shardB, cleanFile)
+	require.NoError(t, err)
+
+	t.Run("merge and convert", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := run("report", "--color", "never", "--format", "json", shardA, shardB)
+		require.NoError(t, err)
+
+		var merged struct {
+			Files   []json.RawMessage `json:"files"`
+			Summary struct {
+				FilesChecked int `json:"filesChecked"`
+				TotalIssues  int `json:"totalIssues"`
+			} `json:"summary"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &merged))
+		assert.Len(t, merged.Files, 2)
+		assert.Equal(t, 2, merged.Summary.FilesChecked)
+		assert.Positive(t, merged.Summary.TotalIssues)
+
+		out, err = run("report", "--color", "never", "--format", "sarif", shardA)
+		require.NoError(t, err)
+		assert.Contains(t, out, `"ruleId": "MD009"`)
+
+		_, err = run("report", "--strict", "--format", "summary", shardA)
+		require.ErrorIs(t, err, cli.ErrLintIssuesFound)
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := run("report", "diff", "--format", "markdown", shardB, shardA)
+		require.NoError(t, err)
+		assert.Contains(t, out, "### gomdlint:")
+		assert.Contains(t, out, "`no-trailing-spaces`")
+
+		out, err = run("report", "diff", shardA, shardB)
+		require.NoError(t, err)
+		assert.Contains(t, out, "0 introduced")
+
+		_, err = run("report", "diff", "--fail-on-introduced", shardB, shardA)
+		require.ErrorIs(t, err, cli.ErrLintIssuesFound)
+
+		_, err = run("report", "diff", "--format", "xml", shardA, shardB)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects unknown major version", func(t *testing.T) {
+		t.Parallel()
+
+		future := filepath.Join(tmpDir, "future.json")
+		require.NoError(t, os.WriteFile(future, []byte(`{"version":"2.0.0","files":[]}`), 0644))
+		_, err := run("report", future)
+		require.ErrorContains(t, err, "unsupported JSON report version")
+	})
+}
+
+// TestIntegration_Shard tests linting in shards and merging the shard reports.
+func TestIntegration_Shard(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	for i := range 6 {
+		content := testMarkdownWithTrailingSpaces + strings.Repeat("More text.\n", i*3)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, fmt.Sprintf("doc%d.md", i)), []byte(content), 0644))
+	}
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+	run := func(args ...string) (string, error) {
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	shards := make([]string, 0, 3)
+	for index := 1; index <= 3; index++ {
+		shardFile := filepath.Join(tmpDir, fmt.Sprintf("shard-%d.json", index))
+		shards = append(shards, shardFile)
+		out, err := run("lint", "--config", cfgFile, "--color", "never", "--shard", fmt.Sprintf("%d/3", index),
+			"--output", "text", "--output", "json="+shardFile, tmpDir)
+		require.NoError(t, err)
+		assert.Contains(t, out, fmt.Sprintf("Shard %d/3:", index))
+		assert.Contains(t, out, "of 6 files")
+	}
+
+	out, err := run("report", "--format", "json", shards[0], shards[1], shards[2])
+	require.NoError(t, err)
+	var merged struct {
+		Files []json.RawMessage `json:"files"`
+		Shard json.RawMessage   `json:"shard"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &merged))
+	assert.Len(t, merged.Files, 6)
+	assert.Nil(t, merged.Shard)
+
+	_, err = run("report", "--format", "json", shards[0], shards[2])
+	require.ErrorContains(t, err, "missing shards: 2/3")
+
+	_, err = run("report", "--format", "json", "--allow-partial", shards[0], shards[2])
+	require.NoError(t, err)
+
+	_, err = run("lint", "--config", cfgFile, "--shard", "4/3", tmpDir)
+	require.ErrorContains(t, err, "invalid --shard")
+}
+
+// TestIntegration_Stream tests that streamed output matches buffered output.
+func TestIntegration_Stream(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	for i := range 5 {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, fmt.Sprintf("doc%d.md", i)),
+			[]byte(testMarkdownWithTrailingSpaces), 0644))
+	}
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+	run := func(args ...string) string {
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"lint", "--config", cfgFile, "--color", "never"}, args...))
+		require.NoError(t, cmd.Execute())
+		return stdout.String()
+	}
+
+	buffered := run("--output", "text", "--output", "summary="+filepath.Join(tmpDir, "buffered.txt"), tmpDir)
+	streamed := run("--stream", "--output", "text", "--output", "summary="+filepath.Join(tmpDir, "streamed.txt"), tmpDir)
+
+	assert.Equal(t, buffered, streamed)
+	assert.Contains(t, streamed, "doc4.md")
+
+	bufferedSummary, err := os.ReadFile(filepath.Join(tmpDir, "buffered.txt"))
+	require.NoError(t, err)
+	streamedSummary, err := os.ReadFile(filepath.Join(tmpDir, "streamed.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, string(bufferedSummary), string(streamedSummary))
+}
+
+func TestIntegration_UnsafeFixes(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	mdFile := filepath.Join(tmpDir, "doc.md")
+	original := "# Title\n\n**Usage**\n\nRun it.\n"
+	require.NoError(t, os.WriteFile(mdFile, []byte(original), 0644))
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+	run := func(args ...string) string {
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"lint", "--config", cfgFile, "--color", "never", "--fix", "--no-backups"}, args...))
+		require.NoError(t, cmd.Execute())
+		return stdout.String()
+	}
+
+	// Inferring a heading level is unsafe, so --fix holds the fix back.
+	output := run(mdFile)
+	assert.Contains(t, output, "Unsafe fix (applied with --unsafe-fixes):")
+	assert.Contains(t, output, "1 unsafe fix held back (use --unsafe-fixes)")
+	content, err := os.ReadFile(mdFile)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(content))
+
+	output = run("--unsafe-fixes", mdFile)
+	assert.NotContains(t, output, "held back")
+	content, err = os.ReadFile(mdFile)
+	require.NoError(t, err)
+	assert.Equal(t, "# Title\n\n## Usage\n\nRun it.\n", string(content))
+}
+
+func TestIntegration_FixReport(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	mdFile := filepath.Join(tmpDir, "doc.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte("# Title\n\nSome text.   \nMore text.  \n"), 0644))
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	cmd := cli.NewRootCommand(cli.BuildInfo{Version: "test", Commit: "test", Date: "test"})
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"lint", "--config", cfgFile, "--color", "never", "--fix", "--no-backups", "--fix-report", mdFile})
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.String()
+	assert.Contains(t, output, "fix report, 1 pass")
+	assert.Contains(t, output, "pass 1: no-trailing-spaces 2 applied")
+}
+
+func TestIntegration_Interactive(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	mdFile := filepath.Join(tmpDir, "doc.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte("# Title\n\nSome text.   \nMore text.   \n"), 0644))
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+	cmd := cli.NewRootCommand(info)
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetIn(strings.NewReader("n\ny\n"))
+	cmd.SetArgs([]string{"lint", "--config", cfgFile, "--color", "never", "--fix", "--interactive", "--no-backups", mdFile})
+	_ = cmd.Execute()
+
+	assert.Equal(t, 2, strings.Count(stderr.String(), "Apply this fix?"))
+	content, err := os.ReadFile(mdFile)
+	require.NoError(t, err)
+	assert.Equal(t, "# Title\n\nSome text.   \nMore text.\n", string(content))
+
+	// Reviewing needs --fix.
+	cmd = cli.NewRootCommand(info)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"lint", "--config", cfgFile, "--interactive", mdFile})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--interactive requires --fix")
+}
+
+func TestIntegration_DoctorFixStability(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+	stable := filepath.Join(tmpDir, "stable.md")
+	require.NoError(t, os.WriteFile(stable, []byte("# Title\n\nSome text.\n"), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+	run := func(paths ...string) (string, error) {
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"doctor", "--config", cfgFile, "--color", "never", "--fix-stability", "--rule-format", "id"}, paths...))
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run(stable)
+	require.NoError(t, err)
+	assert.Contains(t, output, "All fixes are stable (1 files checked)")
+
+	// Removing the blank lines at the end leaves one that MD012 reports
+	// again, with a fix that changes nothing.
+	unstable := filepath.Join(tmpDir, "unstable.md")
+	original := "# Title\n\n\n\nText.\n\n\n\n"
+	require.NoError(t, os.WriteFile(unstable, []byte(original), 0644))
+
+	output, err = run(stable, unstable)
+	require.ErrorIs(t, err, cli.ErrLintIssuesFound)
+	assert.Contains(t, output, "not-idempotent  fixing the output of MD012 fixes changes it again")
+	assert.Contains(t, output, "1 unstable fix in 1 of 2 files")
+
+	content, err := os.ReadFile(unstable)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(content))
+}
+
+func TestIntegration_ColumnEncoding(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	docFile := filepath.Join(tmpDir, "doc.md")
+	require.NoError(t, os.WriteFile(docFile, []byte("# Café\n\nnaïve 😀 text  \n"), 0644))
+
+	cfgFile := filepath.Join(tmpDir, ".gomdlint.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("flavor: commonmark\n"), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+	run := func(args ...string) (string, error) {
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+	trailingColumn := func(output string) (string, int) {
+		report, err := reporter.ReadJSON(strings.NewReader(output))
+		require.NoError(t, err)
+		for _, diag := range report.Files[0].Diagnostics {
+			if diag.RuleID == "MD009" {
+				return report.ColumnEncoding, diag.StartColumn
+			}
+		}
+		t.Fatalf("no MD009 diagnostic in %s", output)
+		return "", 0
+	}
+
+	output, _ := run("lint", "--config", cfgFile, "--format", "json", "--column-encoding", "utf-16", docFile)
+	encoding, column := trailingColumn(output)
+	assert.Equal(t, "utf-16", encoding)
+	assert.Equal(t, 14, column)
+
+	reportFile := filepath.Join(tmpDir, "report.json")
+	require.NoError(t, os.WriteFile(reportFile, []byte(output), 0644))
+	converted, _ := run("report", "--format", "json", "--column-encoding", "utf-8", reportFile)
+	encoding, column = trailingColumn(converted)
+	assert.Equal(t, "utf-8", encoding)
+	assert.Equal(t, 17, column)
+
+	_, err := run("lint", "--config", cfgFile, "--column-encoding", "latin1", docFile)
+	require.ErrorContains(t, err, "--column-encoding")
+}
+
+// TestIntegration_BackupDirectory changes the working directory, which
+// the backup commands use, so it does not run in parallel.
+func TestIntegration_BackupDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	require.NoError(t, os.WriteFile(".gomdlint.yml", []byte("backups:\n  enabled: true\n  mode: directory\n"), 0644))
+	original := "# Title\n\nText  \n"
+	require.NoError(t, os.MkdirAll("docs", 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("docs", "doc.md"), []byte(original), 0644))
+
+	info := cli.BuildInfo{Version: "test", Commit: "test", Date: "test"}
+	run := func(args ...string) (string, error) {
+		cmd := cli.NewRootCommand(info)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append(args, "--color", "never"))
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	_, err := run("lint", "--fix", "docs")
+	require.NoError(t, err)
+	fixed, err := os.ReadFile(filepath.Join("docs", "doc.md"))
+	require.NoError(t, err)
+	assert.NotEqual(t, original, string(fixed))
+	assert.NoFileExists(t, filepath.Join("docs", "doc.md"+fsutil.BackupSuffix))
+
+	output, err := run("backup", "list")
+	require.NoError(t, err)
+	assert.Contains(t, output, "1 file")
+
+	output, err = run("backup", "restore", "docs")
+	require.NoError(t, err)
+	assert.Contains(t, output, "restored docs/doc.md")
+	restored, err := os.ReadFile(filepath.Join("docs", "doc.md"))
+	require.NoError(t, err)
+	assert.Equal(t, original, string(restored))
+
+	output, err = run("backup", "prune", "--older-than", "7d")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Removed 0 backups")
+
+	output, err = run("backup", "prune", "--older-than", "0s")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Removed 1 backup")
+
+	output, err = run("backup", "list")
+	require.NoError(t, err)
+	assert.Contains(t, output, "No backups in .gomdlint/backups")
+}
@@ -13,9 +13,12 @@ import (
 
 	"github.com/yaklabco/gomdlint/internal/configloader"
 	"github.com/yaklabco/gomdlint/internal/logging"
+	"github.com/yaklabco/gomdlint/internal/ui/pretty"
+	"github.com/yaklabco/gomdlint/internal/ui/review"
 	"github.com/yaklabco/gomdlint/pkg/config"
 	"github.com/yaklabco/gomdlint/pkg/lint"
 	_ "github.com/yaklabco/gomdlint/pkg/lint/rules" // Register built-in rules
+	"github.com/yaklabco/gomdlint/pkg/mdast"
 	goldmarkparser "github.com/yaklabco/gomdlint/pkg/parser/goldmark"
 	"github.com/yaklabco/gomdlint/pkg/reporter"
 	"github.com/yaklabco/gomdlint/pkg/runner"
@@ -25,24 +28,34 @@ import (
 var ErrLintIssuesFound = errors.New("lint issues found")
 
 type lintFlags struct {
-	format       string
-	flavor       string
-	ignore       []string
-	enable       []string
-	disable      []string
-	fixRules     []string
-	strict       bool
-	noContext    bool
-	compact      bool
-	perFile      bool
-	ruleFormat   string
-	summaryOrder string
-	cpuprofile   string
-	memprofile   string
-	trace        string
+	format         string
+	outputs        []string
+	flavor         string
+	encoding       string
+	ignore         []string
+	enable         []string
+	disable        []string
+	fixRules       []string
+	strict         bool
+	noContext      bool
+	contextBefore  int
+	contextAfter   int
+	compact        bool
+	perFile        bool
+	fixReport      bool
+	interactive    bool
+	ruleFormat     string
+	summaryOrder   string
+	junitGroupBy   string
+	columnEncoding string
+	shard          string
+	stream         bool
+	cpuprofile     string
+	memprofile     string
+	trace          string
 }
 
-func newLintCommand() *cobra.Command {
+func newLintCommand(info BuildInfo) *cobra.Command {
 	var cfg config.Config
 	flags := &lintFlags{}
 
@@ -52,7 +65,7 @@ func newLintCommand() *cobra.Command {
 		Long:  lintLongDescription,
 		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLint(cmd, args, &cfg, flags)
+			return runLint(cmd, args, &cfg, flags, info.Version)
 		},
 	}
 
@@ -63,8 +76,9 @@ func newLintCommand() *cobra.Command {
 
 const lintLongDescription = `Lint Markdown files for style and syntax issues.
 
-By default, lints all .md and .markdown files in the current directory
-and subdirectories. Specify paths to lint specific files or directories.
+By default, lints all .md and .markdown files (plus .mdx with --flavor mdx)
+in the current directory and subdirectories. Specify paths to lint specific
+files or directories.
 
 Examples:
   mdlint lint                    # Lint current directory
@@ -72,8 +86,15 @@ Examples:
   mdlint lint README.md          # Lint single file
   mdlint lint --fix              # Lint and auto-fix issues
   mdlint lint --fix --dry-run    # Show fixes without applying
+  mdlint lint --fix --interactive
+                                 # Review each fix before applying it
   mdlint lint --format json      # Output as JSON for CI
-  mdlint lint --strict           # Treat warnings as errors`
+  mdlint lint --format github    # Annotate GitHub pull requests
+  mdlint lint --output text --output sarif=results.sarif
+                                 # Text log plus a SARIF file
+  mdlint lint --strict           # Treat warnings as errors
+  mdlint lint --shard 2/8 --output json=shard-2.json
+                                 # Lint one of 8 partitions in CI`
 
 // profileCleanup holds cleanup functions for profiling.
 type profileCleanup struct {
@@ -125,7 +146,7 @@ func setupProfiling(flags *lintFlags) (*profileCleanup, error) {
 	return cleanup, nil
 }
 
-func runLint(cmd *cobra.Command, args []string, cfg *config.Config, flags *lintFlags) error {
+func runLint(cmd *cobra.Command, args []string, cfg *config.Config, flags *lintFlags, version string) error {
 	logger := logging.Default()
 
 	// Setup profiling.
@@ -141,11 +162,22 @@ func runLint(cmd *cobra.Command, args []string, cfg *config.Config, flags *lintF
 	if cmd.Flags().Changed("flavor") {
 		cfg.Flavor = config.Flavor(flags.flavor)
 	}
+	if cmd.Flags().Changed("encoding") {
+		cfg.Encoding = config.EncodingPolicy(flags.encoding)
+	}
 	cfg.Ignore = flags.ignore
 	cfg.EnableRules = flags.enable
 	cfg.DisableRules = flags.disable
 	cfg.FixRules = flags.fixRules
 
+	if len(flags.outputs) > 0 {
+		outputs, err := parseOutputFlags(flags.outputs)
+		if err != nil {
+			return err
+		}
+		cfg.Outputs = outputs
+	}
+
 	// Load and merge configuration.
 	ctx := cmd.Context()
 	if ctx == nil {
@@ -195,6 +227,11 @@ func runLint(cmd *cobra.Command, args []string, cfg *config.Config, flags *lintF
 		"jobs", finalCfg.Jobs,
 	)
 
+	// Reviewing fixes needs fixes to review.
+	if flags.interactive && !finalCfg.Fix {
+		return errors.New("--interactive requires --fix")
+	}
+
 	// Create the parser based on flavor.
 	parser := goldmarkparser.New(string(finalCfg.Flavor))
 
@@ -210,13 +247,32 @@ func runLint(cmd *cobra.Command, args []string, cfg *config.Config, flags *lintF
 	// Create the runner.
 	lintRunner := runner.New(pipeline)
 
+	// Parse the shard selection.
+	var shard runner.Shard
+	if flags.shard != "" {
+		shard, err = runner.ParseShard(flags.shard)
+		if err != nil {
+			return fmt.Errorf("invalid --shard: %w", err)
+		}
+	}
+
+	// Parse the column encoding; empty keeps each format's default.
+	var columnEncoding mdast.PositionEncoding
+	if flags.columnEncoding != "" {
+		columnEncoding, err = mdast.ParsePositionEncoding(flags.columnEncoding)
+		if err != nil {
+			return fmt.Errorf("invalid --column-encoding: %w", err)
+		}
+	}
+
 	// Build runner options.
 	runOpts := runner.Options{
 		Paths:        args,
 		WorkingDir:   workDir,
-		Extensions:   runner.DefaultExtensions(),
+		Extensions:   runner.DefaultExtensionsForFlavor(finalCfg.Flavor),
 		ExcludeGlobs: finalCfg.Ignore,
 		Jobs:         finalCfg.Jobs,
+		Shard:        shard,
 		Config:       finalCfg,
 	}
 
@@ -226,45 +282,79 @@ func runLint(cmd *cobra.Command, args []string, cfg *config.Config, flags *lintF
 		"jobs", runOpts.Jobs,
 	)
 
-	// Run linting.
-	result, err := lintRunner.Run(ctx, runOpts)
-	if err != nil {
-		return errors.Join(errors.New("lint run failed"), err)
-	}
-
 	// Get color mode from persistent flag.
 	colorMode, err := cmd.Flags().GetString("color")
 	if err != nil {
 		colorMode = "auto" // Default to auto if flag retrieval fails
 	}
 
-	// Parse output format.
-	format, err := reporter.ParseFormat(flags.format)
+	// Resolve the outputs to write.
+	outputs, err := lintOutputs(cmd, flags, finalCfg)
 	if err != nil {
-		return fmt.Errorf("invalid format: %w", err)
+		return err
 	}
 
 	// Create reporter.
-	rep, err := reporter.New(reporter.Options{
-		Writer:       cmd.OutOrStdout(),
-		ErrorWriter:  cmd.ErrOrStderr(),
-		Format:       format,
-		Color:        colorMode,
-		ShowContext:  !flags.noContext,
-		ShowSummary:  true,
-		GroupByFile:  true,
-		Compact:      flags.compact,
-		PerFile:      flags.perFile,
-		RuleFormat:   config.RuleFormat(flags.ruleFormat),
-		SummaryOrder: config.SummaryOrder(flags.summaryOrder),
-		WorkingDir:   workDir,
-	})
+	rep, closeOutputs, err := reporter.NewOutputs(reporter.Options{
+		Writer:         cmd.OutOrStdout(),
+		ErrorWriter:    cmd.ErrOrStderr(),
+		Color:          colorMode,
+		ShowContext:    !flags.noContext,
+		ContextBefore:  flags.contextBefore,
+		ContextAfter:   flags.contextAfter,
+		ShowSummary:    true,
+		GroupByFile:    true,
+		Compact:        flags.compact,
+		PerFile:        flags.perFile,
+		FixReport:      flags.fixReport,
+		RuleFormat:     config.RuleFormat(flags.ruleFormat),
+		SummaryOrder:   config.SummaryOrder(flags.summaryOrder),
+		JUnitGroupBy:   reporter.JUnitGrouping(flags.junitGroupBy),
+		ColumnEncoding: columnEncoding,
+		WorkingDir:     workDir,
+		ToolVersion:    version,
+	}, outputs)
 	if err != nil {
 		return fmt.Errorf("create reporter: %w", err)
 	}
 
-	// Report results.
-	if _, err := rep.Report(ctx, result); err != nil {
+	// Ask about each fix on stderr, keeping stdout for the report.
+	if flags.interactive {
+		runOpts.Reviewer = review.New(review.Options{
+			In:         cmd.InOrStdin(),
+			Out:        cmd.ErrOrStderr(),
+			Styles:     pretty.NewStyles(pretty.IsColorEnabled(colorMode, cmd.ErrOrStderr())),
+			RuleFormat: config.RuleFormat(flags.ruleFormat),
+			Frame: pretty.FrameOptions{
+				ContextBefore: flags.contextBefore,
+				ContextAfter:  flags.contextAfter,
+			},
+		})
+	}
+
+	// Run linting and report results. When streaming, each file is reported
+	// as soon as it and the files before it are done, and is then released.
+	var result *runner.Result
+	var reportErr error
+	if flags.stream {
+		stream := reporter.NewStream(rep)
+		result, err = lintRunner.Stream(ctx, runOpts, func(outcome runner.FileOutcome) error {
+			_, err := stream.ReportFile(ctx, outcome)
+			return err
+		})
+		if err == nil {
+			_, reportErr = stream.Finish(ctx, result)
+		}
+	} else {
+		result, err = lintRunner.Run(ctx, runOpts)
+		if err == nil {
+			_, reportErr = rep.Report(ctx, result)
+		}
+	}
+	if err != nil {
+		return errors.Join(errors.New("lint run failed"), err, closeOutputs())
+	}
+	if err := errors.Join(reportErr, closeOutputs()); err != nil {
 		logger.Error("report failed", "error", err)
 		return fmt.Errorf("report results: %w", err)
 	}
@@ -291,25 +381,87 @@ func runLint(cmd *cobra.Command, args []string, cfg *config.Config, flags *lintF
 	return nil
 }
 
+// parseOutputFlags parses --output values of the form "format" or
+// "format=path".
+func parseOutputFlags(specs []string) ([]config.OutputConfig, error) {
+	outputs := make([]config.OutputConfig, 0, len(specs))
+	for _, spec := range specs {
+		output, err := reporter.ParseOutput(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --output: %w", err)
+		}
+		outputs = append(outputs, config.OutputConfig{
+			Format: config.OutputFormat(output.Format),
+			Path:   output.Path,
+		})
+	}
+	return outputs, nil
+}
+
+// lintOutputs returns the reports to write. An explicit --format writes a
+// single report to stdout; otherwise the configured outputs (from --output
+// or the config file) are used, falling back to the default format.
+func lintOutputs(cmd *cobra.Command, flags *lintFlags, cfg *config.Config) ([]reporter.Output, error) {
+	if cmd.Flags().Changed("format") || len(cfg.Outputs) == 0 {
+		format, err := reporter.ParseFormat(flags.format)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format: %w", err)
+		}
+		return []reporter.Output{{Format: format}}, nil
+	}
+
+	outputs := make([]reporter.Output, 0, len(cfg.Outputs))
+	for _, output := range cfg.Outputs {
+		outputs = append(outputs, reporter.Output{
+			Format: reporter.Format(output.Format),
+			Path:   output.Path,
+		})
+	}
+	return outputs, nil
+}
+
 func addLintFlags(cmd *cobra.Command, cfg *config.Config, flags *lintFlags) {
 	cmd.Flags().BoolVar(&cfg.Fix, "fix", false, "automatically fix issues")
 	cmd.Flags().BoolVar(&cfg.DryRun, "dry-run", false, "show fixes without applying them")
-	cmd.Flags().StringVar(&flags.format, "format", "text", "output format: text, table, json, sarif, diff, summary")
+	cmd.Flags().StringVar(&flags.format, "format", "text", "output format: text, table, json, jsonl, sarif, diff, summary, html, github, gitlab, rdjsonl, junit, checkstyle")
+	cmd.Flags().StringArrayVar(&flags.outputs, "output", nil,
+		"write a report as format[=path]; repeatable, path defaults to stdout")
+	cmd.MarkFlagsMutuallyExclusive("format", "output")
 	cmd.Flags().IntVar(&cfg.Jobs, "jobs", 0, "number of parallel workers (0 = auto)")
 	cmd.Flags().StringSliceVar(&flags.ignore, "ignore", nil, "glob patterns to ignore")
 	cmd.Flags().StringSliceVar(&flags.enable, "enable", nil, "rule IDs to enable")
 	cmd.Flags().StringSliceVar(&flags.disable, "disable", nil, "rule IDs to disable")
 	cmd.Flags().StringSliceVar(&flags.fixRules, "fix-rules", nil, "limit auto-fix to specific rule IDs")
 	cmd.Flags().BoolVar(&cfg.NoBackups, "no-backups", false, "disable backup creation when fixing")
-	cmd.Flags().StringVar(&flags.flavor, "flavor", "commonmark", "Markdown flavor: commonmark, gfm")
+	cmd.Flags().BoolVar(&cfg.UnsafeFixes, "unsafe-fixes", false,
+		"also apply unsafe fixes, such as guessed code block languages")
+	cmd.Flags().BoolVar(&cfg.VerifyFixes, "verify-fixes", false,
+		"reject fixes that change the rendered HTML, except from rules meant to change it")
+	cmd.Flags().BoolVar(&flags.interactive, "interactive", false,
+		"review each fix before it is applied; requires --fix")
+	cmd.Flags().BoolVar(&flags.fixReport, "fix-report", false,
+		"explain the fix passes: edits applied and skipped by rule, conflicts, and rules undoing each other")
+	cmd.Flags().StringVar(&flags.flavor, "flavor", "commonmark", "Markdown flavor: commonmark, gfm, mdx, obsidian, mkdocs, pandoc")
+	cmd.Flags().StringVar(&flags.encoding, "encoding", "skip",
+		"files that are not UTF-8: skip, transcode (lint a UTF-8 copy) or convert (write UTF-8 with --fix)")
 	cmd.Flags().BoolVar(&flags.strict, "strict", false, "treat warnings as errors for exit code")
 	cmd.Flags().BoolVar(&flags.noContext, "no-context", false, "hide source line context in output")
+	cmd.Flags().IntVar(&flags.contextBefore, "context-before", 0, "source lines to show before each diagnostic in text output")
+	cmd.Flags().IntVar(&flags.contextAfter, "context-after", 0, "source lines to show after each diagnostic in text output")
 	cmd.Flags().BoolVar(&flags.compact, "compact", false, "use compact output format")
 	cmd.Flags().BoolVar(&flags.perFile, "per-file", false, "output separate report for each file (table format)")
 	cmd.Flags().StringVar(&flags.ruleFormat, "rule-format", "name",
 		"rule identifier format in output: name, id, or combined")
 	cmd.Flags().StringVar(&flags.summaryOrder, "summary-order", "rules",
 		"order of tables in summary output: rules, files")
+	cmd.Flags().StringVar(&flags.junitGroupBy, "junit-group-by", "file",
+		"JUnit testcase per file or per rule: file, rule")
+	cmd.Flags().StringVar(&flags.columnEncoding, "column-encoding", "",
+		"column units in output: utf-8 (bytes), utf-16 or utf-32 (code points); default utf-16 for sarif, utf-8 otherwise")
+	cmd.Flags().StringVar(&flags.shard, "shard", "",
+		"lint only partition index/count of the files, e.g. 2/8, for parallel CI jobs")
+	cmd.Flags().BoolVar(&flags.stream, "stream", false,
+		"report each file as soon as it is checked instead of after the run, using less memory")
 
 	// Profiling flags.
 	cmd.Flags().StringVar(&flags.cpuprofile, "cpuprofile", "", "write CPU profile to file")
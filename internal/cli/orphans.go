@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/orphans"
+	goldmarkparser "github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+)
+
+// orphansFlags holds the flags for the orphans command.
+type orphansFlags struct {
+	format          string
+	entryPoints     []string
+	assets          []string
+	assetExtensions []string
+	ignore          []string
+	report          bool
+	strict          bool
+	ruleFormat      string
+}
+
+func newOrphansCommand(info BuildInfo) *cobra.Command {
+	flags := &orphansFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "orphans [paths...]",
+		Short: "Report orphaned pages and unused assets",
+		Long: `Find Markdown pages that cannot be reached by following links from the
+entry points, and asset files (images by default) that no page references.
+
+Entry points default to README.md, index.md and the nav of mkdocs.yml in the
+current directory. Configure them, the asset directories and the asset
+extensions in the orphans section of the configuration, or with flags.
+
+Findings are reported as MDL012 (orphaned-page) and MDL013 (unused-asset)
+warnings in any lint output format, or as a JSON report with --report.
+
+Examples:
+  gomdlint orphans                          Check the current directory
+  gomdlint orphans docs/ --entry docs/index.md
+  gomdlint orphans --assets docs/assets     Only check assets under docs/assets
+  gomdlint orphans --report > orphans.json  Write a JSON report`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOrphans(cmd, args, flags, info.Version)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.format, "format", "text",
+		"output format: text, table, json, jsonl, sarif, summary, html, github, gitlab, rdjsonl, junit, checkstyle")
+	cmd.Flags().StringSliceVar(&flags.entryPoints, "entry", nil,
+		"entry point page, glob, or mkdocs.yml (repeatable; overrides orphans.entry_points)")
+	cmd.Flags().StringSliceVar(&flags.assets, "assets", nil,
+		"directories searched for assets (repeatable; overrides orphans.assets)")
+	cmd.Flags().StringSliceVar(&flags.assetExtensions, "asset-ext", nil,
+		"asset file extensions, e.g. .png (repeatable; overrides orphans.asset_extensions)")
+	cmd.Flags().StringSliceVar(&flags.ignore, "ignore", nil, "glob patterns to ignore (repeatable)")
+	cmd.Flags().BoolVar(&flags.report, "report", false, "write a JSON report instead of diagnostics")
+	cmd.Flags().BoolVar(&flags.strict, "strict", false, "treat warnings as errors for exit code")
+	cmd.Flags().StringVar(&flags.ruleFormat, "rule-format", "name",
+		"rule identifier format in output: name, id, or combined")
+
+	return cmd
+}
+
+func runOrphans(cmd *cobra.Command, args []string, flags *orphansFlags, version string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cfg, err := loadProjectConfig(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if flags.entryPoints != nil {
+		cfg.Orphans.EntryPoints = flags.entryPoints
+	}
+	if flags.assets != nil {
+		cfg.Orphans.Assets = flags.assets
+	}
+	if flags.assetExtensions != nil {
+		cfg.Orphans.AssetExtensions = flags.assetExtensions
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	report, err := orphans.Analyze(ctx, orphans.Options{
+		Paths:           args,
+		WorkingDir:      workDir,
+		Extensions:      cfg.Flavor.FileExtensions(),
+		ExcludeGlobs:    slices.Concat(cfg.Ignore, flags.ignore),
+		EntryPoints:     cfg.Orphans.EntryPoints,
+		AssetPaths:      cfg.Orphans.Assets,
+		AssetExtensions: cfg.Orphans.AssetExtensions,
+		Parser:          goldmarkparser.New(string(cfg.Flavor)),
+		Flavor:          cfg.Flavor,
+	})
+	if err != nil {
+		return fmt.Errorf("find orphans: %w", err)
+	}
+
+	result := report.Result(workDir)
+
+	if flags.report {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("encode report: %w", err)
+		}
+	} else {
+		format, err := reporter.ParseFormat(flags.format)
+		if err != nil {
+			return fmt.Errorf("invalid format: %w", err)
+		}
+
+		colorMode, err := cmd.Flags().GetString("color")
+		if err != nil {
+			colorMode = "auto"
+		}
+
+		rep, err := reporter.New(reporter.Options{
+			Writer:      cmd.OutOrStdout(),
+			ErrorWriter: cmd.ErrOrStderr(),
+			Format:      format,
+			Color:       colorMode,
+			ShowSummary: true,
+			GroupByFile: true,
+			RuleFormat:  config.RuleFormat(flags.ruleFormat),
+			WorkingDir:  workDir,
+			ToolVersion: version,
+		})
+		if err != nil {
+			return fmt.Errorf("create reporter: %w", err)
+		}
+		if _, err := rep.Report(ctx, result); err != nil {
+			return fmt.Errorf("report results: %w", err)
+		}
+	}
+
+	if ExitCodeFromResult(result, flags.strict) != ExitSuccess {
+		return ErrLintIssuesFound
+	}
+	return nil
+}
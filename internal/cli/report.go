@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaklabco/gomdlint/pkg/analysis"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+)
+
+// reportFlags holds the flags for the report command.
+type reportFlags struct {
+	format         string
+	outputs        []string
+	strict         bool
+	noSource       bool
+	contextBefore  int
+	contextAfter   int
+	allowPartial   bool
+	ruleFormat     string
+	summaryOrder   string
+	junitGroupBy   string
+	columnEncoding string
+}
+
+// reportDiffFlags holds the flags for the report diff command.
+type reportDiffFlags struct {
+	format           string
+	ruleFormat       string
+	failOnIntroduced bool
+}
+
+func newReportCommand(info BuildInfo) *cobra.Command {
+	flags := &reportFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "report <report.json>...",
+		Short: "Merge and convert JSON reports",
+		Long: `Read one or more reports written by "gomdlint lint --format json", merge
+them, and render the result in any output format.
+
+Use it to combine the reports of sharded CI jobs, or to produce SARIF, HTML
+or summary output from a stored report without linting again. A report path
+of "-" reads standard input. When a file appears in several reports, the last
+one wins. Reports from "lint --shard" must form a complete set of shards
+without overlap, unless --allow-partial is given.
+
+Absolute paths are rebased onto the current directory, and source files are
+read from it for context and fixes; pass --no-source to skip reading them.
+Columns are converted to --column-encoding using the source files, so
+without them they keep the encoding of the reports.
+
+Examples:
+  gomdlint report lint.json --format html > report.html
+  gomdlint report shard-*.json --output sarif=merged.sarif --output summary
+  gomdlint report diff base.json head.json --format markdown`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReport(cmd, args, flags, info.Version)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.format, "format", "text",
+		"output format: text, table, json, jsonl, sarif, summary, html, github, gitlab, rdjsonl, junit, checkstyle")
+	cmd.Flags().StringArrayVar(&flags.outputs, "output", nil,
+		"write a report as format[=path]; repeatable, path defaults to stdout")
+	cmd.MarkFlagsMutuallyExclusive("format", "output")
+	cmd.Flags().BoolVar(&flags.strict, "strict", false, "treat warnings as errors for exit code")
+	cmd.Flags().BoolVar(&flags.noSource, "no-source", false, "do not read source files for context")
+	cmd.Flags().IntVar(&flags.contextBefore, "context-before", 0, "source lines to show before each diagnostic in text output")
+	cmd.Flags().IntVar(&flags.contextAfter, "context-after", 0, "source lines to show after each diagnostic in text output")
+	cmd.Flags().BoolVar(&flags.allowPartial, "allow-partial", false, "merge sharded reports even if shards are missing")
+	cmd.Flags().StringVar(&flags.ruleFormat, "rule-format", "name",
+		"rule identifier format in output: name, id, or combined")
+	cmd.Flags().StringVar(&flags.summaryOrder, "summary-order", "rules",
+		"order of tables in summary output: rules, files")
+	cmd.Flags().StringVar(&flags.junitGroupBy, "junit-group-by", "file",
+		"JUnit testcase per file or per rule: file, rule")
+	cmd.Flags().StringVar(&flags.columnEncoding, "column-encoding", "",
+		"column units in output: utf-8 (bytes), utf-16 or utf-32 (code points); default utf-16 for sarif, utf-8 otherwise")
+
+	cmd.AddCommand(newReportDiffCommand())
+
+	return cmd
+}
+
+func newReportDiffCommand() *cobra.Command {
+	flags := &reportDiffFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "diff <old.json> <new.json>",
+		Short: "Show diagnostics introduced and resolved between two reports",
+		Long: `Compare two JSON reports and list the diagnostics that were introduced and
+resolved, with totals per rule and per file.
+
+Diagnostics are matched by file, rule and message, so findings that only
+moved because of edits elsewhere in the file count as unchanged.
+
+The markdown format is meant for pull request comments; the json format
+can be stored to track quality over time.
+
+Examples:
+  gomdlint report diff base.json head.json
+  gomdlint report diff base.json head.json --format markdown > comment.md
+  gomdlint report diff base.json head.json --fail-on-introduced`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReportDiff(cmd, args[0], args[1], flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.format, "format", "text", "output format: text, markdown, json")
+	cmd.Flags().StringVar(&flags.ruleFormat, "rule-format", "name",
+		"rule identifier format in output: name, id, or combined")
+	cmd.Flags().BoolVar(&flags.failOnIntroduced, "fail-on-introduced", false,
+		"exit with an error if any diagnostics were introduced")
+
+	return cmd
+}
+
+func runReport(cmd *cobra.Command, args []string, flags *reportFlags, version string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	reports := make([]*reporter.JSONOutput, 0, len(args))
+	for _, path := range args {
+		report, err := readReport(cmd, path, workDir)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	if err := reporter.CheckShards(flags.allowPartial, reports...); err != nil {
+		return fmt.Errorf("merge reports: %w", err)
+	}
+	if err := reporter.CheckColumnEncodings(reports...); err != nil {
+		return fmt.Errorf("merge reports: %w", err)
+	}
+
+	var columnEncoding mdast.PositionEncoding
+	if flags.columnEncoding != "" {
+		columnEncoding, err = mdast.ParsePositionEncoding(flags.columnEncoding)
+		if err != nil {
+			return fmt.Errorf("invalid --column-encoding: %w", err)
+		}
+	}
+
+	readSource := os.ReadFile
+	if flags.noSource {
+		readSource = nil
+	}
+	result := reporter.MergeJSON(reports...).Result(readSource)
+
+	outputs, err := reportOutputs(flags)
+	if err != nil {
+		return err
+	}
+
+	colorMode, err := cmd.Flags().GetString("color")
+	if err != nil {
+		colorMode = "auto"
+	}
+
+	rep, closeOutputs, err := reporter.NewOutputs(reporter.Options{
+		Writer:         cmd.OutOrStdout(),
+		ErrorWriter:    cmd.ErrOrStderr(),
+		Color:          colorMode,
+		ShowContext:    !flags.noSource,
+		ContextBefore:  flags.contextBefore,
+		ContextAfter:   flags.contextAfter,
+		ShowSummary:    true,
+		GroupByFile:    true,
+		RuleFormat:     config.RuleFormat(flags.ruleFormat),
+		SummaryOrder:   config.SummaryOrder(flags.summaryOrder),
+		JUnitGroupBy:   reporter.JUnitGrouping(flags.junitGroupBy),
+		ColumnEncoding: columnEncoding,
+		WorkingDir:     workDir,
+		ToolVersion:    version,
+	}, outputs)
+	if err != nil {
+		return fmt.Errorf("create reporter: %w", err)
+	}
+
+	_, reportErr := rep.Report(ctx, result)
+	if err := errors.Join(reportErr, closeOutputs()); err != nil {
+		return fmt.Errorf("report results: %w", err)
+	}
+
+	if ExitCodeFromResult(result, flags.strict) != ExitSuccess {
+		return ErrLintIssuesFound
+	}
+	return nil
+}
+
+func runReportDiff(cmd *cobra.Command, oldPath, newPath string, flags *reportDiffFlags) error {
+	format := reporter.ComparisonFormat(flags.format)
+	if !format.IsValid() {
+		return fmt.Errorf("invalid format: %q (valid: text, markdown, json)", flags.format)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	oldReport, err := readReport(cmd, oldPath, workDir)
+	if err != nil {
+		return err
+	}
+	newReport, err := readReport(cmd, newPath, workDir)
+	if err != nil {
+		return err
+	}
+
+	comparison := analysis.Compare(oldReport.Result(nil), newReport.Result(nil), analysis.Options{
+		WorkingDir: workDir,
+	})
+
+	if err := reporter.WriteComparison(cmd.OutOrStdout(), comparison, format,
+		config.RuleFormat(flags.ruleFormat)); err != nil {
+		return err
+	}
+
+	if flags.failOnIntroduced && comparison.HasIntroduced() {
+		return ErrLintIssuesFound
+	}
+	return nil
+}
+
+// readReport reads a JSON report from path, or from standard input if path
+// is "-", and rebases it onto workDir.
+func readReport(cmd *cobra.Command, path, workDir string) (*reporter.JSONOutput, error) {
+	var report *reporter.JSONOutput
+	if path == reporter.StdoutPath {
+		var err error
+		report, err = reporter.ReadJSON(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("read report from stdin: %w", err)
+		}
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open report: %w", err)
+		}
+		defer file.Close()
+
+		report, err = reporter.ReadJSON(file)
+		if err != nil {
+			return nil, fmt.Errorf("read report %s: %w", path, err)
+		}
+	}
+
+	report.Rebase(workDir)
+	return report, nil
+}
+
+// reportOutputs returns the reports to write: the --output values, or a
+// single --format report to stdout.
+func reportOutputs(flags *reportFlags) ([]reporter.Output, error) {
+	if len(flags.outputs) == 0 {
+		format, err := reporter.ParseFormat(flags.format)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format: %w", err)
+		}
+		return []reporter.Output{{Format: format}}, nil
+	}
+
+	outputs := make([]reporter.Output, 0, len(flags.outputs))
+	for _, spec := range flags.outputs {
+		output, err := reporter.ParseOutput(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --output: %w", err)
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
@@ -47,10 +47,15 @@ and optional backups.`,
 		"colorize output: auto, always, never")
 
 	// Add subcommands.
-	rootCmd.AddCommand(newLintCommand())
+	rootCmd.AddCommand(newLintCommand(info))
 	rootCmd.AddCommand(newRulesCommand())
 	rootCmd.AddCommand(newInitCommand())
 	rootCmd.AddCommand(newMigrateCommand())
+	rootCmd.AddCommand(newDetectLangCommand())
+	rootCmd.AddCommand(newOrphansCommand(info))
+	rootCmd.AddCommand(newReportCommand(info))
+	rootCmd.AddCommand(newDoctorCommand())
+	rootCmd.AddCommand(newBackupCommand())
 	rootCmd.AddCommand(newVersionCommand(info))
 
 	// Apply styled help formatting.
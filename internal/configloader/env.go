@@ -42,6 +42,9 @@ var envMappings = map[string]envMapping{
 	"BACKUPS_MODE":     {field: "backups.mode", typ: envTypeString},
 	"IGNORE":           {field: "ignore", typ: envTypeSlice},
 	"NO_BACKUPS":       {field: "no_backups", typ: envTypeBool},
+	"UNSAFE_FIXES":     {field: "unsafe_fixes", typ: envTypeBool},
+	"VERIFY_FIXES":     {field: "verify_fixes", typ: envTypeBool},
+	"ENCODING":         {field: "encoding", typ: envTypeString},
 }
 
 // LoadFromEnv applies environment variable overrides to the configuration.
@@ -120,6 +123,8 @@ func setStringField(cfg *config.Config, field, value string) error {
 		cfg.Format = config.OutputFormat(value)
 	case "backups.mode":
 		cfg.Backups.Mode = value
+	case "encoding":
+		cfg.Encoding = config.EncodingPolicy(value)
 	default:
 		return fmt.Errorf("unknown string field: %s", field)
 	}
@@ -137,6 +142,10 @@ func setBoolField(cfg *config.Config, field string, value bool) error {
 		cfg.Backups.Enabled = value
 	case "no_backups":
 		cfg.NoBackups = value
+	case "unsafe_fixes":
+		cfg.UnsafeFixes = value
+	case "verify_fixes":
+		cfg.VerifyFixes = value
 	default:
 		return fmt.Errorf("unknown boolean field: %s", field)
 	}
@@ -178,15 +187,18 @@ func GetEnvVarName(field string) string {
 // ListEnvVars returns a list of all supported environment variables with their descriptions.
 func ListEnvVars() map[string]string {
 	return map[string]string{
-		"GOMDLINT_FLAVOR":           "Markdown flavor: commonmark or gfm",
+		"GOMDLINT_FLAVOR":           "Markdown flavor: commonmark, gfm, mdx, obsidian, mkdocs, or pandoc",
 		"GOMDLINT_SEVERITY_DEFAULT": "Default severity: error, warning, or info",
 		"GOMDLINT_FIX":              "Enable auto-fix: true or false",
 		"GOMDLINT_DRY_RUN":          "Dry-run mode: true or false",
 		"GOMDLINT_JOBS":             "Number of parallel workers (0 = auto)",
 		"GOMDLINT_FORMAT":           "Output format: text, json, sarif, or diff",
 		"GOMDLINT_BACKUPS_ENABLED":  "Enable backups when fixing: true or false",
-		"GOMDLINT_BACKUPS_MODE":     "Backup mode: sidecar or none",
+		"GOMDLINT_BACKUPS_MODE":     "Backup mode: sidecar, directory or none",
 		"GOMDLINT_IGNORE":           "Comma-separated list of ignore patterns",
 		"GOMDLINT_NO_BACKUPS":       "Disable backups: true or false",
+		"GOMDLINT_UNSAFE_FIXES":     "Also apply unsafe fixes: true or false",
+		"GOMDLINT_VERIFY_FIXES":     "Reject fixes that change the rendered HTML: true or false",
+		"GOMDLINT_ENCODING":         "Files that are not UTF-8: skip, transcode or convert",
 	}
 }
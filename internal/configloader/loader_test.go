@@ -213,6 +213,273 @@ flavor: invalid-flavor
 	}
 }
 
+func TestLoad_LangDetect(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	configContent := `
+langdetect:
+  candidates: [go, python, console]
+  aliases:
+    python: py
+  min_confidence: 0.8
+`
+	configPath := filepath.Join(tmpDir, ".gomdlint.yml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	// CLI config adds an alias; project aliases are kept.
+	cliCfg := &config.Config{
+		LangDetect: config.LangDetectConfig{Aliases: map[string]string{"console": "shell-session"}},
+	}
+
+	ctx := context.Background()
+	opts := LoadOptions{
+		WorkingDir:         tmpDir,
+		IgnoreSystemConfig: true,
+		IgnoreUserConfig:   true,
+		IgnoreMarkdownlint: true,
+		NonInteractive:     true,
+		CLIConfig:          cliCfg,
+	}
+
+	result, err := Load(ctx, opts)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	ld := result.Config.LangDetect
+	if len(ld.Candidates) != 3 || ld.Candidates[2] != "console" {
+		t.Errorf("unexpected candidates %v", ld.Candidates)
+	}
+	if ld.Aliases["python"] != "py" || ld.Aliases["console"] != "shell-session" {
+		t.Errorf("unexpected aliases %v", ld.Aliases)
+	}
+	if ld.MinConfidence == nil || *ld.MinConfidence != 0.8 {
+		t.Errorf("expected min_confidence 0.8, got %v", ld.MinConfidence)
+	}
+}
+
+func TestLoad_InvalidLangDetectConfidence(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	configContent := `
+langdetect:
+  min_confidence: 1.5
+`
+	configPath := filepath.Join(tmpDir, ".gomdlint.yml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	ctx := context.Background()
+	opts := LoadOptions{
+		WorkingDir:         tmpDir,
+		IgnoreSystemConfig: true,
+		IgnoreUserConfig:   true,
+		IgnoreMarkdownlint: true,
+		NonInteractive:     true,
+	}
+
+	_, err := Load(ctx, opts)
+	if err == nil {
+		t.Fatal("expected validation error for min_confidence out of range")
+	}
+	if !strings.Contains(err.Error(), "langdetect.min_confidence") {
+		t.Errorf("error should mention langdetect.min_confidence: %v", err)
+	}
+}
+
+func TestLoad_Orphans(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	configContent := `
+orphans:
+  entry_points: [docs/index.md, mkdocs.yml]
+  assets: [docs/img]
+  asset_extensions: [.png, .svg]
+`
+	configPath := filepath.Join(tmpDir, ".gomdlint.yml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	// CLI config replaces the asset directories; other lists are kept.
+	cliCfg := &config.Config{
+		Orphans: config.OrphansConfig{Assets: []string{"assets"}},
+	}
+
+	ctx := context.Background()
+	opts := LoadOptions{
+		WorkingDir:         tmpDir,
+		IgnoreSystemConfig: true,
+		IgnoreUserConfig:   true,
+		IgnoreMarkdownlint: true,
+		NonInteractive:     true,
+		CLIConfig:          cliCfg,
+	}
+
+	result, err := Load(ctx, opts)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	orphans := result.Config.Orphans
+	if len(orphans.EntryPoints) != 2 || orphans.EntryPoints[1] != "mkdocs.yml" {
+		t.Errorf("unexpected entry points %v", orphans.EntryPoints)
+	}
+	if len(orphans.Assets) != 1 || orphans.Assets[0] != "assets" {
+		t.Errorf("expected CLI assets to replace project assets, got %v", orphans.Assets)
+	}
+	if len(orphans.AssetExtensions) != 2 || orphans.AssetExtensions[1] != ".svg" {
+		t.Errorf("unexpected asset extensions %v", orphans.AssetExtensions)
+	}
+}
+
+func TestLoad_InvalidOrphansAssetExtension(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	configContent := `
+orphans:
+  asset_extensions: [png]
+`
+	configPath := filepath.Join(tmpDir, ".gomdlint.yml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	ctx := context.Background()
+	opts := LoadOptions{
+		WorkingDir:         tmpDir,
+		IgnoreSystemConfig: true,
+		IgnoreUserConfig:   true,
+		IgnoreMarkdownlint: true,
+		NonInteractive:     true,
+	}
+
+	_, err := Load(ctx, opts)
+	if err == nil {
+		t.Fatal("expected validation error for asset extension without a dot")
+	}
+	if !strings.Contains(err.Error(), "orphans.asset_extensions") {
+		t.Errorf("error should mention orphans.asset_extensions: %v", err)
+	}
+}
+
+func TestLoad_Outputs(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	configContent := `
+outputs:
+  - format: text
+  - format: sarif
+    path: results.sarif
+`
+	configPath := filepath.Join(tmpDir, ".gomdlint.yml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	ctx := context.Background()
+	opts := LoadOptions{
+		WorkingDir:         tmpDir,
+		IgnoreSystemConfig: true,
+		IgnoreUserConfig:   true,
+		IgnoreMarkdownlint: true,
+		NonInteractive:     true,
+	}
+
+	result, err := Load(ctx, opts)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	outputs := result.Config.Outputs
+	if len(outputs) != 2 {
+		t.Fatalf("expected 2 outputs, got %v", outputs)
+	}
+	if outputs[0].Format != config.FormatText || outputs[0].Path != "" {
+		t.Errorf("unexpected first output %+v", outputs[0])
+	}
+	if outputs[1].Format != config.FormatSARIF || outputs[1].Path != "results.sarif" {
+		t.Errorf("unexpected second output %+v", outputs[1])
+	}
+
+	// CLI outputs replace the configured list.
+	opts.CLIConfig = &config.Config{
+		Outputs: []config.OutputConfig{{Format: config.FormatJSON, Path: "report.json"}},
+	}
+	result, err = Load(ctx, opts)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(result.Config.Outputs) != 1 || result.Config.Outputs[0].Format != config.FormatJSON {
+		t.Errorf("expected CLI outputs to replace project outputs, got %v", result.Config.Outputs)
+	}
+}
+
+func TestLoad_InvalidOutputs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		field   string
+	}{
+		{
+			name:    "unknown format",
+			content: "outputs:\n  - format: html5\n",
+			field:   "outputs[0].format",
+		},
+		{
+			name:    "two stdout outputs",
+			content: "outputs:\n  - format: text\n  - format: json\n",
+			field:   "outputs[1].path",
+		},
+		{
+			name:    "same file",
+			content: "outputs:\n  - format: json\n    path: out.json\n  - format: sarif\n    path: ./out.json\n",
+			field:   "outputs[1].path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, ".gomdlint.yml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			_, err := Load(context.Background(), LoadOptions{
+				WorkingDir:         tmpDir,
+				IgnoreSystemConfig: true,
+				IgnoreUserConfig:   true,
+				IgnoreMarkdownlint: true,
+				NonInteractive:     true,
+			})
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+			if !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("error should mention %s: %v", tt.field, err)
+			}
+		})
+	}
+}
+
 func TestLoad_ContextCancellation(t *testing.T) {
 	t.Parallel()
 
@@ -32,9 +32,12 @@ func merge(base, override *config.Config) *config.Config {
 	if override.Jobs != 0 {
 		result.Jobs = override.Jobs
 	}
+	if override.Encoding != "" {
+		result.Encoding = override.Encoding
+	}
 
 	// Booleans: these are tricky because false is the zero value.
-	// For Fix, DryRun, NoBackups - we check if they're true in override.
+	// For Fix, DryRun, NoBackups, UnsafeFixes, VerifyFixes - we check if they're true in override.
 	// This means CLI --fix will override, but config file cannot unset.
 	if override.Fix {
 		result.Fix = override.Fix
@@ -45,6 +48,12 @@ func merge(base, override *config.Config) *config.Config {
 	if override.NoBackups {
 		result.NoBackups = override.NoBackups
 	}
+	if override.UnsafeFixes {
+		result.UnsafeFixes = override.UnsafeFixes
+	}
+	if override.VerifyFixes {
+		result.VerifyFixes = override.VerifyFixes
+	}
 
 	// Backups: merge individual fields
 	if override.Backups.Mode != "" {
@@ -57,6 +66,35 @@ func merge(base, override *config.Config) *config.Config {
 		result.Backups.Enabled = override.Backups.Enabled
 	}
 
+	// LangDetect: merge individual fields
+	if override.LangDetect.Candidates != nil {
+		result.LangDetect.Candidates = override.LangDetect.Candidates
+	}
+	if override.LangDetect.MinConfidence != nil {
+		result.LangDetect.MinConfidence = override.LangDetect.MinConfidence
+	}
+	if override.LangDetect.Aliases != nil {
+		aliases := make(map[string]string, len(base.LangDetect.Aliases)+len(override.LangDetect.Aliases))
+		for key, val := range base.LangDetect.Aliases {
+			aliases[key] = val
+		}
+		for key, val := range override.LangDetect.Aliases {
+			aliases[key] = val
+		}
+		result.LangDetect.Aliases = aliases
+	}
+
+	// Orphans: lists replace individually
+	if override.Orphans.EntryPoints != nil {
+		result.Orphans.EntryPoints = override.Orphans.EntryPoints
+	}
+	if override.Orphans.Assets != nil {
+		result.Orphans.Assets = override.Orphans.Assets
+	}
+	if override.Orphans.AssetExtensions != nil {
+		result.Orphans.AssetExtensions = override.Orphans.AssetExtensions
+	}
+
 	// Maps: deep merge
 	result.Rules = mergeRules(base.Rules, override.Rules)
 
@@ -64,6 +102,9 @@ func merge(base, override *config.Config) *config.Config {
 	if override.Ignore != nil {
 		result.Ignore = override.Ignore
 	}
+	if override.Outputs != nil {
+		result.Outputs = override.Outputs
+	}
 	if override.EnableRules != nil {
 		result.EnableRules = override.EnableRules
 	}
@@ -94,6 +94,10 @@ var knownSeverities = map[string]bool{
 var knownFlavors = map[config.Flavor]bool{
 	config.FlavorCommonMark: true,
 	config.FlavorGFM:        true,
+	config.FlavorMDX:        true,
+	config.FlavorObsidian:   true,
+	config.FlavorMkDocs:     true,
+	config.FlavorPandoc:     true,
 }
 
 // knownFormats lists valid output format values.
@@ -103,17 +107,35 @@ var knownFormats = map[config.OutputFormat]bool{
 	config.FormatText:    true,
 	config.FormatTable:   true,
 	config.FormatJSON:    true,
+	config.FormatJSONL:   true,
 	config.FormatSARIF:   true,
 	config.FormatDiff:    true,
 	config.FormatSummary: true,
+	config.FormatHTML:    true,
+
+	config.FormatGitHub:     true,
+	config.FormatGitLab:     true,
+	config.FormatRDJSONL:    true,
+	config.FormatJUnit:      true,
+	config.FormatCheckstyle: true,
 }
 
 // knownBackupModes lists valid backup mode values.
 //
 //nolint:gochecknoglobals // Read-only lookup table.
 var knownBackupModes = map[string]bool{
-	"sidecar": true,
-	"none":    true,
+	"sidecar":   true,
+	"directory": true,
+	"none":      true,
+}
+
+// knownEncodingPolicies lists valid encoding values.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var knownEncodingPolicies = map[config.EncodingPolicy]bool{
+	config.EncodingSkip:      true,
+	config.EncodingTranscode: true,
+	config.EncodingConvert:   true,
 }
 
 // Validate checks a configuration for errors and warnings.
@@ -129,7 +151,7 @@ func Validate(cfg *config.Config) *ValidationResult {
 		result.Errors = append(result.Errors, ValidationError{
 			Field:   "flavor",
 			Value:   cfg.Flavor,
-			Message: fmt.Sprintf("invalid flavor %q; must be one of: commonmark, gfm", cfg.Flavor),
+			Message: fmt.Sprintf("invalid flavor %q; must be one of: commonmark, gfm, mdx, obsidian, mkdocs, pandoc", cfg.Flavor),
 		})
 	}
 
@@ -147,10 +169,13 @@ func Validate(cfg *config.Config) *ValidationResult {
 		result.Errors = append(result.Errors, ValidationError{
 			Field:   "format",
 			Value:   cfg.Format,
-			Message: fmt.Sprintf("invalid format %q; must be one of: text, table, json, sarif, diff, summary", cfg.Format),
+			Message: fmt.Sprintf("invalid format %q; must be one of: text, table, json, jsonl, sarif, diff, summary, html, github, gitlab, rdjsonl, junit, checkstyle", cfg.Format),
 		})
 	}
 
+	// Validate outputs
+	validateOutputs(cfg, result)
+
 	// Validate jobs
 	if cfg.Jobs < 0 {
 		result.Errors = append(result.Errors, ValidationError{
@@ -165,10 +190,39 @@ func Validate(cfg *config.Config) *ValidationResult {
 		result.Errors = append(result.Errors, ValidationError{
 			Field:   "backups.mode",
 			Value:   cfg.Backups.Mode,
-			Message: fmt.Sprintf("invalid backup mode %q; must be one of: sidecar, none", cfg.Backups.Mode),
+			Message: fmt.Sprintf("invalid backup mode %q; must be one of: sidecar, directory, none", cfg.Backups.Mode),
+		})
+	}
+
+	// Validate encoding
+	if cfg.Encoding != "" && !knownEncodingPolicies[cfg.Encoding] {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "encoding",
+			Value:   cfg.Encoding,
+			Message: fmt.Sprintf("invalid encoding %q; must be one of: skip, transcode, convert", cfg.Encoding),
+		})
+	}
+
+	// Validate langdetect.min_confidence
+	if mc := cfg.LangDetect.MinConfidence; mc != nil && (*mc < 0 || *mc > 1) {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "langdetect.min_confidence",
+			Value:   *mc,
+			Message: "min_confidence must be between 0 and 1",
 		})
 	}
 
+	// Validate orphans.asset_extensions
+	for _, ext := range cfg.Orphans.AssetExtensions {
+		if !strings.HasPrefix(ext, ".") {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "orphans.asset_extensions",
+				Value:   ext,
+				Message: fmt.Sprintf("asset extension %q must start with a dot", ext),
+			})
+		}
+	}
+
 	// Validate rules
 	validateRules(cfg, result)
 
@@ -178,13 +232,46 @@ func Validate(cfg *config.Config) *ValidationResult {
 	return result
 }
 
+// validateOutputs checks output formats and that no two outputs write to
+// the same destination.
+func validateOutputs(cfg *config.Config, result *ValidationResult) {
+	seen := make(map[string]bool, len(cfg.Outputs))
+
+	for i, output := range cfg.Outputs {
+		if !knownFormats[output.Format] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   fmt.Sprintf("outputs[%d].format", i),
+				Value:   output.Format,
+				Message: fmt.Sprintf("invalid format %q; must be one of: text, table, json, jsonl, sarif, diff, summary, html, github, gitlab, rdjsonl, junit, checkstyle", output.Format),
+			})
+		}
+
+		dest := "-"
+		if output.Path != "" {
+			dest = filepath.Clean(output.Path)
+		}
+		if seen[dest] {
+			message := fmt.Sprintf("more than one output writes to %q", output.Path)
+			if dest == "-" {
+				message = "more than one output writes to standard output"
+			}
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   fmt.Sprintf("outputs[%d].path", i),
+				Value:   output.Path,
+				Message: message,
+			})
+		}
+		seen[dest] = true
+	}
+}
+
 // validateRules checks rule configurations for errors and warnings.
 func validateRules(cfg *config.Config, result *ValidationResult) {
 	registry := lint.DefaultRegistry
 
 	for ruleID, ruleCfg := range cfg.Rules {
 		// Check if rule exists in registry
-		if _, exists := registry.Get(ruleID); !exists {
+		if _, exists := registry.Get(ruleID); !exists && !lint.IsPipelineRule(ruleID) {
 			result.Warnings = append(result.Warnings, ValidationError{
 				Field:   "rules." + ruleID,
 				Value:   ruleID,
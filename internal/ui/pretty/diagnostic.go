@@ -18,27 +18,8 @@ func (s *Styles) FormatDiagnostic(diag *lint.Diagnostic, showContext bool, sourc
 func (s *Styles) FormatDiagnosticWithFormat(diag *lint.Diagnostic, showContext bool, sourceLine string, ruleFormat config.RuleFormat) string {
 	var builder strings.Builder
 
-	// Location: path:line:col
-	location := fmt.Sprintf("%s:%d:%d",
-		s.FilePath.Render(diag.FilePath),
-		diag.StartLine,
-		diag.StartColumn,
-	)
-
-	// Severity with prefix
-	severity := s.FormatSeverity(diag.Severity)
-
-	// Rule identifier formatted according to config
-	ruleIdentifier := config.FormatRuleID(ruleFormat, diag.RuleID, diag.RuleName)
-	ruleDisplay := s.RuleID.Render("(" + ruleIdentifier + ")")
-
 	// Main line: location  severity  message  (rule-id)
-	builder.WriteString(fmt.Sprintf("  %s  %s  %s  %s\n",
-		location,
-		severity,
-		s.Message.Render(diag.Message),
-		ruleDisplay,
-	))
+	builder.WriteString(s.formatDiagnosticLine(diag, diag.StartColumn, ruleFormat))
 
 	// Source context
 	if showContext && sourceLine != "" {
@@ -0,0 +1,362 @@
+package pretty
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+// Code frame layout.
+const (
+	frameIndent       = "    "
+	defaultTabWidth   = 4
+	frameMaxSpanLines = 4 // longer spans show their first and last lines
+	frameMaxFixLines  = 8 // per side of a fix preview
+)
+
+// FrameOptions configures the code frames of FormatDiagnosticFrame.
+type FrameOptions struct {
+	// ContextBefore and ContextAfter are the number of lines shown before
+	// and after the lines of the diagnostic.
+	ContextBefore int
+	ContextAfter  int
+
+	// TabWidth is the distance between tab stops. Zero means 4.
+	TabWidth int
+
+	// Encoding is the unit of the column shown in the location. Empty
+	// means bytes. The frame itself always uses byte columns.
+	Encoding mdast.PositionEncoding
+}
+
+// FrameSource is the content of a file split into lines once, for
+// formatting the code frames of all of its diagnostics.
+type FrameSource struct {
+	content []byte
+
+	// lines are the lines of content without their line endings.
+	lines [][]byte
+
+	// starts are the offsets at which lines start, including the end of
+	// content after a final line ending.
+	starts []int
+}
+
+// NewFrameSource splits content into lines. It returns nil for nil content.
+func NewFrameSource(content []byte) *FrameSource {
+	if content == nil {
+		return nil
+	}
+	starts := []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &FrameSource{content: content, lines: frameLines(content), starts: starts}
+}
+
+// lineAt returns the 1-based line that holds offset.
+func (src *FrameSource) lineAt(offset int) int {
+	return max(sort.SearchInts(src.starts, offset+1), 1)
+}
+
+// FormatDiagnosticFrame formats a diagnostic like FormatDiagnosticWithFormat,
+// but with a compiler-style code frame: the diagnostic's lines with line
+// numbers, its range from StartColumn to EndColumn underlined, the
+// surrounding context lines, and a preview of its fix. content is the whole
+// file; without it, or with showContext unset, only the message and
+// suggestion are shown. To format many diagnostics of a file, use
+// FormatSourceFrame with one FrameSource.
+func (s *Styles) FormatDiagnosticFrame(
+	diag *lint.Diagnostic,
+	content []byte,
+	showContext bool,
+	ruleFormat config.RuleFormat,
+	opts FrameOptions,
+) string {
+	var src *FrameSource
+	if showContext || opts.Encoding != "" {
+		src = NewFrameSource(content)
+	}
+	return s.FormatSourceFrame(diag, src, showContext, ruleFormat, opts)
+}
+
+// FormatSourceFrame is FormatDiagnosticFrame for a file split by
+// NewFrameSource. src is only used for the code frame, with showContext
+// set, and for columns in opts.Encoding; it may be nil otherwise.
+func (s *Styles) FormatSourceFrame(
+	diag *lint.Diagnostic,
+	src *FrameSource,
+	showContext bool,
+	ruleFormat config.RuleFormat,
+	opts FrameOptions,
+) string {
+	var builder strings.Builder
+
+	inSource := src != nil && diag.StartLine >= 1 && diag.StartLine <= len(src.lines)
+	column := diag.StartColumn
+	if opts.Encoding != "" && inSource {
+		column = opts.Encoding.Column(src.lines[diag.StartLine-1], column)
+	}
+	builder.WriteString(s.formatDiagnosticLine(diag, column, ruleFormat))
+
+	showFrame := showContext && inSource
+	if showFrame {
+		builder.WriteString(s.formatCodeFrame(src.lines, diag, opts))
+	}
+
+	if diag.Suggestion != "" {
+		builder.WriteString(frameIndent + s.Dim.Render("Suggestion:") + " " +
+			s.Suggestion.Render(diag.Suggestion) + "\n")
+	}
+
+	if showFrame && len(diag.FixEdits) > 0 {
+		builder.WriteString(s.formatFixPreview(src, diag.FixEdits, fixLabel(diag.Applicability), opts))
+	}
+
+	return builder.String()
+}
+
+// formatDiagnosticLine formats the main line of a diagnostic:
+// location, severity, message and rule.
+func (s *Styles) formatDiagnosticLine(diag *lint.Diagnostic, column int, ruleFormat config.RuleFormat) string {
+	location := fmt.Sprintf("%s:%d:%d", s.FilePath.Render(diag.FilePath), diag.StartLine, column)
+	ruleIdentifier := config.FormatRuleID(ruleFormat, diag.RuleID, diag.RuleName)
+
+	return fmt.Sprintf("  %s  %s  %s  %s\n",
+		location,
+		s.FormatSeverity(diag.Severity),
+		s.Message.Render(diag.Message),
+		s.RuleID.Render("("+ruleIdentifier+")"),
+	)
+}
+
+// formatCodeFrame formats the lines of a diagnostic and their context,
+// underlining its range.
+func (s *Styles) formatCodeFrame(lines [][]byte, diag *lint.Diagnostic, opts FrameOptions) string {
+	startLine := diag.StartLine
+	endLine := diag.EndLine
+	if endLine < startLine || endLine > len(lines) {
+		endLine = startLine
+	}
+
+	first := max(startLine-max(opts.ContextBefore, 0), 1)
+	last := min(endLine+max(opts.ContextAfter, 0), len(lines))
+	gutter := newFrameGutter(last)
+
+	var builder strings.Builder
+	for n := first; n <= last; n++ {
+		inSpan := n >= startLine && n <= endLine
+		if inSpan && endLine-startLine+1 > frameMaxSpanLines &&
+			n > startLine+1 && n < endLine-1 {
+			if n == startLine+2 {
+				builder.WriteString(frameIndent + s.Dim.Render(gutter.elision()) + "\n")
+			}
+			continue
+		}
+
+		text, columns := expandLine(lines[n-1], opts.TabWidth)
+		builder.WriteString(frameIndent + s.Dim.Render(gutter.line(n, inSpan, text == "")) + s.SourceLine.Render(text) + "\n")
+		if !inSpan {
+			continue
+		}
+
+		from, to := spanColumns(lines[n-1], diag, n, startLine, endLine)
+		start := columns[from-1]
+		width := max(columns[to-1]-start, 1)
+		builder.WriteString(frameIndent + s.Dim.Render(gutter.blank()) +
+			strings.Repeat(" ", start) + s.Caret.Render(strings.Repeat("^", width)) + "\n")
+	}
+
+	return builder.String()
+}
+
+// fixLabel returns the heading of a fix preview, which tells whether --fix
+// applies the fix.
+func fixLabel(applicability lint.FixApplicability) string {
+	switch applicability {
+	case lint.FixUnsafe:
+		return "Unsafe fix (applied with --unsafe-fixes):"
+	case lint.FixSuggestion:
+		return "Suggested fix (not applied automatically):"
+	default:
+		return "Fix:"
+	}
+}
+
+// formatFixPreview formats the lines a fix changes, before and after, under
+// label. Only the changed lines are fixed, not the whole file.
+func (s *Styles) formatFixPreview(src *FrameSource, edits []fix.TextEdit, label string, opts FrameOptions) string {
+	content := src.content
+	prepared, err := fix.PrepareEdits(append([]fix.TextEdit(nil), edits...), len(content))
+	if err != nil || len(prepared) == 0 {
+		return ""
+	}
+
+	// The changed lines run from the start of the first edit's line to the
+	// end of the last edit's line.
+	startOffset := prepared[0].StartOffset
+	endOffset := prepared[len(prepared)-1].EndOffset
+	firstLine := src.lineAt(startOffset)
+	regionStart := src.starts[firstLine-1]
+	regionEnd := len(content)
+	if i := bytes.IndexByte(content[endOffset:], '\n'); i >= 0 {
+		regionEnd = endOffset + i
+	}
+
+	region := content[regionStart:regionEnd]
+	for i := range prepared {
+		prepared[i].StartOffset -= regionStart
+		prepared[i].EndOffset -= regionStart
+	}
+	fixed := fix.ApplyEdits(region, prepared)
+
+	before := frameLines(region)
+	after := frameLines(fixed)
+	if len(fixed) == 0 {
+		after = nil
+	}
+	gutter := newFrameGutter(max(firstLine+len(before), firstLine+len(after)))
+
+	var builder strings.Builder
+	builder.WriteString(frameIndent + s.Dim.Render(label) + "\n")
+	write := func(prefix string, style func(...string) string, fixLines [][]byte) {
+		for i, line := range fixLines {
+			if i == frameMaxFixLines {
+				builder.WriteString(frameIndent + s.Dim.Render(gutter.elision()) + "\n")
+				break
+			}
+			text, _ := expandLine(line, opts.TabWidth)
+			builder.WriteString(frameIndent + style(prefix+gutter.number(firstLine+i, text == "")+text) + "\n")
+		}
+	}
+	write("- ", s.DiffRemove.Render, before)
+	write("+ ", s.DiffAdd.Render, after)
+
+	return builder.String()
+}
+
+// spanColumns returns the 1-based byte columns underlined on line n of a
+// diagnostic spanning startLine to endLine; to is exclusive. Lines after
+// the first are underlined from their first non-blank character, and lines
+// before the last to their end.
+func spanColumns(line []byte, diag *lint.Diagnostic, n, startLine, endLine int) (from, to int) {
+	lineEnd := len(line) + 1
+
+	from = 1
+	if n == startLine {
+		from = diag.StartColumn
+	} else {
+		from += len(line) - len(bytes.TrimLeft(line, " \t"))
+	}
+	from = min(max(from, 1), lineEnd)
+
+	to = lineEnd
+	if n == endLine && diag.EndLine == endLine && diag.EndColumn > 0 {
+		to = min(diag.EndColumn, lineEnd)
+	}
+	if to < from {
+		to = from
+	}
+	return from, to
+}
+
+// expandLine returns line with tabs expanded and invalid or control
+// characters replaced, and the display column at which each byte of the
+// line starts. columns has an entry for the position after the last byte.
+func expandLine(line []byte, tabWidth int) (string, []int) {
+	if tabWidth <= 0 {
+		tabWidth = defaultTabWidth
+	}
+
+	var text strings.Builder
+	columns := make([]int, len(line)+1)
+	width := 0
+	for i := 0; i < len(line); {
+		r, size := utf8.DecodeRune(line[i:])
+		for j := range size {
+			columns[i+j] = width
+		}
+
+		switch {
+		case r == '\t':
+			spaces := tabWidth - width%tabWidth
+			text.WriteString(strings.Repeat(" ", spaces))
+			width += spaces
+		case r == utf8.RuneError && size == 1, r < ' ', r == 0x7f:
+			text.WriteRune(utf8.RuneError)
+			width++
+		default:
+			text.WriteRune(r)
+			width += runewidth.RuneWidth(r)
+		}
+		i += size
+	}
+	columns[len(line)] = width
+
+	return text.String(), columns
+}
+
+// frameLines splits content into lines without their line endings. A final
+// line ending does not start another line.
+func frameLines(content []byte) [][]byte {
+	if content == nil {
+		return nil
+	}
+	lines := bytes.Split(content, []byte("\n"))
+	if len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		lines[i] = bytes.TrimSuffix(line, []byte("\r"))
+	}
+	return lines
+}
+
+// frameGutter formats the line number column of a code frame.
+type frameGutter struct {
+	width int
+}
+
+func newFrameGutter(lastLine int) frameGutter {
+	return frameGutter{width: len(strconv.Itoa(lastLine))}
+}
+
+// line returns the gutter of line n, marked if it is part of the span.
+func (g frameGutter) line(n int, marked, empty bool) string {
+	marker := "  "
+	if marked {
+		marker = "> "
+	}
+	return marker + g.number(n, empty)
+}
+
+// number returns a line number and the separator, without the space that
+// separates it from the text if the line is empty.
+func (g frameGutter) number(n int, empty bool) string {
+	if empty {
+		return fmt.Sprintf("%*d |", g.width, n)
+	}
+	return fmt.Sprintf("%*d | ", g.width, n)
+}
+
+// blank returns the gutter of an underline.
+func (g frameGutter) blank() string {
+	return "  " + strings.Repeat(" ", g.width) + " | "
+}
+
+// elision returns the gutter of omitted lines.
+func (g frameGutter) elision() string {
+	return "  " + strings.Repeat(" ", g.width) + " ..."
+}
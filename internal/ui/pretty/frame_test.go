@@ -0,0 +1,186 @@
+package pretty_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yaklabco/gomdlint/internal/ui/pretty"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+func frameDiagnostic(startLine, startColumn, endLine, endColumn int) *lint.Diagnostic {
+	return &lint.Diagnostic{
+		RuleID:      "MD009",
+		RuleName:    "no-trailing-spaces",
+		Message:     "Trailing spaces",
+		Severity:    config.SeverityWarning,
+		FilePath:    "test.md",
+		StartLine:   startLine,
+		StartColumn: startColumn,
+		EndLine:     endLine,
+		EndColumn:   endColumn,
+	}
+}
+
+func TestFormatDiagnosticFrame_Underline(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	content := []byte("# Title\n\ntext  \n")
+	diag := frameDiagnostic(3, 5, 3, 7)
+
+	result := styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName, pretty.FrameOptions{})
+
+	assert.Equal(t, "  test.md:3:5  warning  Trailing spaces  (no-trailing-spaces)\n"+
+		"    > 3 | text  \n"+
+		"        |     ^^\n", result)
+}
+
+func TestFormatDiagnosticFrame_Context(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	content := []byte("one\ntwo\nthree\nfour\n")
+	diag := frameDiagnostic(2, 1, 2, 4)
+
+	result := styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName,
+		pretty.FrameOptions{ContextBefore: 5, ContextAfter: 1})
+
+	assert.Contains(t, result, "      1 | one\n    > 2 | two\n        | ^^^\n      3 | three\n")
+	assert.NotContains(t, result, "four")
+}
+
+func TestFormatDiagnosticFrame_TabsAndWideCharacters(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	// The tab expands to column 4; "日本" is 6 bytes and 4 cells wide.
+	content := []byte("a\t日本 x\n")
+	start := len("a\t日本 ") + 1
+	diag := frameDiagnostic(1, start, 1, start+1)
+
+	result := styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName, pretty.FrameOptions{})
+
+	lines := strings.Split(result, "\n")
+	assert.Equal(t, "    > 1 | a   日本 x", lines[1])
+	assert.Equal(t, "        | "+strings.Repeat(" ", len("a   ")+4+1)+"^", lines[2])
+}
+
+func TestFormatDiagnosticFrame_MultiLineSpan(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	content := []byte("```\none\n  two\nthree\nfour\n```\n")
+	diag := frameDiagnostic(1, 1, 6, 4)
+
+	result := styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName, pretty.FrameOptions{})
+
+	assert.Equal(t, "  test.md:1:1  warning  Trailing spaces  (no-trailing-spaces)\n"+
+		"    > 1 | ```\n"+
+		"        | ^^^\n"+
+		"    > 2 | one\n"+
+		"        | ^^^\n"+
+		"        ...\n"+
+		"    > 5 | four\n"+
+		"        | ^^^^\n"+
+		"    > 6 | ```\n"+
+		"        | ^^^\n", result)
+}
+
+func TestFormatDiagnosticFrame_FixPreview(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	content := []byte("# Title\n- item\n")
+	diag := frameDiagnostic(2, 1, 2, 1)
+	diag.Suggestion = "Add a blank line"
+	diag.FixEdits = []fix.TextEdit{{StartOffset: 8, EndOffset: 8, NewText: "\n"}}
+
+	result := styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName, pretty.FrameOptions{})
+
+	assert.Contains(t, result, "    Suggestion: Add a blank line\n"+
+		"    Fix:\n"+
+		"    - 2 | - item\n"+
+		"    + 2 |\n"+
+		"    + 3 | - item\n")
+}
+
+func TestFormatDiagnosticFrame_FixApplicability(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	content := []byte("**Usage**\n")
+	diag := frameDiagnostic(1, 1, 1, 10)
+	diag.FixEdits = []fix.TextEdit{{StartOffset: 0, EndOffset: 9, NewText: "## Usage"}}
+
+	diag.Applicability = lint.FixUnsafe
+	result := styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName, pretty.FrameOptions{})
+	assert.Contains(t, result, "    Unsafe fix (applied with --unsafe-fixes):\n"+
+		"    - 1 | **Usage**\n"+
+		"    + 1 | ## Usage\n")
+
+	diag.Applicability = lint.FixSuggestion
+	result = styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName, pretty.FrameOptions{})
+	assert.Contains(t, result, "    Suggested fix (not applied automatically):\n")
+}
+
+func TestFormatDiagnosticFrame_WithoutSource(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	diag := frameDiagnostic(3, 5, 3, 7)
+	diag.FixEdits = []fix.TextEdit{{StartOffset: 13, EndOffset: 15}}
+
+	withoutContent := styles.FormatDiagnosticFrame(diag, nil, true, config.RuleFormatName, pretty.FrameOptions{})
+	hidden := styles.FormatDiagnosticFrame(diag, []byte("# T\n\ntext  \n"), false, config.RuleFormatName, pretty.FrameOptions{})
+
+	want := "  test.md:3:5  warning  Trailing spaces  (no-trailing-spaces)\n"
+	assert.Equal(t, want, withoutContent)
+	assert.Equal(t, want, hidden)
+}
+
+func TestFormatDiagnosticFrame_ColumnEncoding(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	content := []byte("😀 x  \n")
+	diag := frameDiagnostic(1, 7, 1, 9)
+
+	result := styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName,
+		pretty.FrameOptions{Encoding: mdast.PositionEncodingUTF16})
+
+	assert.Contains(t, result, "test.md:1:5 ")
+	// The underline still sits under the trailing spaces.
+	assert.Contains(t, result, "        |     ^^\n")
+}
+
+func TestFormatSourceFrame_SharedSource(t *testing.T) {
+	t.Parallel()
+
+	styles := pretty.NewStyles(false)
+	content := []byte("# Title\n\none  \ntwo  \n")
+	src := pretty.NewFrameSource(content)
+
+	for _, line := range []int{3, 4} {
+		diag := frameDiagnostic(line, 4, line, 6)
+		offset := 9 + (line-3)*6
+		diag.FixEdits = []fix.TextEdit{{StartOffset: offset + 3, EndOffset: offset + 5}}
+
+		want := styles.FormatDiagnosticFrame(diag, content, true, config.RuleFormatName, pretty.FrameOptions{})
+		got := styles.FormatSourceFrame(diag, src, true, config.RuleFormatName, pretty.FrameOptions{})
+		assert.Equal(t, want, got)
+		text := []string{"one", "two"}[line-3]
+		assert.Contains(t, got, fmt.Sprintf("    Fix:\n    - %d | %s  \n    + %d | %s\n", line, text, line, text))
+	}
+
+	// Without context, no source is needed.
+	diag := frameDiagnostic(3, 4, 3, 6)
+	assert.Equal(t, "  test.md:3:4  warning  Trailing spaces  (no-trailing-spaces)\n",
+		styles.FormatSourceFrame(diag, nil, false, config.RuleFormatName, pretty.FrameOptions{}))
+}
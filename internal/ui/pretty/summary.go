@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/yaklabco/gomdlint/pkg/lint"
 	"github.com/yaklabco/gomdlint/pkg/runner"
 )
 
@@ -27,6 +28,9 @@ func (s *Styles) FormatSummaryOneLine(stats runner.Stats) string {
 			}
 			msg += ", " + s.Success.Render(fmt.Sprintf("%d fixed in %d %s", stats.DiagnosticsFixed, stats.FilesModified, fileWord))
 		}
+		if converted := s.formatConverted(stats); converted != "" {
+			msg += ", " + converted
+		}
 		return msg + "\n"
 	}
 
@@ -78,9 +82,49 @@ func (s *Styles) FormatSummaryOneLine(stats runner.Stats) string {
 		parts = append(parts, s.Success.Render(fmt.Sprintf("%d fixed in %d %s", stats.DiagnosticsFixed, stats.FilesModified, fixedFileWord)))
 	}
 
+	// Files converted to UTF-8
+	if converted := s.formatConverted(stats); converted != "" {
+		parts = append(parts, converted)
+	}
+
+	// Fixes held back by their applicability
+	parts = append(parts, s.formatHeldBack(stats)...)
+
 	return strings.Join(parts, ", ") + "\n"
 }
 
+// formatConverted formats the count of files converted to UTF-8, or
+// returns "" if there are none.
+func (s *Styles) formatConverted(stats runner.Stats) string {
+	if stats.FilesConverted == 0 {
+		return ""
+	}
+	fileWord := wordFiles
+	if stats.FilesConverted == 1 {
+		fileWord = wordFile
+	}
+	return s.Success.Render(fmt.Sprintf("%d %s converted to UTF-8", stats.FilesConverted, fileWord))
+}
+
+// formatHeldBack formats the counts of fixes held back and why.
+func (s *Styles) formatHeldBack(stats runner.Stats) []string {
+	var parts []string
+	if unsafe := stats.FixesHeldBack[lint.FixUnsafe]; unsafe > 0 {
+		parts = append(parts, s.Warning.Render(fmt.Sprintf("%d unsafe %s held back (use --unsafe-fixes)", unsafe, pluralFix(unsafe))))
+	}
+	if suggestions := stats.FixesHeldBack[lint.FixSuggestion]; suggestions > 0 {
+		parts = append(parts, s.Dim.Render(fmt.Sprintf("%d suggested %s not applied", suggestions, pluralFix(suggestions))))
+	}
+	return parts
+}
+
+func pluralFix(n int) string {
+	if n == 1 {
+		return "fix"
+	}
+	return "fixes"
+}
+
 // FormatSummary formats run statistics as a summary block.
 func (s *Styles) FormatSummary(stats runner.Stats) string {
 	var builder strings.Builder
@@ -105,6 +149,11 @@ func (s *Styles) FormatSummary(stats runner.Stats) string {
 			s.Success.Render(strconv.Itoa(stats.FilesModified)) + "\n")
 	}
 
+	if stats.FilesConverted > 0 {
+		builder.WriteString("  Files converted:   " +
+			s.Success.Render(strconv.Itoa(stats.FilesConverted)) + "\n")
+	}
+
 	builder.WriteString("\n")
 
 	// Diagnostics by severity
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/yaklabco/gomdlint/internal/ui/pretty"
+	"github.com/yaklabco/gomdlint/pkg/lint"
 	"github.com/yaklabco/gomdlint/pkg/runner"
 )
 
@@ -187,6 +188,40 @@ func TestFormatSummaryOneLine_WithModified(t *testing.T) {
 	assert.Contains(t, result, "7 fixed in 2 files")
 }
 
+func TestFormatSummaryOneLine_HeldBack(t *testing.T) {
+	styles := pretty.NewStyles(false)
+
+	stats := runner.Stats{
+		FilesProcessed:        2,
+		FilesWithIssues:       1,
+		DiagnosticsTotal:      4,
+		DiagnosticsFixable:    4,
+		DiagnosticsBySeverity: map[string]int{"warning": 4},
+		FixesHeldBack:         map[lint.FixApplicability]int{lint.FixUnsafe: 3, lint.FixSuggestion: 1},
+	}
+
+	result := styles.FormatSummaryOneLine(stats)
+
+	assert.Contains(t, result, "3 unsafe fixes held back (use --unsafe-fixes)")
+	assert.Contains(t, result, "1 suggested fix not applied")
+}
+
+func TestFormatSummaryOneLine_Converted(t *testing.T) {
+	styles := pretty.NewStyles(false)
+
+	stats := runner.Stats{
+		FilesProcessed:        3,
+		FilesModified:         1,
+		FilesConverted:        1,
+		DiagnosticsBySeverity: map[string]int{},
+	}
+
+	result := styles.FormatSummaryOneLine(stats)
+
+	assert.Contains(t, result, "1 file converted to UTF-8")
+	assert.NotContains(t, result, "fixed")
+}
+
 func TestFormatSummaryOneLine_NoFixable(t *testing.T) {
 	styles := pretty.NewStyles(false)
 
@@ -0,0 +1,232 @@
+// Package review provides the interactive review of fixes for
+// gomdlint lint --fix --interactive.
+package review
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/internal/ui/pretty"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+)
+
+// Choice is an answer to the prompt for a fix.
+type Choice int
+
+const (
+	// Accept applies the fix.
+	Accept Choice = iota
+
+	// Reject leaves the fix out.
+	Reject
+
+	// AcceptRule applies the fix and every later fix of its rule.
+	AcceptRule
+
+	// SkipFile leaves out the fix and the remaining fixes of the file.
+	SkipFile
+
+	// Quit leaves out the fix and all remaining fixes.
+	Quit
+)
+
+// Options configures a Reviewer.
+type Options struct {
+	// In is read for the answers, one per line.
+	In io.Reader
+
+	// Out receives the fixes and prompts.
+	Out io.Writer
+
+	// Styles formats the fixes. If nil, colors are disabled.
+	Styles *pretty.Styles
+
+	// RuleFormat controls how rule identifiers appear.
+	RuleFormat config.RuleFormat
+
+	// Frame configures the code frames of the fixes.
+	Frame pretty.FrameOptions
+}
+
+// Reviewer asks about each fix on a terminal. It implements
+// lint.FixReviewer; fixes are reviewed one file at a time.
+type Reviewer struct {
+	opts Options
+	in   *bufio.Reader
+
+	// acceptedRules are the rules whose fixes are accepted without asking.
+	acceptedRules map[string]bool
+
+	// rejected holds the keys of rejected fixes, so that later passes do
+	// not ask about them again.
+	rejected map[string]bool
+
+	// skippedFiles are the files whose remaining fixes are left out.
+	skippedFiles map[string]bool
+
+	quit bool
+}
+
+// New creates a Reviewer.
+func New(opts Options) *Reviewer {
+	if opts.Styles == nil {
+		opts.Styles = pretty.NewStyles(false)
+	}
+	return &Reviewer{
+		opts:          opts,
+		in:            bufio.NewReader(opts.In),
+		acceptedRules: make(map[string]bool),
+		rejected:      make(map[string]bool),
+		skippedFiles:  make(map[string]bool),
+	}
+}
+
+// ReviewFixes implements lint.FixReviewer.
+func (r *Reviewer) ReviewFixes(ctx context.Context, path string, content []byte, fileResult *lint.FileResult) ([]fix.TextEdit, error) {
+	var accepted []fix.TextEdit
+	for _, hunk := range lint.FixHunks(fileResult) {
+		if r.quit || r.skippedFiles[path] {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("review cancelled: %w", err)
+		}
+
+		ruleID := hunk.Edits[0].Origin.RuleID
+		key := hunkKey(path, content, hunk.Edits)
+		if r.rejected[key] {
+			continue
+		}
+		if ruleID != "" && r.acceptedRules[ruleID] {
+			accepted = append(accepted, hunk.Edits...)
+			continue
+		}
+
+		choice, err := r.ask(path, content, hunk)
+		if err != nil {
+			return nil, err
+		}
+		switch choice {
+		case Accept:
+			accepted = append(accepted, hunk.Edits...)
+		case AcceptRule:
+			r.acceptedRules[ruleID] = true
+			accepted = append(accepted, hunk.Edits...)
+		case Reject:
+			r.rejected[key] = true
+		case SkipFile:
+			r.skippedFiles[path] = true
+		case Quit:
+			r.quit = true
+		}
+	}
+
+	// The hunks keep the order of the edits, which the accepted edits
+	// must keep too.
+	fix.SortEdits(accepted)
+	return accepted, nil
+}
+
+// ask shows a fix and reads the answer, asking again until it is valid.
+// The end of the input quits.
+func (r *Reviewer) ask(path string, content []byte, hunk lint.FixHunk) (Choice, error) {
+	styles := r.opts.Styles
+	diag := hunkDiagnostic(path, hunk)
+	rule := config.FormatRuleID(r.opts.RuleFormat, diag.RuleID, diag.RuleName)
+
+	// A fix without a rule cannot be accepted for its rule.
+	prompt, answers := fmt.Sprintf("[y]es, [n]o, [a]ll %s fixes, [s]kip file, [q]uit", rule), "y, n, a, s or q"
+	if diag.RuleID == "" {
+		prompt, answers = "[y]es, [n]o, [s]kip file, [q]uit", "y, n, s or q"
+	}
+
+	fmt.Fprint(r.opts.Out, styles.FormatDiagnosticFrame(&diag, content, true, r.opts.RuleFormat, r.opts.Frame))
+	for {
+		fmt.Fprintf(r.opts.Out, "%s %s: ", styles.Bold.Render("Apply this fix?"), prompt)
+
+		line, err := r.in.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Quit, fmt.Errorf("read answer: %w", err)
+		}
+		if choice, ok := parseChoice(line); ok && (choice != AcceptRule || diag.RuleID != "") {
+			fmt.Fprintln(r.opts.Out)
+			return choice, nil
+		}
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(r.opts.Out)
+			return Quit, nil
+		}
+		fmt.Fprintln(r.opts.Out, styles.Warning.Render("Please answer "+answers+"."))
+	}
+}
+
+// parseChoice parses an answer by its first letter.
+func parseChoice(answer string) (Choice, bool) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "" {
+		return 0, false
+	}
+	switch answer[0] {
+	case 'y':
+		return Accept, true
+	case 'n':
+		return Reject, true
+	case 'a':
+		return AcceptRule, true
+	case 's':
+		return SkipFile, true
+	case 'q':
+		return Quit, true
+	default:
+		return 0, false
+	}
+}
+
+// hunkDiagnostic returns the diagnostic to show for hunk, with only the
+// edits of the hunk as its fix.
+func hunkDiagnostic(path string, hunk lint.FixHunk) lint.Diagnostic {
+	origin := hunk.Edits[0].Origin
+	diag := lint.Diagnostic{
+		RuleID:      origin.RuleID,
+		FilePath:    path,
+		StartLine:   origin.Line,
+		StartColumn: origin.Column,
+		Message:     "Fix",
+	}
+	if hunk.Diagnostic != nil {
+		diag = *hunk.Diagnostic
+	}
+	diag.FixEdits = hunk.Edits
+	return diag
+}
+
+// hunkKey identifies a fix by its file, its rule, the text of the lines it
+// changes and the changes, so that the same fix proposed again in a later
+// pass has the same key even if edits elsewhere moved it.
+func hunkKey(path string, content []byte, edits []fix.TextEdit) string {
+	start := edits[0].StartOffset
+	end := edits[len(edits)-1].EndOffset
+	for start > 0 && content[start-1] != '\n' {
+		start--
+	}
+	for end < len(content) && content[end] != '\n' {
+		end++
+	}
+
+	var key strings.Builder
+	key.WriteString(path)
+	key.WriteByte(0)
+	key.WriteString(edits[0].Origin.RuleID)
+	key.WriteByte(0)
+	key.Write(content[start:end])
+	for _, edit := range edits {
+		fmt.Fprintf(&key, "\x00%d:%d:%s", edit.StartOffset-start, edit.EndOffset-start, edit.NewText)
+	}
+	return key.String()
+}
@@ -0,0 +1,153 @@
+package review_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/internal/ui/review"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+)
+
+// trailingSpaces is a file with trailing spaces on its first three lines.
+const trailingSpaces = "one  \ntwo  \nthree  \n"
+
+// fileResult returns a lint result with a fix for the trailing spaces of
+// each line of trailingSpaces, from ruleIDs in turn.
+func fileResult(ruleIDs ...string) *lint.FileResult {
+	result := &lint.FileResult{}
+	offset := 0
+	for i, line := range strings.SplitAfter(strings.TrimSuffix(trailingSpaces, "\n"), "\n") {
+		end := offset + len(strings.TrimSuffix(line, "\n"))
+		start := end - 2
+		edit := fix.TextEdit{
+			StartOffset: start,
+			EndOffset:   end,
+			Origin:      fix.Origin{RuleID: ruleIDs[i], Line: i + 1, Column: start - offset + 1},
+		}
+		result.Diagnostics = append(result.Diagnostics, lint.Diagnostic{
+			RuleID:      ruleIDs[i],
+			Message:     "Trailing whitespace",
+			FilePath:    "doc.md",
+			StartLine:   i + 1,
+			StartColumn: start - offset + 1,
+			FixEdits:    []fix.TextEdit{edit},
+		})
+		result.Edits = append(result.Edits, edit)
+		offset += len(line)
+	}
+	return result
+}
+
+func newReviewer(answers string, out *bytes.Buffer) *review.Reviewer {
+	return review.New(review.Options{
+		In:         strings.NewReader(answers),
+		Out:        out,
+		RuleFormat: config.RuleFormatID,
+	})
+}
+
+func lines(edits []fix.TextEdit) []int {
+	var result []int
+	for _, edit := range edits {
+		result = append(result, edit.Origin.Line)
+	}
+	return result
+}
+
+func TestReviewer_AcceptAndReject(t *testing.T) {
+	var out bytes.Buffer
+	reviewer := newReviewer("y\nno\n", &out)
+
+	accepted, err := reviewer.ReviewFixes(context.Background(), "doc.md", []byte(trailingSpaces),
+		fileResult("MD009", "MD009", "MD010"))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, lines(accepted))
+
+	output := out.String()
+	assert.Contains(t, output, "doc.md:1:4")
+	assert.Contains(t, output, "- 1 | one  ")
+	assert.Contains(t, output, "+ 1 | one")
+	assert.Contains(t, output, "Apply this fix? [y]es, [n]o, [a]ll MD009 fixes, [s]kip file, [q]uit:")
+	// The end of the answers quits at the third fix.
+	assert.Equal(t, 3, strings.Count(output, "Apply this fix?"))
+}
+
+func TestReviewer_RememberedAnswers(t *testing.T) {
+	var out bytes.Buffer
+	reviewer := newReviewer("a\nn\n", &out)
+
+	accepted, err := reviewer.ReviewFixes(context.Background(), "doc.md", []byte(trailingSpaces),
+		fileResult("MD009", "MD010", "MD009"))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, lines(accepted))
+	assert.Equal(t, 2, strings.Count(out.String(), "Apply this fix?"))
+
+	// A later pass accepts the rule's fixes and skips the rejected fix
+	// without asking again.
+	out.Reset()
+	accepted, err = reviewer.ReviewFixes(context.Background(), "doc.md", []byte(trailingSpaces),
+		fileResult("MD009", "MD010", "MD009"))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, lines(accepted))
+	assert.Empty(t, out.String())
+}
+
+func TestReviewer_SkipFileAndQuit(t *testing.T) {
+	var out bytes.Buffer
+	reviewer := newReviewer("y\ns\nq\n", &out)
+
+	accepted, err := reviewer.ReviewFixes(context.Background(), "doc.md", []byte(trailingSpaces),
+		fileResult("MD009", "MD009", "MD009"))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, lines(accepted))
+
+	accepted, err = reviewer.ReviewFixes(context.Background(), "doc.md", []byte(trailingSpaces),
+		fileResult("MD009", "MD009", "MD009"))
+	require.NoError(t, err)
+	assert.Empty(t, accepted)
+
+	accepted, err = reviewer.ReviewFixes(context.Background(), "other.md", []byte(trailingSpaces),
+		fileResult("MD009", "MD009", "MD009"))
+	require.NoError(t, err)
+	assert.Empty(t, accepted)
+
+	accepted, err = reviewer.ReviewFixes(context.Background(), "third.md", []byte(trailingSpaces),
+		fileResult("MD009", "MD009", "MD009"))
+	require.NoError(t, err)
+	assert.Empty(t, accepted)
+	assert.Equal(t, 3, strings.Count(out.String(), "Apply this fix?"))
+}
+
+func TestReviewer_InvalidAnswer(t *testing.T) {
+	var out bytes.Buffer
+	reviewer := newReviewer("maybe\n\ny\n", &out)
+
+	result := fileResult("MD009", "MD009", "MD009")
+	result.Edits = result.Edits[:1]
+	accepted, err := reviewer.ReviewFixes(context.Background(), "doc.md", []byte(trailingSpaces), result)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, lines(accepted))
+	assert.Equal(t, 2, strings.Count(out.String(), "Please answer y, n, a, s or q."))
+}
+
+func TestReviewer_FixWithoutRule(t *testing.T) {
+	var out bytes.Buffer
+	reviewer := newReviewer("a\ny\n", &out)
+
+	result := fileResult("", "MD009", "MD009")
+	result.Edits = result.Edits[:1]
+	accepted, err := reviewer.ReviewFixes(context.Background(), "doc.md", []byte(trailingSpaces), result)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, lines(accepted))
+
+	output := out.String()
+	assert.Contains(t, output, "Apply this fix? [y]es, [n]o, [s]kip file, [q]uit:")
+	assert.Contains(t, output, "Please answer y, n, s or q.")
+}
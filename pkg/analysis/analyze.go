@@ -241,13 +241,14 @@ func sortRuleAnalysis(rules []RuleAnalysis, sortBy SortField, desc bool) {
 			if result == 0 {
 				result = cmp.Compare(right.Issues, left.Issues)
 			}
-			return result
+			return cmp.Or(result, cmp.Compare(left.RuleID, right.RuleID))
 		default: // SortByCount
 			result := cmp.Compare(left.Issues, right.Issues)
 			if desc {
 				result = -result
 			}
-			return result
+			// Ties keep a stable, alphabetical order.
+			return cmp.Or(result, cmp.Compare(left.RuleID, right.RuleID))
 		}
 	})
 }
@@ -267,13 +268,14 @@ func sortFileAnalysis(files []FileAnalysis, sortBy SortField, desc bool) {
 			if result == 0 {
 				result = cmp.Compare(right.Issues, left.Issues)
 			}
-			return result
+			return cmp.Or(result, cmp.Compare(left.Path, right.Path))
 		default: // SortByCount
 			result := cmp.Compare(left.Issues, right.Issues)
 			if desc {
 				result = -result
 			}
-			return result
+			// Ties keep a stable, alphabetical order.
+			return cmp.Or(result, cmp.Compare(left.Path, right.Path))
 		}
 	})
 }
@@ -0,0 +1,235 @@
+package analysis
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// Comparison describes how diagnostics changed between two results.
+type Comparison struct {
+	// Introduced are diagnostics in the new result without a counterpart in
+	// the old one.
+	Introduced []DiagnosticEntry `json:"introduced"`
+
+	// Resolved are diagnostics in the old result without a counterpart in
+	// the new one.
+	Resolved []DiagnosticEntry `json:"resolved"`
+
+	// Unchanged is the number of diagnostics present in both results.
+	Unchanged int `json:"unchanged"`
+
+	// ByRule summarizes changes per rule, largest net increase first.
+	ByRule []RuleDelta `json:"byRule"`
+
+	// ByFile summarizes changes per file, largest net increase first.
+	ByFile []FileDelta `json:"byFile"`
+}
+
+// HasIntroduced reports whether any diagnostics were introduced.
+func (c *Comparison) HasIntroduced() bool {
+	return c != nil && len(c.Introduced) > 0
+}
+
+// RuleDelta counts changes for one rule.
+type RuleDelta struct {
+	RuleID     string `json:"ruleId"`
+	RuleName   string `json:"ruleName"`
+	Introduced int    `json:"introduced"`
+	Resolved   int    `json:"resolved"`
+}
+
+// FileDelta counts changes for one file.
+type FileDelta struct {
+	Path       string `json:"path"`
+	Introduced int    `json:"introduced"`
+	Resolved   int    `json:"resolved"`
+}
+
+// compareKey identifies diagnostics that may be the same finding.
+// Line numbers are excluded so that findings survive edits elsewhere in
+// the file.
+type compareKey struct {
+	path    string
+	ruleID  string
+	message string
+}
+
+// Compare matches the diagnostics of oldResult and newResult. Diagnostics
+// match when they have the same file, rule and message; among candidates,
+// those on the same line are paired first, then the rest in line order.
+// Paths are made relative to opts.WorkingDir.
+func Compare(oldResult, newResult *runner.Result, opts Options) *Comparison {
+	oldByKey := groupEntries(oldResult, opts)
+	newByKey := groupEntries(newResult, opts)
+
+	comparison := &Comparison{
+		Introduced: make([]DiagnosticEntry, 0),
+		Resolved:   make([]DiagnosticEntry, 0),
+	}
+
+	for key, newEntries := range newByKey {
+		introduced, resolved := matchEntries(oldByKey[key], newEntries)
+		comparison.Introduced = append(comparison.Introduced, introduced...)
+		comparison.Resolved = append(comparison.Resolved, resolved...)
+		comparison.Unchanged += len(newEntries) - len(introduced)
+	}
+	for key, oldEntries := range oldByKey {
+		if _, ok := newByKey[key]; !ok {
+			comparison.Resolved = append(comparison.Resolved, oldEntries...)
+		}
+	}
+
+	sortEntries(comparison.Introduced)
+	sortEntries(comparison.Resolved)
+	comparison.ByRule = ruleDeltas(comparison)
+	comparison.ByFile = fileDeltas(comparison)
+
+	return comparison
+}
+
+// groupEntries collects the diagnostics of result by compareKey.
+func groupEntries(result *runner.Result, opts Options) map[compareKey][]DiagnosticEntry {
+	groups := make(map[compareKey][]DiagnosticEntry)
+	if result == nil {
+		return groups
+	}
+
+	for _, file := range result.Files {
+		if file.Result == nil || file.Result.FileResult == nil {
+			continue
+		}
+
+		path := makeRelativePath(file.Path, opts.WorkingDir)
+		for _, diag := range file.Result.Diagnostics {
+			entry := createDiagnosticEntry(path, normalizeSeverity(string(diag.Severity)), &diag)
+			key := compareKey{path: path, ruleID: diag.RuleID, message: diag.Message}
+			groups[key] = append(groups[key], entry)
+		}
+	}
+
+	for _, entries := range groups {
+		sortEntries(entries)
+	}
+	return groups
+}
+
+// matchEntries pairs old and new entries of one key and returns the
+// unpaired new (introduced) and old (resolved) entries.
+func matchEntries(oldEntries, newEntries []DiagnosticEntry) ([]DiagnosticEntry, []DiagnosticEntry) {
+	oldUsed := make([]bool, len(oldEntries))
+	newUsed := make([]bool, len(newEntries))
+
+	// Same line first.
+	for i, newEntry := range newEntries {
+		for j, oldEntry := range oldEntries {
+			if !oldUsed[j] && oldEntry.StartLine == newEntry.StartLine {
+				oldUsed[j], newUsed[i] = true, true
+				break
+			}
+		}
+	}
+
+	// Then the remaining entries in line order.
+	j := 0
+	for i := range newEntries {
+		if newUsed[i] {
+			continue
+		}
+		for j < len(oldEntries) && oldUsed[j] {
+			j++
+		}
+		if j == len(oldEntries) {
+			break
+		}
+		oldUsed[j], newUsed[i] = true, true
+	}
+
+	var introduced, resolved []DiagnosticEntry
+	for i, used := range newUsed {
+		if !used {
+			introduced = append(introduced, newEntries[i])
+		}
+	}
+	for j, used := range oldUsed {
+		if !used {
+			resolved = append(resolved, oldEntries[j])
+		}
+	}
+	return introduced, resolved
+}
+
+// sortEntries orders entries by path, line, column and rule.
+func sortEntries(entries []DiagnosticEntry) {
+	slices.SortFunc(entries, func(a, b DiagnosticEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.FilePath, b.FilePath),
+			cmp.Compare(a.StartLine, b.StartLine),
+			cmp.Compare(a.StartColumn, b.StartColumn),
+			cmp.Compare(a.RuleID, b.RuleID),
+		)
+	})
+}
+
+// ruleDeltas summarizes a comparison per rule.
+func ruleDeltas(c *Comparison) []RuleDelta {
+	byID := make(map[string]*RuleDelta)
+	get := func(entry DiagnosticEntry) *RuleDelta {
+		delta, ok := byID[entry.RuleID]
+		if !ok {
+			delta = &RuleDelta{RuleID: entry.RuleID, RuleName: entry.RuleName}
+			byID[entry.RuleID] = delta
+		}
+		return delta
+	}
+	for _, entry := range c.Introduced {
+		get(entry).Introduced++
+	}
+	for _, entry := range c.Resolved {
+		get(entry).Resolved++
+	}
+
+	deltas := make([]RuleDelta, 0, len(byID))
+	for _, delta := range byID {
+		deltas = append(deltas, *delta)
+	}
+	slices.SortFunc(deltas, func(a, b RuleDelta) int {
+		return cmp.Or(
+			cmp.Compare(b.Introduced-b.Resolved, a.Introduced-a.Resolved),
+			cmp.Compare(a.RuleID, b.RuleID),
+		)
+	})
+	return deltas
+}
+
+// fileDeltas summarizes a comparison per file.
+func fileDeltas(c *Comparison) []FileDelta {
+	byPath := make(map[string]*FileDelta)
+	get := func(entry DiagnosticEntry) *FileDelta {
+		delta, ok := byPath[entry.FilePath]
+		if !ok {
+			delta = &FileDelta{Path: entry.FilePath}
+			byPath[entry.FilePath] = delta
+		}
+		return delta
+	}
+	for _, entry := range c.Introduced {
+		get(entry).Introduced++
+	}
+	for _, entry := range c.Resolved {
+		get(entry).Resolved++
+	}
+
+	deltas := make([]FileDelta, 0, len(byPath))
+	for _, delta := range byPath {
+		deltas = append(deltas, *delta)
+	}
+	slices.SortFunc(deltas, func(a, b FileDelta) int {
+		return cmp.Or(
+			cmp.Compare(b.Introduced-b.Resolved, a.Introduced-a.Resolved),
+			cmp.Compare(a.Path, b.Path),
+		)
+	})
+	return deltas
+}
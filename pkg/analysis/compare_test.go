@@ -0,0 +1,107 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+func compareResult(files map[string][]lint.Diagnostic) *runner.Result {
+	result := runner.NewResult()
+	for path, diags := range files {
+		result.Add(runner.FileOutcome{
+			Path: path,
+			Result: &lint.PipelineResult{
+				FileResult: &lint.FileResult{Diagnostics: diags},
+			},
+		})
+	}
+	return result
+}
+
+func compareDiag(ruleID, message string, line int) lint.Diagnostic {
+	return lint.Diagnostic{
+		RuleID:      ruleID,
+		RuleName:    "rule-" + ruleID,
+		Message:     message,
+		Severity:    config.SeverityWarning,
+		StartLine:   line,
+		StartColumn: 1,
+	}
+}
+
+func TestCompare_IntroducedAndResolved(t *testing.T) {
+	t.Parallel()
+
+	oldResult := compareResult(map[string][]lint.Diagnostic{
+		"/repo/a.md": {
+			compareDiag("MD009", "Trailing spaces", 3),
+			compareDiag("MD013", "Line too long", 10),
+		},
+		"/repo/b.md": {compareDiag("MD001", "Heading increment", 1)},
+	})
+	newResult := compareResult(map[string][]lint.Diagnostic{
+		"/repo/a.md": {
+			// Moved down by an edit above it: still the same finding.
+			compareDiag("MD009", "Trailing spaces", 5),
+			compareDiag("MD009", "Trailing spaces", 8),
+		},
+		"/repo/b.md": {compareDiag("MD001", "Heading increment", 1)},
+	})
+
+	comparison := Compare(oldResult, newResult, Options{WorkingDir: "/repo"})
+
+	require.Len(t, comparison.Introduced, 1)
+	assert.Equal(t, "a.md", comparison.Introduced[0].FilePath)
+	assert.Equal(t, 8, comparison.Introduced[0].StartLine)
+	require.Len(t, comparison.Resolved, 1)
+	assert.Equal(t, "MD013", comparison.Resolved[0].RuleID)
+	assert.Equal(t, 2, comparison.Unchanged)
+	assert.True(t, comparison.HasIntroduced())
+
+	assert.Equal(t, []RuleDelta{
+		{RuleID: "MD009", RuleName: "rule-MD009", Introduced: 1},
+		{RuleID: "MD013", RuleName: "rule-MD013", Resolved: 1},
+	}, comparison.ByRule)
+	assert.Equal(t, []FileDelta{{Path: "a.md", Introduced: 1, Resolved: 1}}, comparison.ByFile)
+}
+
+func TestCompare_PrefersSameLine(t *testing.T) {
+	t.Parallel()
+
+	oldResult := compareResult(map[string][]lint.Diagnostic{
+		"a.md": {compareDiag("MD009", "Trailing spaces", 2), compareDiag("MD009", "Trailing spaces", 7)},
+	})
+	newResult := compareResult(map[string][]lint.Diagnostic{
+		"a.md": {compareDiag("MD009", "Trailing spaces", 7)},
+	})
+
+	comparison := Compare(oldResult, newResult, Options{})
+
+	assert.Empty(t, comparison.Introduced)
+	require.Len(t, comparison.Resolved, 1)
+	assert.Equal(t, 2, comparison.Resolved[0].StartLine)
+	assert.Equal(t, 1, comparison.Unchanged)
+}
+
+func TestCompare_NilResults(t *testing.T) {
+	t.Parallel()
+
+	newResult := compareResult(map[string][]lint.Diagnostic{
+		"a.md": {compareDiag("MD009", "Trailing spaces", 2)},
+	})
+
+	comparison := Compare(nil, newResult, Options{})
+	assert.Len(t, comparison.Introduced, 1)
+	assert.Empty(t, comparison.Resolved)
+
+	comparison = Compare(newResult, nil, Options{})
+	assert.Empty(t, comparison.Introduced)
+	assert.Len(t, comparison.Resolved, 1)
+	assert.False(t, comparison.HasIntroduced())
+}
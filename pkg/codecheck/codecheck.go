@@ -0,0 +1,278 @@
+// Package codecheck validates and formats the contents of code blocks.
+// It understands a small set of languages whose syntax can be checked with
+// the standard library or lightweight parsers: JSON, YAML, TOML and Go.
+package codecheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Canonical language names.
+const (
+	LangJSON = "json"
+	LangYAML = "yaml"
+	LangTOML = "toml"
+	LangGo   = "go"
+)
+
+// languageAliases maps lowercase info-string languages to canonical names.
+var languageAliases = map[string]string{
+	"json":   LangJSON,
+	"yaml":   LangYAML,
+	"yml":    LangYAML,
+	"toml":   LangTOML,
+	"go":     LangGo,
+	"golang": LangGo,
+}
+
+// displayNames holds the human-readable name of each canonical language.
+var displayNames = map[string]string{
+	LangJSON: "JSON",
+	LangYAML: "YAML",
+	LangTOML: "TOML",
+	LangGo:   "Go",
+}
+
+// Languages returns the canonical names of all checkable languages.
+func Languages() []string {
+	return []string{LangJSON, LangYAML, LangTOML, LangGo}
+}
+
+// Canonical returns the canonical language name for an info-string language,
+// or "" if the language is not supported.
+func Canonical(lang string) string {
+	return languageAliases[strings.ToLower(strings.TrimSpace(lang))]
+}
+
+// DisplayName returns the human-readable name for a canonical language.
+func DisplayName(lang string) string {
+	if name, ok := displayNames[lang]; ok {
+		return name
+	}
+	return lang
+}
+
+// SyntaxError describes a syntax error inside a code snippet.
+// Line and Column are 1-based and relative to the snippet.
+type SyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// Check parses src as the given canonical language.
+// It returns nil if the snippet is valid or the language is unsupported,
+// and a *SyntaxError otherwise.
+func Check(lang string, src []byte) error {
+	switch lang {
+	case LangJSON:
+		return checkJSON(src)
+	case LangYAML:
+		return checkYAML(src)
+	case LangTOML:
+		return checkTOML(src)
+	case LangGo:
+		return checkGo(src)
+	default:
+		return nil
+	}
+}
+
+// CanFormat reports whether Format supports the given canonical language.
+func CanFormat(lang string) bool {
+	return lang == LangJSON || lang == LangGo
+}
+
+// FormatOptions controls Format output.
+type FormatOptions struct {
+	// JSONIndent is the indentation string used for JSON. Defaults to two spaces.
+	JSONIndent string
+}
+
+// Format returns src reformatted in the canonical style for the language
+// (gofmt for Go, indented JSON for JSON). The result always ends with a
+// single newline. Returns an error if the language is not supported or
+// src does not parse.
+func Format(lang string, src []byte, opts FormatOptions) ([]byte, error) {
+	var out []byte
+	switch lang {
+	case LangJSON:
+		indent := opts.JSONIndent
+		if indent == "" {
+			indent = "  "
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, src, "", indent); err != nil {
+			return nil, fmt.Errorf("format JSON: %w", err)
+		}
+		out = buf.Bytes()
+	case LangGo:
+		formatted, err := format.Source(src)
+		if err != nil {
+			return nil, fmt.Errorf("format Go: %w", err)
+		}
+		out = formatted
+	default:
+		return nil, fmt.Errorf("formatting %s is not supported", DisplayName(lang))
+	}
+
+	out = bytes.TrimSpace(out)
+	return append(out, '\n'), nil
+}
+
+// checkJSON validates a JSON document.
+func checkJSON(src []byte) error {
+	var v any
+	err := json.Unmarshal(src, &v)
+	if err == nil {
+		return nil
+	}
+
+	var synErr *json.SyntaxError
+	if errors.As(err, &synErr) {
+		// Offset is the number of bytes read before the error; the
+		// offending byte is the last one read. Truncated input is
+		// reported just past the last significant byte.
+		offset := int(synErr.Offset) - 1
+		if strings.HasPrefix(synErr.Error(), "unexpected end") {
+			offset = len(bytes.TrimRight(src, " \t\r\n"))
+		}
+		line, col := lineCol(src, offset)
+		return &SyntaxError{Line: line, Column: col, Message: synErr.Error()}
+	}
+	return &SyntaxError{Line: 1, Column: 1, Message: err.Error()}
+}
+
+// yamlLinePattern extracts the line number from yaml.v3 error messages.
+var yamlLinePattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// checkYAML validates a (possibly multi-document) YAML stream.
+func checkYAML(src []byte) error {
+	dec := yaml.NewDecoder(bytes.NewReader(src))
+	for {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err == nil {
+			continue
+		}
+
+		// yaml.v3 reports line numbers but not columns.
+		if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			return &SyntaxError{Line: max(line, 1), Column: 1, Message: m[2]}
+		}
+		return &SyntaxError{Line: 1, Column: 1, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+	}
+}
+
+// checkTOML validates a TOML document.
+func checkTOML(src []byte) error {
+	var v map[string]any
+	_, err := toml.Decode(string(src), &v)
+	if err == nil {
+		return nil
+	}
+
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		return &SyntaxError{
+			Line:    max(parseErr.Position.Line, 1),
+			Column:  max(parseErr.Position.Col, 1),
+			Message: parseErr.Message,
+		}
+	}
+	return &SyntaxError{Line: 1, Column: 1, Message: err.Error()}
+}
+
+// Wrappers used to parse Go fragments, mirroring go/format. They are joined
+// to the source with ';' so that line numbers are preserved.
+const (
+	goDeclPrefix = "package p;"
+	goStmtPrefix = "package p; func _() {"
+)
+
+// checkGo validates Go source. Like gofmt, it accepts a complete file, a
+// list of declarations, or a list of statements.
+func checkGo(src []byte) error {
+	fset := token.NewFileSet()
+
+	_, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err == nil {
+		return nil
+	}
+	prefix := ""
+
+	if strings.Contains(err.Error(), "expected 'package'") {
+		prefix = goDeclPrefix
+		_, err = parser.ParseFile(fset, "", append([]byte(prefix), src...), parser.ParseComments)
+		if err == nil {
+			return nil
+		}
+
+		if strings.Contains(err.Error(), "expected declaration") {
+			prefix = goStmtPrefix
+			wrapped := append(append([]byte(prefix), src...), '\n', '\n', '}')
+			_, err = parser.ParseFile(fset, "", wrapped, parser.ParseComments)
+			if err == nil {
+				return nil
+			}
+		}
+	}
+
+	return goSyntaxError(err, src, len(prefix))
+}
+
+// goSyntaxError converts a go/parser error into a SyntaxError relative to src,
+// removing the wrapper prefix from first-line columns.
+func goSyntaxError(err error, src []byte, prefixLen int) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return &SyntaxError{Line: 1, Column: 1, Message: err.Error()}
+	}
+
+	first := list[0]
+	line, col := first.Pos.Line, first.Pos.Column
+	if line == 1 {
+		col -= prefixLen
+	}
+
+	// Errors reported in the closing wrapper or at EOF belong at the end of src.
+	lines := bytes.Split(bytes.TrimRight(src, "\n"), []byte("\n"))
+	if line > len(lines) {
+		line, col = lineCol(src, len(bytes.TrimRight(src, "\n")))
+	} else if line >= 1 {
+		col = min(col, len(lines[line-1])+1)
+	}
+
+	return &SyntaxError{Line: max(line, 1), Column: max(col, 1), Message: first.Msg}
+}
+
+// lineCol converts a byte offset in src to 1-based line and column numbers.
+func lineCol(src []byte, offset int) (int, int) {
+	offset = max(0, min(offset, len(src)))
+	line := 1 + bytes.Count(src[:offset], []byte("\n"))
+	lineStart := bytes.LastIndexByte(src[:offset], '\n') + 1
+	return line, offset - lineStart + 1
+}
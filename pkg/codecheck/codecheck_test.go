@@ -0,0 +1,149 @@
+package codecheck
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"json", LangJSON},
+		{"JSON", LangJSON},
+		{"yml", LangYAML},
+		{"yaml", LangYAML},
+		{"toml", LangTOML},
+		{"golang", LangGo},
+		{"go", LangGo},
+		{"jsonc", ""},
+		{"python", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Canonical(tt.input); got != tt.want {
+				t.Errorf("Canonical(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		src      string
+		wantErr  bool
+		wantLine int
+		wantCol  int
+	}{
+		{"json valid", LangJSON, "{\n  \"a\": [1, 2]\n}\n", false, 0, 0},
+		{"json trailing comma", LangJSON, "{\n  \"a\": 1,\n}\n", true, 3, 1},
+		{"json bad value", LangJSON, "{\"a\": tru}", true, 1, 10},
+		{"json truncated", LangJSON, "{\"a\": 1", true, 1, 8},
+		{"yaml valid", LangYAML, "a: 1\nb:\n  - x\n", false, 0, 0},
+		{"yaml multi document", LangYAML, "a: 1\n---\nb: 2\n", false, 0, 0},
+		{"yaml bad indent", LangYAML, "a: 1\n  b: 2\n", true, 2, 1},
+		{"yaml tab", LangYAML, "a:\n\t- x\n", true, 2, 1},
+		{"toml valid", LangTOML, "[server]\nport = 8080\n", false, 0, 0},
+		{"toml missing value", LangTOML, "[server]\nport = \n", true, 2, 8},
+		{"go file", LangGo, "package main\n\nfunc main() {}\n", false, 0, 0},
+		{"go declarations", LangGo, "func add(a, b int) int {\n\treturn a + b\n}\n", false, 0, 0},
+		{"go statements", LangGo, "x := 1\nfmt.Println(x)\n", false, 0, 0},
+		{"go statement error", LangGo, "x := 1\ny := )\n", true, 2, 6},
+		{"go first line column", LangGo, "x := )\n", true, 1, 6},
+		{"go unclosed brace", LangGo, "func f() {\n\treturn\n", true, 2, 8},
+		{"unsupported", "python", "def (", false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.lang, []byte(tt.src))
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Check() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var synErr *SyntaxError
+			if !errors.As(err, &synErr) {
+				t.Fatalf("Check() error = %v, want *SyntaxError", err)
+			}
+			if synErr.Line != tt.wantLine || synErr.Column != tt.wantCol {
+				t.Errorf("position = %d:%d, want %d:%d (%s)",
+					synErr.Line, synErr.Column, tt.wantLine, tt.wantCol, synErr.Message)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		lang    string
+		src     string
+		opts    FormatOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "json default indent",
+			lang: LangJSON,
+			src:  "{\"a\":[1,2],\"b\":{}}",
+			want: "{\n  \"a\": [\n    1,\n    2\n  ],\n  \"b\": {}\n}\n",
+		},
+		{
+			name: "json custom indent",
+			lang: LangJSON,
+			src:  "{\"a\":1}\n\n",
+			opts: FormatOptions{JSONIndent: "\t"},
+			want: "{\n\t\"a\": 1\n}\n",
+		},
+		{
+			name: "go file",
+			lang: LangGo,
+			src:  "package main\nfunc main(){\nx:=1\n_ = x}",
+			want: "package main\n\nfunc main() {\n\tx := 1\n\t_ = x\n}\n",
+		},
+		{
+			name: "go statements",
+			lang: LangGo,
+			src:  "x:=1\nfmt.Println( x )\n",
+			want: "x := 1\nfmt.Println(x)\n",
+		},
+		{
+			name:    "invalid json",
+			lang:    LangJSON,
+			src:     "{",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported language",
+			lang:    LangYAML,
+			src:     "a: 1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format(tt.lang, []byte(tt.src), tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Format() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
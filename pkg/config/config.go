@@ -22,7 +22,51 @@ type RuleConfig struct {
 // BackupsConfig controls backup behavior when fixing files.
 type BackupsConfig struct {
 	Enabled bool   `mapstructure:"enabled" yaml:"enabled"`
-	Mode    string `mapstructure:"mode" yaml:"mode"` // "sidecar", "xdg", etc.
+	Mode    string `mapstructure:"mode" yaml:"mode"` // "sidecar", "directory" or "none"
+}
+
+// LangDetectConfig controls code block language detection, used when
+// auto-fixing fenced code blocks without a language (MD040).
+type LangDetectConfig struct {
+	// Candidates lists the fence tags detection may choose from.
+	// Empty means the built-in candidate list.
+	Candidates []string `mapstructure:"candidates" yaml:"candidates,omitempty"`
+
+	// Aliases maps a detected fence tag to the tag that should be written,
+	// e.g. "bash: sh".
+	Aliases map[string]string `mapstructure:"aliases" yaml:"aliases,omitempty"`
+
+	// MinConfidence is the confidence (0-1) below which the detected
+	// language is only suggested, not inserted. Nil means the detector
+	// default.
+	MinConfidence *float64 `mapstructure:"min_confidence" yaml:"min_confidence,omitempty"`
+}
+
+// OrphansConfig configures the orphaned pages and unused assets report
+// produced by "gomdlint orphans".
+type OrphansConfig struct {
+	// EntryPoints are the pages reachability starts from, as paths or globs
+	// relative to the working directory. A mkdocs.yml entry contributes the
+	// pages listed in its nav. Empty means README.md, index.md and mkdocs.yml.
+	EntryPoints []string `mapstructure:"entry_points" yaml:"entry_points,omitempty"`
+
+	// Assets are the directories searched for asset files.
+	// Empty means the scanned paths.
+	Assets []string `mapstructure:"assets" yaml:"assets,omitempty"`
+
+	// AssetExtensions are the file extensions treated as assets.
+	// Empty means common image formats.
+	AssetExtensions []string `mapstructure:"asset_extensions" yaml:"asset_extensions,omitempty"`
+}
+
+// OutputConfig is one report written by a lint run.
+type OutputConfig struct {
+	// Format is the output format.
+	Format OutputFormat `mapstructure:"format" yaml:"format"`
+
+	// Path is the file to write, relative to the working directory.
+	// Empty or "-" means standard output.
+	Path string `mapstructure:"path" yaml:"path,omitempty"`
 }
 
 // OutputFormat specifies the output format for diagnostics.
@@ -32,9 +76,18 @@ const (
 	FormatText    OutputFormat = "text"
 	FormatTable   OutputFormat = "table"
 	FormatJSON    OutputFormat = "json"
+	FormatJSONL   OutputFormat = "jsonl"
 	FormatSARIF   OutputFormat = "sarif"
 	FormatDiff    OutputFormat = "diff"
 	FormatSummary OutputFormat = "summary"
+	FormatHTML    OutputFormat = "html"
+
+	// CI formats.
+	FormatGitHub     OutputFormat = "github"
+	FormatGitLab     OutputFormat = "gitlab"
+	FormatRDJSONL    OutputFormat = "rdjsonl"
+	FormatJUnit      OutputFormat = "junit"
+	FormatCheckstyle OutputFormat = "checkstyle"
 )
 
 // RuleFormat controls how rule identifiers appear in output.
@@ -72,11 +125,30 @@ type Flavor string
 const (
 	FlavorCommonMark Flavor = "commonmark"
 	FlavorGFM        Flavor = "gfm"
+	FlavorMDX        Flavor = "mdx"
+	FlavorObsidian   Flavor = "obsidian"
+	FlavorMkDocs     Flavor = "mkdocs"
+	FlavorPandoc     Flavor = "pandoc"
+)
+
+// EncodingPolicy controls how files that are not UTF-8 are linted.
+type EncodingPolicy string
+
+const (
+	// EncodingSkip reports the encoding of the file, and the positions of
+	// invalid UTF-8 bytes, without linting it (default).
+	EncodingSkip EncodingPolicy = "skip"
+	// EncodingTranscode lints the file decoded to UTF-8. Fixes are not
+	// written, since the file is not UTF-8.
+	EncodingTranscode EncodingPolicy = "transcode"
+	// EncodingConvert lints the file decoded to UTF-8 and, with --fix,
+	// writes it back as UTF-8.
+	EncodingConvert EncodingPolicy = "convert"
 )
 
 // Config is the root configuration structure for mdlint.
 type Config struct {
-	// Flavor specifies the Markdown flavor ("commonmark" or "gfm").
+	// Flavor specifies the Markdown flavor (see Flavors for valid values).
 	Flavor Flavor `mapstructure:"flavor" yaml:"flavor"`
 
 	// SeverityDefault is the default severity for rules that don't specify one.
@@ -88,9 +160,23 @@ type Config struct {
 	// Ignore contains glob patterns for files to ignore.
 	Ignore []string `mapstructure:"ignore" yaml:"ignore"`
 
+	// Encoding controls how files that are not UTF-8 are linted.
+	// Empty means EncodingSkip.
+	Encoding EncodingPolicy `mapstructure:"encoding" yaml:"encoding,omitempty"`
+
 	// Backups configures backup behavior when fixing.
 	Backups BackupsConfig `mapstructure:"backups" yaml:"backups"`
 
+	// LangDetect configures code block language detection.
+	LangDetect LangDetectConfig `mapstructure:"langdetect" yaml:"langdetect,omitempty"`
+
+	// Orphans configures the orphaned pages and unused assets report.
+	Orphans OrphansConfig `mapstructure:"orphans" yaml:"orphans,omitempty"`
+
+	// Outputs lists the reports written by a lint run, so one run can
+	// produce several formats. Empty means Format on standard output.
+	Outputs []OutputConfig `mapstructure:"outputs" yaml:"outputs,omitempty"`
+
 	// CLI-level options (not persisted to config files).
 
 	// Fix enables auto-fixing of issues.
@@ -119,6 +205,14 @@ type Config struct {
 
 	// NoBackups disables backup creation when fixing.
 	NoBackups bool `mapstructure:"-" yaml:"-"`
+
+	// UnsafeFixes also applies fixes whose applicability is unsafe, such as
+	// guessed code languages. Only safe fixes are applied otherwise.
+	UnsafeFixes bool `mapstructure:"-" yaml:"-"`
+
+	// VerifyFixes rejects fixes that change the rendered document, except
+	// those of rules whose fixes are meant to.
+	VerifyFixes bool `mapstructure:"-" yaml:"-"`
 }
 
 // NewConfig returns a Config with sensible defaults.
@@ -0,0 +1,54 @@
+package config
+
+// Flavors returns all supported Markdown flavors in display order.
+func Flavors() []Flavor {
+	return []Flavor{
+		FlavorCommonMark,
+		FlavorGFM,
+		FlavorMDX,
+		FlavorObsidian,
+		FlavorMkDocs,
+		FlavorPandoc,
+	}
+}
+
+// IsValid returns true if the flavor is a known flavor.
+func (f Flavor) IsValid() bool {
+	switch f {
+	case FlavorCommonMark, FlavorGFM, FlavorMDX, FlavorObsidian, FlavorMkDocs, FlavorPandoc:
+		return true
+	default:
+		return false
+	}
+}
+
+// SupportsTables returns true if the flavor parses pipe tables.
+// Every flavor except pure CommonMark ships a table extension.
+func (f Flavor) SupportsTables() bool {
+	switch f {
+	case FlavorGFM, FlavorMDX, FlavorObsidian, FlavorMkDocs, FlavorPandoc:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsGFMBased returns true if the flavor is a superset of GitHub Flavored Markdown.
+// GFM-based flavors share GFM defaults such as the allowed inline HTML elements.
+func (f Flavor) IsGFMBased() bool {
+	switch f {
+	case FlavorGFM, FlavorMDX, FlavorObsidian:
+		return true
+	default:
+		return false
+	}
+}
+
+// FileExtensions returns the file extensions (lowercase, with leading dot)
+// that are discovered by default for the flavor.
+func (f Flavor) FileExtensions() []string {
+	if f == FlavorMDX {
+		return []string{".md", ".markdown", ".mdx"}
+	}
+	return []string{".md", ".markdown"}
+}
@@ -0,0 +1,52 @@
+package config_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+)
+
+func TestFlavor_IsValid(t *testing.T) {
+	for _, f := range config.Flavors() {
+		if !f.IsValid() {
+			t.Errorf("%q.IsValid() = false, want true", f)
+		}
+	}
+
+	for _, f := range []config.Flavor{"", "markdown", "GFM"} {
+		if f.IsValid() {
+			t.Errorf("%q.IsValid() = true, want false", f)
+		}
+	}
+}
+
+func TestFlavor_Capabilities(t *testing.T) {
+	tests := []struct {
+		flavor   config.Flavor
+		tables   bool
+		gfmBased bool
+		wantMDX  bool
+	}{
+		{config.FlavorCommonMark, false, false, false},
+		{config.FlavorGFM, true, true, false},
+		{config.FlavorMDX, true, true, true},
+		{config.FlavorObsidian, true, true, false},
+		{config.FlavorMkDocs, true, false, false},
+		{config.FlavorPandoc, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.flavor), func(t *testing.T) {
+			if got := tt.flavor.SupportsTables(); got != tt.tables {
+				t.Errorf("SupportsTables() = %v, want %v", got, tt.tables)
+			}
+			if got := tt.flavor.IsGFMBased(); got != tt.gfmBased {
+				t.Errorf("IsGFMBased() = %v, want %v", got, tt.gfmBased)
+			}
+			if got := slices.Contains(tt.flavor.FileExtensions(), ".mdx"); got != tt.wantMDX {
+				t.Errorf("FileExtensions() includes .mdx = %v, want %v", got, tt.wantMDX)
+			}
+		})
+	}
+}
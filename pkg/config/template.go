@@ -63,7 +63,7 @@ func generateMinimalTemplate(opts TemplateOptions) ([]byte, error) {
 	buf.WriteString(`# gomdlint configuration
 # See: https://github.com/yaklabco/gomdlint
 
-# Markdown flavor: commonmark or gfm
+# Markdown flavor: commonmark, gfm, mdx, obsidian, mkdocs, or pandoc
 flavor: commonmark
 
 # Default severity for all rules: error, warning, or info
@@ -75,6 +75,11 @@ flavor: commonmark
 # Number of parallel workers (0 = auto)
 # jobs: 0
 
+# Files that are not UTF-8: skip reports their encoding and invalid bytes
+# without linting them, transcode lints them decoded to UTF-8 without writing
+# fixes, convert also writes them back as UTF-8 with --fix
+# encoding: skip
+
 # File patterns to ignore (glob patterns)
 # ignore:
 #   - "vendor/**"
@@ -109,7 +114,7 @@ func generateFullTemplate(opts TemplateOptions) ([]byte, error) {
 # This template includes all available rules with their default settings.
 # Uncomment and modify settings as needed.
 
-# Markdown flavor: commonmark or gfm
+# Markdown flavor: commonmark, gfm, mdx, obsidian, mkdocs, or pandoc
 flavor: commonmark
 
 # Default severity for all rules: error, warning, or info
@@ -124,14 +129,37 @@ dry_run: false
 # Number of parallel workers (0 = auto based on CPU cores)
 jobs: 0
 
-# Output format: text, json, sarif, or diff
+# Output format: text, table, json, jsonl, sarif, diff, summary, html, github, gitlab, rdjsonl, junit, or checkstyle
 format: text
 
-# Backup configuration for auto-fix
+# Write several reports from one lint run (same as repeated --output flags).
+# An output without a path writes to stdout.
+# outputs:
+#   - format: text
+#   - format: sarif
+#     path: results.sarif
+#   - format: json
+#     path: report.json
+
+# Backup configuration for auto-fix. mode is sidecar (file.md.gomdlint.bak),
+# directory (snapshots in .gomdlint/backups/<run-id>/) or none.
 backups:
   enabled: true
   mode: sidecar
 
+# Language detection used by the MD040 autofix (debug with gomdlint detect-lang)
+# langdetect:
+#   candidates: [go, python, bash, console, javascript, typescript, json, yaml]
+#   aliases:
+#     bash: sh
+#   min_confidence: 0.5
+
+# Orphaned pages and unused assets (gomdlint orphans)
+# orphans:
+#   entry_points: [README.md, index.md, mkdocs.yml]
+#   assets: [docs/img]
+#   asset_extensions: [.png, .jpg, .gif, .svg]
+
 # File patterns to ignore (glob patterns)
 ignore:
   - "vendor/**"
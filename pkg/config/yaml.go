@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"maps"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
@@ -102,6 +103,8 @@ func (c *Config) copyCLIFields(target *Config) {
 	target.RuleFormat = c.RuleFormat
 	target.Jobs = c.Jobs
 	target.NoBackups = c.NoBackups
+	target.UnsafeFixes = c.UnsafeFixes
+	target.VerifyFixes = c.VerifyFixes
 
 	// Deep copy CLI-only slices
 	if c.EnableRules != nil {
@@ -124,6 +127,7 @@ func (c *Config) deepCopy() *Config {
 	clone := &Config{
 		Flavor:          c.Flavor,
 		SeverityDefault: c.SeverityDefault,
+		Encoding:        c.Encoding,
 		Backups:         c.Backups, // BackupsConfig only has value types
 		Fix:             c.Fix,
 		DryRun:          c.DryRun,
@@ -131,6 +135,8 @@ func (c *Config) deepCopy() *Config {
 		RuleFormat:      c.RuleFormat,
 		Jobs:            c.Jobs,
 		NoBackups:       c.NoBackups,
+		UnsafeFixes:     c.UnsafeFixes,
+		VerifyFixes:     c.VerifyFixes,
 	}
 
 	// Deep copy Ignore slice
@@ -139,6 +145,10 @@ func (c *Config) deepCopy() *Config {
 		copy(clone.Ignore, c.Ignore)
 	}
 
+	clone.LangDetect = c.LangDetect.clone()
+	clone.Orphans = c.Orphans.clone()
+	clone.Outputs = slices.Clone(c.Outputs)
+
 	// Deep copy Rules map
 	if c.Rules != nil {
 		clone.Rules = make(map[string]RuleConfig, len(c.Rules))
@@ -168,6 +178,37 @@ func (c *Config) deepCopy() *Config {
 	return clone
 }
 
+// clone creates a deep copy of a LangDetectConfig.
+func (ld LangDetectConfig) clone() LangDetectConfig {
+	clone := LangDetectConfig{}
+
+	if ld.Candidates != nil {
+		clone.Candidates = make([]string, len(ld.Candidates))
+		copy(clone.Candidates, ld.Candidates)
+	}
+
+	if ld.Aliases != nil {
+		clone.Aliases = make(map[string]string, len(ld.Aliases))
+		maps.Copy(clone.Aliases, ld.Aliases)
+	}
+
+	if ld.MinConfidence != nil {
+		minConfidence := *ld.MinConfidence
+		clone.MinConfidence = &minConfidence
+	}
+
+	return clone
+}
+
+// clone creates a deep copy of an OrphansConfig.
+func (oc OrphansConfig) clone() OrphansConfig {
+	return OrphansConfig{
+		EntryPoints:     slices.Clone(oc.EntryPoints),
+		Assets:          slices.Clone(oc.Assets),
+		AssetExtensions: slices.Clone(oc.AssetExtensions),
+	}
+}
+
 // clone creates a deep copy of a RuleConfig.
 func (rc RuleConfig) clone() RuleConfig {
 	clone := RuleConfig{}
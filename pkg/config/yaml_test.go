@@ -71,6 +71,63 @@ func TestConfigClone(t *testing.T) {
 		assert.Equal(t, "*.md", original.Ignore[0])
 	})
 
+	t.Run("deep copies LangDetect", func(t *testing.T) {
+		minConfidence := 0.7
+		original := &config.Config{
+			LangDetect: config.LangDetectConfig{
+				Candidates:    []string{"go", "python"},
+				Aliases:       map[string]string{"bash": "sh"},
+				MinConfidence: &minConfidence,
+			},
+		}
+
+		clone := original.Clone()
+		require.NotNil(t, clone)
+		assert.Equal(t, original.LangDetect, clone.LangDetect)
+
+		// Verify modifying clone doesn't affect original
+		clone.LangDetect.Candidates[0] = "rust"
+		clone.LangDetect.Aliases["bash"] = "shell"
+		*clone.LangDetect.MinConfidence = 0.1
+		assert.Equal(t, "go", original.LangDetect.Candidates[0])
+		assert.Equal(t, "sh", original.LangDetect.Aliases["bash"])
+		assert.InDelta(t, 0.7, *original.LangDetect.MinConfidence, 0)
+	})
+
+	t.Run("deep copies Orphans", func(t *testing.T) {
+		original := &config.Config{
+			Orphans: config.OrphansConfig{
+				EntryPoints:     []string{"README.md"},
+				Assets:          []string{"docs/img"},
+				AssetExtensions: []string{".png"},
+			},
+		}
+
+		clone := original.Clone()
+		require.NotNil(t, clone)
+		assert.Equal(t, original.Orphans, clone.Orphans)
+
+		clone.Orphans.EntryPoints[0] = "index.md"
+		clone.Orphans.Assets[0] = "assets"
+		clone.Orphans.AssetExtensions[0] = ".svg"
+		assert.Equal(t, "README.md", original.Orphans.EntryPoints[0])
+		assert.Equal(t, "docs/img", original.Orphans.Assets[0])
+		assert.Equal(t, ".png", original.Orphans.AssetExtensions[0])
+	})
+
+	t.Run("deep copies Outputs", func(t *testing.T) {
+		original := &config.Config{
+			Outputs: []config.OutputConfig{{Format: config.FormatSARIF, Path: "results.sarif"}},
+		}
+
+		clone := original.Clone()
+		require.NotNil(t, clone)
+		assert.Equal(t, original.Outputs, clone.Outputs)
+
+		clone.Outputs[0].Path = "other.sarif"
+		assert.Equal(t, "results.sarif", original.Outputs[0].Path)
+	})
+
 	t.Run("preserves all fields", func(t *testing.T) {
 		enabled := true
 		original := &config.Config{
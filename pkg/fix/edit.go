@@ -11,6 +11,27 @@ type TextEdit struct {
 
 	// NewText is the replacement text.
 	NewText string
+
+	// Origin identifies the diagnostic that proposed the edit. It is set by
+	// the lint engine and does not affect how the edit is applied.
+	Origin Origin
+}
+
+// Origin identifies the rule and diagnostic that proposed an edit.
+type Origin struct {
+	// RuleID is the identifier of the rule (e.g., "MD009").
+	RuleID string
+
+	// Line and Column are the 1-based start of the diagnostic.
+	Line   int
+	Column int
+}
+
+// Conflict pairs an edit skipped because of an overlap with the accepted
+// edit it overlaps.
+type Conflict struct {
+	Skipped  TextEdit
+	Accepted TextEdit
 }
 
 // EditBuilder accumulates text edits for a file.
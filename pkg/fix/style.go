@@ -0,0 +1,73 @@
+package fix
+
+import (
+	"bytes"
+	"strings"
+)
+
+// utf8BOM is the UTF-8 encoding of the byte order mark.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// TextStyle is the line-ending style and byte order mark of a file, which
+// fixes keep. Rules write their replacement text with LF line endings.
+type TextStyle struct {
+	// CRLF is true if more lines of the file end with CRLF than with LF.
+	CRLF bool
+
+	// BOM is true if the file starts with a UTF-8 byte order mark.
+	BOM bool
+}
+
+// DetectTextStyle returns the style of content.
+func DetectTextStyle(content []byte) TextStyle {
+	crlf := bytes.Count(content, []byte("\r\n"))
+	lf := bytes.Count(content, []byte("\n")) - crlf
+	return TextStyle{
+		CRLF: crlf > lf,
+		BOM:  bytes.HasPrefix(content, utf8BOM),
+	}
+}
+
+// ApplyEdits applies edits to content like the ApplyEdits function, in
+// style s: in a CRLF style the bare LFs of the new text become CRLF, and
+// in a BOM style a byte order mark that the edits remove is added back.
+// Line endings the edits leave in place are not changed.
+func (s TextStyle) ApplyEdits(content []byte, edits []TextEdit) []byte {
+	content = ApplyEdits(content, s.StyleEdits(edits))
+	if s.BOM && !bytes.HasPrefix(content, utf8BOM) {
+		content = append(bytes.Clone(utf8BOM), content...)
+	}
+	return content
+}
+
+// StyleEdits returns edits with their new text in style s, as ApplyEdits
+// applies them: in a CRLF style the bare LFs of the new text become CRLF.
+// edits is returned as it is if no edit changes.
+func (s TextStyle) StyleEdits(edits []TextEdit) []TextEdit {
+	if !s.CRLF {
+		return edits
+	}
+	styled := make([]TextEdit, len(edits))
+	for i, edit := range edits {
+		edit.NewText = crlfText(edit.NewText)
+		styled[i] = edit
+	}
+	return styled
+}
+
+// crlfText returns text with its bare LFs replaced by CRLF.
+func crlfText(text string) string {
+	if !strings.Contains(text, "\n") {
+		return text
+	}
+
+	var out strings.Builder
+	out.Grow(len(text) + strings.Count(text, "\n"))
+	for i := range len(text) {
+		if text[i] == '\n' && (i == 0 || text[i-1] != '\r') {
+			out.WriteByte('\r')
+		}
+		out.WriteByte(text[i])
+	}
+	return out.String()
+}
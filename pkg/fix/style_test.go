@@ -0,0 +1,86 @@
+package fix_test
+
+import (
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/fix"
+)
+
+func TestDetectTextStyle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    fix.TextStyle
+	}{
+		{"empty", "", fix.TextStyle{}},
+		{"LF", "a\nb\n", fix.TextStyle{}},
+		{"CRLF", "a\r\nb\r\n", fix.TextStyle{CRLF: true}},
+		{"mixed, mostly LF", "a\r\nb\nc\n", fix.TextStyle{}},
+		{"mixed, mostly CRLF", "a\r\nb\nc\r\n", fix.TextStyle{CRLF: true}},
+		{"no line break", "a", fix.TextStyle{}},
+		{"BOM", "\ufeffa\n", fix.TextStyle{BOM: true}},
+		{"BOM and CRLF", "\ufeffa\r\n", fix.TextStyle{CRLF: true, BOM: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := fix.DetectTextStyle([]byte(tt.content)); got != tt.want {
+				t.Errorf("DetectTextStyle(%q) = %+v, want %+v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextStyle_ApplyEdits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		style   fix.TextStyle
+		content string
+		edits   []fix.TextEdit
+		want    string
+	}{
+		{
+			name:    "LF style keeps new text",
+			content: "a\r\nb\r\n",
+			edits:   []fix.TextEdit{{StartOffset: 0, EndOffset: 1, NewText: "x\ny"}},
+			want:    "x\ny\r\nb\r\n",
+		},
+		{
+			name:    "CRLF style converts new text",
+			style:   fix.TextStyle{CRLF: true},
+			content: "a\r\nb\nc\r\n",
+			edits:   []fix.TextEdit{{StartOffset: 0, EndOffset: 1, NewText: "\nx\r\ny\n"}},
+			want:    "\r\nx\r\ny\r\n\r\nb\nc\r\n",
+		},
+		{
+			name:    "BOM restored",
+			style:   fix.TextStyle{BOM: true},
+			content: "\ufeffa\n",
+			edits:   []fix.TextEdit{{StartOffset: 0, EndOffset: 4, NewText: "b"}},
+			want:    "\ufeffb\n",
+		},
+		{
+			name:    "BOM kept",
+			style:   fix.TextStyle{BOM: true},
+			content: "\ufeffa\n",
+			edits:   []fix.TextEdit{{StartOffset: 3, EndOffset: 4, NewText: "b"}},
+			want:    "\ufeffb\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := string(tt.style.ApplyEdits([]byte(tt.content), tt.edits)); got != tt.want {
+				t.Errorf("ApplyEdits() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
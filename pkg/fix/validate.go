@@ -47,10 +47,11 @@ func ValidateEdits(edits []TextEdit, contentLen int) error {
 	return nil
 }
 
-// SortEdits sorts edits by start offset, then by end offset.
+// SortEdits sorts edits by start offset, then by end offset. Edits with the
+// same range keep their order, so the first one proposed wins a conflict.
 // This produces a deterministic order for edit application.
 func SortEdits(edits []TextEdit) {
-	sort.Slice(edits, func(i, j int) bool {
+	sort.SliceStable(edits, func(i, j int) bool {
 		if edits[i].StartOffset != edits[j].StartOffset {
 			return edits[i].StartOffset < edits[j].StartOffset
 		}
@@ -103,12 +104,14 @@ func canMerge(a, b TextEdit) bool {
 
 // mergeEdits merges two overlapping deletion edits into one.
 // Both edits must have empty NewText (deletions only).
-// Returns the merged edit covering the union of both ranges.
+// Returns the merged edit covering the union of both ranges, with the
+// origin of a.
 func mergeEdits(a, b TextEdit) TextEdit {
 	return TextEdit{
 		StartOffset: min(a.StartOffset, b.StartOffset),
 		EndOffset:   max(a.EndOffset, b.EndOffset),
 		NewText:     "",
+		Origin:      a.Origin,
 	}
 }
 
@@ -145,6 +148,28 @@ func FilterConflicts(edits []TextEdit) ([]TextEdit, []TextEdit) {
 	return accepted, skipped
 }
 
+// FindConflicts returns, for each skipped edit, the accepted edit it
+// overlaps. accepted and skipped are the results of FilterConflicts or
+// MergeAndFilterConflicts. Skipped edits that overlap no accepted edit are
+// left out.
+func FindConflicts(accepted, skipped []TextEdit) []Conflict {
+	var conflicts []Conflict
+	for _, edit := range skipped {
+		// The skipped edit starts inside the last accepted edit that
+		// starts at or before it.
+		i := sort.Search(len(accepted), func(i int) bool {
+			return accepted[i].StartOffset > edit.StartOffset
+		}) - 1
+		for ; i >= 0; i-- {
+			if edit.StartOffset < accepted[i].EndOffset {
+				conflicts = append(conflicts, Conflict{Skipped: edit, Accepted: accepted[i]})
+				break
+			}
+		}
+	}
+	return conflicts
+}
+
 // MergeAndFilterConflicts attempts to merge overlapping deletions, then filters
 // any remaining conflicts. This is safer than pure filtering because overlapping
 // deletions can be combined into a single deletion covering the union.
@@ -154,15 +179,16 @@ func FilterConflicts(edits []TextEdit) ([]TextEdit, []TextEdit) {
 // Returns:
 //   - accepted: edits to apply (merged where possible)
 //   - skipped: edits that couldn't be merged or applied
-//   - merged: count of edits that were merged (for reporting)
-func MergeAndFilterConflicts(edits []TextEdit) ([]TextEdit, []TextEdit, int) {
+//   - merged: edits merged into an accepted edit, which keeps the origin of
+//     the first edit it covers (for reporting)
+func MergeAndFilterConflicts(edits []TextEdit) ([]TextEdit, []TextEdit, []TextEdit) {
 	if len(edits) == 0 {
-		return nil, nil, 0
+		return nil, nil, nil
 	}
 
 	accepted := make([]TextEdit, 0, len(edits))
 	skipped := make([]TextEdit, 0)
-	merged := 0
+	var merged []TextEdit
 
 	// Start with first edit
 	current := edits[0]
@@ -179,7 +205,7 @@ func MergeAndFilterConflicts(edits []TextEdit) ([]TextEdit, []TextEdit, int) {
 			if canMerge(current, edit) {
 				// Both are deletions - merge them
 				current = mergeEdits(current, edit)
-				merged++
+				merged = append(merged, edit)
 			} else {
 				// Can't merge - skip the later edit
 				skipped = append(skipped, edit)
@@ -196,15 +222,15 @@ func MergeAndFilterConflicts(edits []TextEdit) ([]TextEdit, []TextEdit, int) {
 // PrepareEditsFiltered validates, sorts, merges, and filters conflicting edits.
 // Unlike PrepareEdits, it does not error on conflicts - it merges deletions
 // and filters remaining conflicts.
-// Returns (accepted edits, skipped edits, merged count, error).
+// Returns (accepted edits, skipped edits, merged edits, error).
 // Error only for validation failures.
-func PrepareEditsFiltered(edits []TextEdit, contentLen int) ([]TextEdit, []TextEdit, int, error) {
+func PrepareEditsFiltered(edits []TextEdit, contentLen int) ([]TextEdit, []TextEdit, []TextEdit, error) {
 	if len(edits) == 0 {
-		return nil, nil, 0, nil
+		return nil, nil, nil, nil
 	}
 
 	if err := ValidateEdits(edits, contentLen); err != nil {
-		return nil, nil, 0, err
+		return nil, nil, nil, err
 	}
 
 	sorted := make([]TextEdit, len(edits))
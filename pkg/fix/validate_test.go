@@ -239,6 +239,71 @@ func TestDetectConflicts(t *testing.T) {
 	}
 }
 
+func TestFindConflicts(t *testing.T) {
+	t.Parallel()
+
+	first := fix.TextEdit{StartOffset: 0, EndOffset: 5, NewText: "a", Origin: fix.Origin{RuleID: "MD001"}}
+	second := fix.TextEdit{StartOffset: 10, EndOffset: 15, NewText: "b", Origin: fix.Origin{RuleID: "MD002"}}
+	accepted := []fix.TextEdit{first, second}
+
+	inFirst := fix.TextEdit{StartOffset: 3, EndOffset: 8, Origin: fix.Origin{RuleID: "MD003"}}
+	atSecond := fix.TextEdit{StartOffset: 10, EndOffset: 12, Origin: fix.Origin{RuleID: "MD004"}}
+	between := fix.TextEdit{StartOffset: 6, EndOffset: 7, Origin: fix.Origin{RuleID: "MD005"}}
+
+	conflicts := fix.FindConflicts(accepted, []fix.TextEdit{inFirst, atSecond, between})
+
+	want := []fix.Conflict{
+		{Skipped: inFirst, Accepted: first},
+		{Skipped: atSecond, Accepted: second},
+	}
+	if len(conflicts) != len(want) {
+		t.Fatalf("got %d conflicts, want %d: %+v", len(conflicts), len(want), conflicts)
+	}
+	for i := range want {
+		if conflicts[i] != want[i] {
+			t.Errorf("conflict[%d] = %+v, want %+v", i, conflicts[i], want[i])
+		}
+	}
+}
+
+func TestMergeAndFilterConflicts_KeepsOrigins(t *testing.T) {
+	t.Parallel()
+
+	first := fix.TextEdit{StartOffset: 2, EndOffset: 6, Origin: fix.Origin{RuleID: "MD009", Line: 1, Column: 3}}
+	second := fix.TextEdit{StartOffset: 4, EndOffset: 8, Origin: fix.Origin{RuleID: "MD012", Line: 2, Column: 1}}
+
+	accepted, skipped, merged := fix.MergeAndFilterConflicts([]fix.TextEdit{first, second})
+
+	if len(accepted) != 1 || len(skipped) != 0 {
+		t.Fatalf("accepted %+v, skipped %+v; want one merged edit", accepted, skipped)
+	}
+	want := fix.TextEdit{StartOffset: 2, EndOffset: 8, Origin: first.Origin}
+	if accepted[0] != want {
+		t.Errorf("merged edit = %+v, want %+v", accepted[0], want)
+	}
+	if len(merged) != 1 || merged[0] != second {
+		t.Errorf("merged = %+v, want [%+v]", merged, second)
+	}
+}
+
+func TestSortEdits_KeepsOrderOfEqualRanges(t *testing.T) {
+	t.Parallel()
+
+	edits := []fix.TextEdit{
+		{StartOffset: 4, EndOffset: 6, Origin: fix.Origin{RuleID: "MD003"}},
+		{StartOffset: 0, EndOffset: 2, Origin: fix.Origin{RuleID: "MD001"}},
+		{StartOffset: 0, EndOffset: 2, Origin: fix.Origin{RuleID: "MD002"}},
+	}
+
+	fix.SortEdits(edits)
+
+	for i, want := range []string{"MD001", "MD002", "MD003"} {
+		if edits[i].Origin.RuleID != want {
+			t.Errorf("edit[%d] from %s, want %s", i, edits[i].Origin.RuleID, want)
+		}
+	}
+}
+
 func TestPrepareEdits(t *testing.T) {
 	t.Parallel()
 
@@ -632,8 +697,8 @@ func TestMergeAndFilterConflicts(t *testing.T) {
 			accepted, skipped, merged := fix.MergeAndFilterConflicts(tt.edits)
 
 			// Check merged count
-			if merged != tt.wantMerged {
-				t.Errorf("merged count: got %d, want %d", merged, tt.wantMerged)
+			if len(merged) != tt.wantMerged {
+				t.Errorf("merged count: got %d, want %d", len(merged), tt.wantMerged)
 			}
 
 			// Check accepted
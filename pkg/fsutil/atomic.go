@@ -23,6 +23,12 @@ const DefaultFileMode os.FileMode = 0644
 //
 // On error, the temp file is cleaned up and the original file remains untouched.
 func WriteAtomic(ctx context.Context, path string, content []byte, mode os.FileMode) error {
+	return writeAtomic(ctx, path, content, mode, nil)
+}
+
+// writeAtomic implements WriteAtomic. If prepare is not nil, it is called
+// with the path of the temp file before its mode is set.
+func writeAtomic(ctx context.Context, path string, content []byte, mode os.FileMode, prepare func(tmpPath string) error) error {
 	select {
 	case <-ctx.Done():
 		return fmt.Errorf("write atomic: %w", ctx.Err())
@@ -66,6 +72,12 @@ func WriteAtomic(ctx context.Context, path string, content []byte, mode os.FileM
 		return fmt.Errorf("close temp file: %w", err)
 	}
 
+	if prepare != nil {
+		if err := prepare(tmpPath); err != nil {
+			return err
+		}
+	}
+
 	// Set mode before rename.
 	if err := os.Chmod(tmpPath, mode); err != nil {
 		return fmt.Errorf("chmod temp file: %w", err)
@@ -2,6 +2,7 @@ package fsutil
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 )
@@ -13,6 +14,10 @@ const (
 	// BackupModeSidecar stores backups alongside the original file with a .gomdlint.bak suffix.
 	BackupModeSidecar BackupMode = "sidecar"
 
+	// BackupModeDirectory stores the backups of each run in a snapshot
+	// directory under BackupDir, with a manifest (see BackupRun).
+	BackupModeDirectory BackupMode = "directory"
+
 	// BackupModeNone disables backups.
 	BackupModeNone BackupMode = "none"
 )
@@ -27,6 +32,9 @@ type BackupConfig struct {
 
 	// Mode specifies how backups are stored.
 	Mode BackupMode
+
+	// Run is the snapshot of the current run in BackupModeDirectory.
+	Run *BackupRun
 }
 
 // DefaultBackupConfig returns sensible backup defaults.
@@ -39,11 +47,12 @@ func DefaultBackupConfig() BackupConfig {
 }
 
 // BackupPath returns the backup path for the given file based on the mode.
+// It is empty for BackupModeDirectory, whose paths depend on the run.
 func BackupPath(path string, mode BackupMode) string {
 	switch mode {
 	case BackupModeSidecar:
 		return path + BackupSuffix
-	case BackupModeNone:
+	case BackupModeDirectory, BackupModeNone:
 		return ""
 	default:
 		// Default to sidecar mode for unknown modes.
@@ -54,6 +63,8 @@ func BackupPath(path string, mode BackupMode) string {
 // CreateBackup creates a backup of the file at path if one does not already exist.
 // Returns true if a backup was created, false if it already existed or backups are disabled.
 //
+// In BackupModeDirectory the file is added to cfg.Run.
+//
 // Backup creation is idempotent: if a backup already exists, it is not overwritten.
 // This ensures that repeated runs do not lose the original file content.
 func CreateBackup(ctx context.Context, path string, cfg BackupConfig) (bool, error) {
@@ -67,6 +78,13 @@ func CreateBackup(ctx context.Context, path string, cfg BackupConfig) (bool, err
 	default:
 	}
 
+	if cfg.Mode == BackupModeDirectory {
+		if cfg.Run == nil {
+			return false, errors.New("directory backups need a backup run")
+		}
+		return cfg.Run.Add(ctx, path)
+	}
+
 	backupPath := BackupPath(path, cfg.Mode)
 	if backupPath == "" {
 		return false, nil
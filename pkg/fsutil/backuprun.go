@@ -0,0 +1,390 @@
+package fsutil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BackupDir is the slash-separated directory, relative to the root of a
+// run, that holds the snapshots of BackupModeDirectory.
+const BackupDir = ".gomdlint/backups"
+
+// BackupManifestName is the name of the manifest file in a snapshot.
+const BackupManifestName = "manifest.json"
+
+// backupIDFormat formats the time a snapshot was taken as its ID.
+const backupIDFormat = "20060102T150405Z"
+
+// BackupManifest describes a snapshot: the files one run backed up.
+type BackupManifest struct {
+	// ID names the snapshot and its directory.
+	ID string `json:"id"`
+
+	// Created is when the snapshot was taken.
+	Created time.Time `json:"created"`
+
+	// Files lists the backed-up files in the order they were backed up.
+	Files []BackupEntry `json:"files"`
+}
+
+// BackupEntry is a file in a snapshot.
+type BackupEntry struct {
+	// Path is the path of the original file: slash-separated and relative
+	// to the root of the run, or absolute if the file is outside the root.
+	Path string `json:"path"`
+
+	// Backup is the slash-separated path of the copy, relative to the
+	// snapshot directory.
+	Backup string `json:"backup"`
+
+	// Mode is the permission and mode bits of the original file.
+	Mode os.FileMode `json:"mode"`
+
+	// SHA256 is the hex-encoded hash of the original content.
+	SHA256 string `json:"sha256"`
+}
+
+// BackupRun takes the snapshot of one run in BackupModeDirectory. Its
+// directory is created when the first file is backed up, and its manifest
+// is rewritten after each file, so that an interrupted run can still be
+// restored. It is safe for concurrent use.
+type BackupRun struct {
+	root string
+
+	mu       sync.Mutex
+	dir      string
+	manifest BackupManifest
+	backed   map[string]bool
+
+	// external counts the files outside the root, which are stored by
+	// number.
+	external int
+}
+
+// NewBackupRun returns the snapshot of a run started at now, stored under
+// BackupDir in root.
+func NewBackupRun(root string, now time.Time) *BackupRun {
+	now = now.UTC()
+	return &BackupRun{
+		root:     root,
+		manifest: BackupManifest{ID: now.Format(backupIDFormat), Created: now},
+		backed:   make(map[string]bool),
+	}
+}
+
+// ID returns the ID of the snapshot. It can change when the directory is
+// created, if a snapshot with the same ID already exists.
+func (r *BackupRun) ID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.manifest.ID
+}
+
+// Add copies the file at path into the snapshot and records it in the
+// manifest. Returns false if the file was already backed up in this run or
+// does not exist. Files are copied concurrently; only the manifest is
+// written under the lock.
+func (r *BackupRun) Add(ctx context.Context, path string) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, fmt.Errorf("create backup: %w", ctx.Err())
+	default:
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false, fmt.Errorf("resolve %s: %w", path, err)
+	}
+
+	entry, dir, ok, err := r.reserve(absPath)
+	if !ok || err != nil {
+		return false, err
+	}
+
+	created, err := copyBackup(ctx, absPath, filepath.Join(dir, filepath.FromSlash(entry.Backup)), &entry)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !created || err != nil {
+		// Let a later call back the file up.
+		delete(r.backed, absPath)
+		return false, err
+	}
+	r.manifest.Files = append(r.manifest.Files, entry)
+	// The manifest is written even if ctx is cancelled, since the copy
+	// protects a file that is about to be rewritten.
+	if err := r.writeManifest(context.WithoutCancel(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// reserve marks the file at absPath as backed up and returns its manifest
+// entry, without mode and hash, and the snapshot directory. ok is false if
+// the file is already backed up in this run.
+func (r *BackupRun) reserve(absPath string) (entry BackupEntry, dir string, ok bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.backed[absPath] {
+		return BackupEntry{}, "", false, nil
+	}
+	if err := r.createDir(); err != nil {
+		return BackupEntry{}, "", false, err
+	}
+	r.backed[absPath] = true
+
+	entry.Path, entry.Backup = r.entryPaths(absPath)
+	return entry, r.dir, true, nil
+}
+
+// copyBackup copies the file at absPath to backupPath and sets the mode
+// and hash of entry. Returns false if the file does not exist.
+func copyBackup(ctx context.Context, absPath, backupPath string, entry *BackupEntry) (bool, error) {
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read original for backup: %w", err)
+	}
+	stat, err := os.Stat(absPath)
+	if err != nil {
+		return false, fmt.Errorf("stat original for backup: %w", err)
+	}
+
+	entry.Mode = stat.Mode()
+	entry.SHA256 = hashHex(content)
+	if err := os.MkdirAll(filepath.Dir(backupPath), 0o755); err != nil {
+		return false, fmt.Errorf("create backup directory: %w", err)
+	}
+	if err := WriteAtomic(ctx, backupPath, content, stat.Mode()); err != nil {
+		return false, fmt.Errorf("write backup: %w", err)
+	}
+	return true, nil
+}
+
+// createDir creates the snapshot directory if it does not exist yet. If
+// the ID is taken, a numeric suffix is added to it. r.mu must be held.
+func (r *BackupRun) createDir() error {
+	if r.dir != "" {
+		return nil
+	}
+
+	parent := backupsDir(r.root)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return fmt.Errorf("create backup directory: %w", err)
+	}
+
+	id := r.manifest.ID
+	for n := 2; ; n++ {
+		dir := filepath.Join(parent, id)
+		err := os.Mkdir(dir, 0o755)
+		if err == nil {
+			r.dir = dir
+			r.manifest.ID = id
+			return nil
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("create backup directory: %w", err)
+		}
+		id = r.manifest.ID + "-" + strconv.Itoa(n)
+	}
+}
+
+// entryPaths returns the manifest path of the file at absPath and the path
+// of its copy in the snapshot. r.mu must be held.
+func (r *BackupRun) entryPaths(absPath string) (path, backup string) {
+	if root, err := filepath.Abs(r.root); err == nil {
+		if rel, err := filepath.Rel(root, absPath); err == nil && filepath.IsLocal(rel) {
+			return filepath.ToSlash(rel), filepath.ToSlash(rel)
+		}
+	}
+	// Files outside the root are stored by number.
+	r.external++
+	backup = fmt.Sprintf("external/%d/%s", r.external, filepath.Base(absPath))
+	return filepath.ToSlash(absPath), backup
+}
+
+// writeManifest writes the manifest into the snapshot directory. r.mu must
+// be held.
+func (r *BackupRun) writeManifest(ctx context.Context) error {
+	data, err := json.MarshalIndent(r.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode backup manifest: %w", err)
+	}
+	if err := WriteAtomic(ctx, filepath.Join(r.dir, BackupManifestName), append(data, '\n'), DefaultFileMode); err != nil {
+		return fmt.Errorf("write backup manifest: %w", err)
+	}
+	return nil
+}
+
+// ListBackups returns the manifests of the snapshots under BackupDir in
+// root, newest first. Directories without a manifest are ignored.
+func ListBackups(root string) ([]BackupManifest, error) {
+	entries, err := os.ReadDir(backupsDir(root))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read backup directory: %w", err)
+	}
+
+	var manifests []BackupManifest
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		manifest, err := ReadBackupManifest(root, entry.Name())
+		if errors.Is(err, ErrBackupNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, *manifest)
+	}
+
+	slices.SortFunc(manifests, func(a, b BackupManifest) int {
+		if c := b.Created.Compare(a.Created); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	return manifests, nil
+}
+
+// ReadBackupManifest reads the manifest of the snapshot id under BackupDir
+// in root.
+func ReadBackupManifest(root, id string) (*BackupManifest, error) {
+	if !filepath.IsLocal(id) || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("%w: %s", ErrBackupNotFound, id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(backupsDir(root), id, BackupManifestName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrBackupNotFound, id)
+		}
+		return nil, fmt.Errorf("read backup manifest: %w", err)
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse backup manifest %s: %w", id, err)
+	}
+	manifest.ID = id
+	return &manifest, nil
+}
+
+// OriginalPath returns the path of the original file of entry in root.
+func (e BackupEntry) OriginalPath(root string) string {
+	path := filepath.FromSlash(e.Path)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(root, path)
+}
+
+// RestoreBackups writes the files of the snapshot id under BackupDir in
+// root back to their original paths. If paths is not empty, only the files
+// at or below one of paths are restored. A copy that no longer matches the
+// hash in the manifest is not restored, and stops the restore with
+// ErrBackupCorrupt. Returns the entries restored.
+func RestoreBackups(ctx context.Context, root, id string, paths []string) ([]BackupEntry, error) {
+	manifest, err := ReadBackupManifest(root, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored []BackupEntry
+	for _, entry := range manifest.Files {
+		select {
+		case <-ctx.Done():
+			return restored, fmt.Errorf("restore backup: %w", ctx.Err())
+		default:
+		}
+
+		original := entry.OriginalPath(root)
+		if len(paths) > 0 && !slices.ContainsFunc(paths, func(path string) bool { return pathWithin(original, path) }) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(backupsDir(root), id, filepath.FromSlash(entry.Backup)))
+		if err != nil {
+			return restored, fmt.Errorf("read backup of %s: %w", entry.Path, err)
+		}
+		if hashHex(content) != entry.SHA256 {
+			return restored, fmt.Errorf("%w: %s", ErrBackupCorrupt, entry.Path)
+		}
+
+		if _, err := os.Stat(original); err == nil {
+			err = ReplaceContent(ctx, original, content)
+		} else {
+			err = WriteAtomic(ctx, original, content, entry.Mode)
+		}
+		if err != nil {
+			return restored, fmt.Errorf("restore %s: %w", entry.Path, err)
+		}
+		restored = append(restored, entry)
+	}
+	return restored, nil
+}
+
+// PruneBackups removes the snapshots under BackupDir in root that were
+// taken before cutoff. Returns the manifests of the removed snapshots.
+func PruneBackups(root string, cutoff time.Time) ([]BackupManifest, error) {
+	manifests, err := ListBackups(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []BackupManifest
+	for _, manifest := range manifests {
+		if !manifest.Created.Before(cutoff) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(backupsDir(root), manifest.ID)); err != nil {
+			return removed, fmt.Errorf("remove backup %s: %w", manifest.ID, err)
+		}
+		removed = append(removed, manifest)
+	}
+	return removed, nil
+}
+
+// backupsDir returns the directory of the snapshots in root.
+func backupsDir(root string) string {
+	return filepath.Join(root, filepath.FromSlash(BackupDir))
+}
+
+// pathWithin reports whether path is dir or inside it. Both are made
+// absolute first.
+func pathWithin(path, dir string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	return err == nil && (rel == "." || filepath.IsLocal(rel))
+}
+
+// hashHex returns the hex-encoded SHA-256 hash of content.
+func hashHex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,223 @@
+package fsutil_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
+)
+
+// backupRunAt backs up paths, relative to root, in a run started at now.
+func backupRunAt(t *testing.T, root string, now time.Time, paths ...string) *fsutil.BackupRun {
+	t.Helper()
+
+	cfg := fsutil.BackupConfig{Enabled: true, Mode: fsutil.BackupModeDirectory, Run: fsutil.NewBackupRun(root, now)}
+	for _, path := range paths {
+		created, err := fsutil.CreateBackup(context.Background(), filepath.Join(root, path), cfg)
+		if err != nil {
+			t.Fatalf("CreateBackup(%s) error = %v", path, err)
+		}
+		if !created {
+			t.Errorf("CreateBackup(%s) = false, want true", path)
+		}
+	}
+	return cfg.Run
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+}
+
+func TestBackupRun_Add(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"a.md": "alpha", "docs/b.md": "beta"})
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	run := backupRunAt(t, root, now, "a.md", "docs/b.md")
+
+	if run.ID() != "20260102T150405Z" {
+		t.Errorf("ID() = %q", run.ID())
+	}
+
+	// A file is backed up once per run.
+	created, err := run.Add(context.Background(), filepath.Join(root, "a.md"))
+	if err != nil || created {
+		t.Errorf("second Add() = %v, %v; want false, nil", created, err)
+	}
+
+	manifest, err := fsutil.ReadBackupManifest(root, run.ID())
+	if err != nil {
+		t.Fatalf("ReadBackupManifest() error = %v", err)
+	}
+	if !manifest.Created.Equal(now) || len(manifest.Files) != 2 {
+		t.Fatalf("manifest = %+v", manifest)
+	}
+	if entry := manifest.Files[1]; entry.Path != "docs/b.md" || entry.Backup != "docs/b.md" || entry.Mode.Perm() != 0644 {
+		t.Errorf("entry = %+v", entry)
+	}
+	assertContent(t, filepath.Join(root, ".gomdlint", "backups", run.ID(), "docs", "b.md"), "beta")
+
+	// Another run in the same second gets its own directory.
+	other := backupRunAt(t, root, now, "a.md")
+	if other.ID() != "20260102T150405Z-2" {
+		t.Errorf("second run ID() = %q", other.ID())
+	}
+}
+
+func TestBackupRun_ConcurrentAdd(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	files := make(map[string]string)
+	for i := range 20 {
+		files[fmt.Sprintf("doc%d.md", i)] = fmt.Sprintf("content %d", i)
+	}
+	writeFiles(t, root, files)
+	run := fsutil.NewBackupRun(root, time.Now())
+
+	var wg sync.WaitGroup
+	for path := range files {
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := run.Add(context.Background(), filepath.Join(root, path)); err != nil {
+					t.Errorf("Add(%s) error = %v", path, err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	manifest, err := fsutil.ReadBackupManifest(root, run.ID())
+	if err != nil {
+		t.Fatalf("ReadBackupManifest() error = %v", err)
+	}
+	if len(manifest.Files) != len(files) {
+		t.Errorf("manifest has %d files, want %d", len(manifest.Files), len(files))
+	}
+	for _, entry := range manifest.Files {
+		assertContent(t, filepath.Join(root, ".gomdlint", "backups", run.ID(), entry.Backup), files[entry.Path])
+	}
+}
+
+func TestBackupRun_InterruptedRunRestores(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"a.md": "alpha", "b.md": "beta"})
+
+	// The run is dropped after backing up and rewriting one file, as if
+	// it was killed, and is never finished.
+	ctx, cancel := context.WithCancel(context.Background())
+	run := fsutil.NewBackupRun(root, time.Now())
+	if _, err := run.Add(ctx, filepath.Join(root, "a.md")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	cancel()
+	writeFiles(t, root, map[string]string{"a.md": "fixed alpha"})
+
+	manifests, err := fsutil.ListBackups(root)
+	if err != nil || len(manifests) != 1 || manifests[0].ID != run.ID() {
+		t.Fatalf("ListBackups() = %+v, %v; want the interrupted run", manifests, err)
+	}
+	restored, err := fsutil.RestoreBackups(context.Background(), root, run.ID(), nil)
+	if err != nil || len(restored) != 1 {
+		t.Fatalf("RestoreBackups() = %+v, %v", restored, err)
+	}
+	assertContent(t, filepath.Join(root, "a.md"), "alpha")
+	assertContent(t, filepath.Join(root, "b.md"), "beta")
+}
+
+func TestCreateBackup_DirectoryWithoutRun(t *testing.T) {
+	t.Parallel()
+
+	cfg := fsutil.BackupConfig{Enabled: true, Mode: fsutil.BackupModeDirectory}
+	if _, err := fsutil.CreateBackup(context.Background(), "a.md", cfg); err == nil {
+		t.Error("expected error without a backup run")
+	}
+}
+
+func TestRestoreBackups(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"a.md": "alpha", "docs/b.md": "beta"})
+	run := backupRunAt(t, root, time.Now(), "a.md", "docs/b.md")
+	writeFiles(t, root, map[string]string{"a.md": "fixed alpha", "docs/b.md": "fixed beta"})
+
+	restored, err := fsutil.RestoreBackups(context.Background(), root, run.ID(), []string{filepath.Join(root, "docs")})
+	if err != nil {
+		t.Fatalf("RestoreBackups() error = %v", err)
+	}
+	if len(restored) != 1 || restored[0].Path != "docs/b.md" {
+		t.Errorf("restored = %+v, want docs/b.md", restored)
+	}
+	assertContent(t, filepath.Join(root, "a.md"), "fixed alpha")
+	assertContent(t, filepath.Join(root, "docs", "b.md"), "beta")
+
+	// A copy changed since the backup is not restored.
+	writeFiles(t, root, map[string]string{filepath.Join(".gomdlint", "backups", run.ID(), "a.md"): "tampered"})
+	if _, err := fsutil.RestoreBackups(context.Background(), root, run.ID(), nil); !errors.Is(err, fsutil.ErrBackupCorrupt) {
+		t.Errorf("RestoreBackups(tampered) error = %v, want ErrBackupCorrupt", err)
+	}
+	assertContent(t, filepath.Join(root, "a.md"), "fixed alpha")
+
+	if _, err := fsutil.RestoreBackups(context.Background(), root, "missing", nil); !errors.Is(err, fsutil.ErrBackupNotFound) {
+		t.Errorf("RestoreBackups(missing) error = %v, want ErrBackupNotFound", err)
+	}
+	if _, err := fsutil.RestoreBackups(context.Background(), root, "../..", nil); !errors.Is(err, fsutil.ErrBackupNotFound) {
+		t.Errorf("RestoreBackups(../..) error = %v, want ErrBackupNotFound", err)
+	}
+}
+
+func TestListAndPruneBackups(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"a.md": "alpha"})
+	now := time.Now().UTC()
+	old := backupRunAt(t, root, now.Add(-10*24*time.Hour), "a.md")
+	recent := backupRunAt(t, root, now.Add(-time.Hour), "a.md")
+
+	manifests, err := fsutil.ListBackups(root)
+	if err != nil {
+		t.Fatalf("ListBackups() error = %v", err)
+	}
+	if len(manifests) != 2 || manifests[0].ID != recent.ID() || manifests[1].ID != old.ID() {
+		t.Fatalf("ListBackups() = %+v, want newest first", manifests)
+	}
+
+	removed, err := fsutil.PruneBackups(root, now.Add(-7*24*time.Hour))
+	if err != nil {
+		t.Fatalf("PruneBackups() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0].ID != old.ID() {
+		t.Errorf("removed = %+v, want %s", removed, old.ID())
+	}
+	manifests, err = fsutil.ListBackups(root)
+	if err != nil || len(manifests) != 1 {
+		t.Errorf("ListBackups() after prune = %+v, %v", manifests, err)
+	}
+
+	if manifests, err := fsutil.ListBackups(t.TempDir()); err != nil || manifests != nil {
+		t.Errorf("ListBackups(empty) = %+v, %v", manifests, err)
+	}
+}
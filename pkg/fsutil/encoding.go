@@ -0,0 +1,141 @@
+package fsutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Encoding is the text encoding of a file, as detected by DetectEncoding.
+type Encoding string
+
+const (
+	// EncodingUTF8 is UTF-8, with or without a byte order mark. The content
+	// may still hold invalid byte sequences (see InvalidUTF8).
+	EncodingUTF8 Encoding = "UTF-8"
+
+	// EncodingUTF16LE is little-endian UTF-16 with a byte order mark.
+	EncodingUTF16LE Encoding = "UTF-16LE"
+
+	// EncodingUTF16BE is big-endian UTF-16 with a byte order mark.
+	EncodingUTF16BE Encoding = "UTF-16BE"
+
+	// EncodingBinary is content that is not text: it holds NUL bytes and
+	// no UTF-16 byte order mark. UTF-32 files are reported as binary.
+	EncodingBinary Encoding = "binary"
+)
+
+// binarySniffLen is how many leading bytes DetectEncoding searches for a
+// NUL byte, as git does.
+const binarySniffLen = 8000
+
+// ErrNotText is returned when decoding binary content.
+var ErrNotText = errors.New("content is not text")
+
+// InvalidSequence is a run of bytes that is not valid UTF-8.
+type InvalidSequence struct {
+	// Offset is the byte offset of the first byte of the run.
+	Offset int
+
+	// Bytes are the bytes of the run.
+	Bytes []byte
+}
+
+// DetectEncoding returns the encoding of content from its byte order mark,
+// or from the NUL bytes that only binary content holds. Content without
+// either is taken to be UTF-8.
+func DetectEncoding(content []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE, 0x00, 0x00}),
+		bytes.HasPrefix(content, []byte{0x00, 0x00, 0xFE, 0xFF}):
+		return EncodingBinary
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}):
+		return EncodingUTF16LE
+	case bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		return EncodingUTF16BE
+	}
+
+	if bytes.IndexByte(content[:min(len(content), binarySniffLen)], 0) >= 0 {
+		return EncodingBinary
+	}
+	return EncodingUTF8
+}
+
+// InvalidUTF8 returns the runs of bytes in content that are not valid
+// UTF-8, in order. It returns nil for valid UTF-8.
+func InvalidUTF8(content []byte) []InvalidSequence {
+	if utf8.Valid(content) {
+		return nil
+	}
+
+	var invalid []InvalidSequence
+	for offset := 0; offset < len(content); {
+		char, size := utf8.DecodeRune(content[offset:])
+		if char != utf8.RuneError || size != 1 {
+			offset += size
+			continue
+		}
+
+		// Extend the run of invalid bytes.
+		if n := len(invalid); n > 0 && invalid[n-1].Offset+len(invalid[n-1].Bytes) == offset {
+			invalid[n-1].Bytes = content[invalid[n-1].Offset : offset+1]
+		} else {
+			invalid = append(invalid, InvalidSequence{Offset: offset, Bytes: content[offset : offset+1]})
+		}
+		offset++
+	}
+	return invalid
+}
+
+// DecodeText returns content, in encoding enc, as UTF-8. The byte order
+// mark of UTF-16 content is dropped; a UTF-8 one is kept. Bytes that are
+// not valid UTF-8 are decoded as Windows-1252, the encoding of most
+// Latin-1 text.
+func DecodeText(content []byte, enc Encoding) ([]byte, error) {
+	switch enc {
+	case EncodingUTF16LE:
+		return decodeUTF16(content[2:], binary.LittleEndian), nil
+	case EncodingUTF16BE:
+		return decodeUTF16(content[2:], binary.BigEndian), nil
+	case EncodingBinary:
+		return nil, ErrNotText
+	}
+
+	invalid := InvalidUTF8(content)
+	if invalid == nil {
+		return content, nil
+	}
+
+	decoded := make([]byte, 0, len(content)+len(invalid))
+	last := 0
+	for _, seq := range invalid {
+		decoded = append(decoded, content[last:seq.Offset]...)
+		for _, b := range seq.Bytes {
+			decoded = utf8.AppendRune(decoded, charmap.Windows1252.DecodeByte(b))
+		}
+		last = seq.Offset + len(seq.Bytes)
+	}
+	return append(decoded, content[last:]...), nil
+}
+
+// decodeUTF16 returns the UTF-16 content in byte order order as UTF-8. A
+// trailing odd byte and unpaired surrogates become U+FFFD.
+func decodeUTF16(content []byte, order binary.ByteOrder) []byte {
+	units := make([]uint16, 0, len(content)/2)
+	for i := 0; i+1 < len(content); i += 2 {
+		units = append(units, order.Uint16(content[i:]))
+	}
+
+	decoded := make([]byte, 0, len(content))
+	for _, char := range utf16.Decode(units) {
+		decoded = utf8.AppendRune(decoded, char)
+	}
+	if len(content)%2 != 0 {
+		decoded = utf8.AppendRune(decoded, utf8.RuneError)
+	}
+	return decoded
+}
@@ -0,0 +1,92 @@
+package fsutil_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    fsutil.Encoding
+	}{
+		{"empty", "", fsutil.EncodingUTF8},
+		{"ascii", "# Title\n", fsutil.EncodingUTF8},
+		{"utf-8 bom", "\ufeff# Title\n", fsutil.EncodingUTF8},
+		{"latin-1", "Caf\xe9\n", fsutil.EncodingUTF8},
+		{"utf-16le", "\xff\xfe#\x00\n\x00", fsutil.EncodingUTF16LE},
+		{"utf-16be", "\xfe\xff\x00#\x00\n", fsutil.EncodingUTF16BE},
+		{"utf-32le", "\xff\xfe\x00\x00#\x00\x00\x00", fsutil.EncodingBinary},
+		{"nul byte", "\x89PNG\r\n\x1a\n\x00\x00", fsutil.EncodingBinary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := fsutil.DetectEncoding([]byte(tt.content)); got != tt.want {
+				t.Errorf("DetectEncoding() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	if got := fsutil.InvalidUTF8([]byte("caf\u00e9\n")); got != nil {
+		t.Errorf("InvalidUTF8(valid) = %+v, want nil", got)
+	}
+
+	got := fsutil.InvalidUTF8([]byte("a\xe9b\xff\xfe\u00e9\xc3"))
+	if len(got) != 3 {
+		t.Fatalf("InvalidUTF8() = %+v, want 3 runs", got)
+	}
+	want := []struct {
+		offset int
+		bytes  string
+	}{{1, "\xe9"}, {3, "\xff\xfe"}, {7, "\xc3"}}
+	for i, w := range want {
+		if got[i].Offset != w.offset || string(got[i].Bytes) != w.bytes {
+			t.Errorf("run %d = %+v, want offset %d bytes % X", i, got[i], w.offset, w.bytes)
+		}
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		enc     fsutil.Encoding
+		want    string
+	}{
+		{"utf-8 unchanged", "\ufeffcaf\u00e9\r\n", fsutil.EncodingUTF8, "\ufeffcaf\u00e9\r\n"},
+		{"windows-1252 bytes", "caf\xe9 \x93q\x94 \u00e9", fsutil.EncodingUTF8, "caf\u00e9 \u201cq\u201d \u00e9"},
+		{"utf-16le", "\xff\xfea\x00\xe9\x00\r\x00\n\x00=\xd8\x00\xde", fsutil.EncodingUTF16LE, "a\u00e9\r\n\U0001f600"},
+		{"utf-16be", "\xfe\xff\x00a\x00\xe9", fsutil.EncodingUTF16BE, "a\u00e9"},
+		{"utf-16 odd length", "\xff\xfea\x00b", fsutil.EncodingUTF16LE, "a\ufffd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := fsutil.DecodeText([]byte(tt.content), tt.enc)
+			if err != nil {
+				t.Fatalf("DecodeText() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("DecodeText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := fsutil.DecodeText([]byte("\x00"), fsutil.EncodingBinary); !errors.Is(err, fsutil.ErrNotText) {
+		t.Errorf("DecodeText(binary) error = %v, want ErrNotText", err)
+	}
+}
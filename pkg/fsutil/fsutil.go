@@ -28,10 +28,15 @@ type FileInfo struct {
 
 	// Hash is the SHA-256 hash of the file content.
 	Hash [32]byte
+
+	// Encoding is the text encoding of the file content.
+	Encoding Encoding
 }
 
 // ReadFile reads a file and returns its content along with metadata.
-// The returned FileInfo can be used for modification detection.
+// The returned FileInfo can be used for modification detection, and holds
+// the encoding of the content. The content is returned as it is on disk;
+// see DecodeText.
 func ReadFile(ctx context.Context, path string) ([]byte, *FileInfo, error) {
 	select {
 	case <-ctx.Done():
@@ -63,11 +68,12 @@ func ReadFile(ctx context.Context, path string) ([]byte, *FileInfo, error) {
 	}
 
 	info := &FileInfo{
-		Path:    path,
-		Mode:    stat.Mode(),
-		ModTime: stat.ModTime(),
-		Size:    stat.Size(),
-		Hash:    sha256.Sum256(content),
+		Path:     path,
+		Mode:     stat.Mode(),
+		ModTime:  stat.ModTime(),
+		Size:     stat.Size(),
+		Hash:     sha256.Sum256(content),
+		Encoding: DetectEncoding(content),
 	}
 
 	return content, info, nil
@@ -93,6 +99,12 @@ var (
 
 	// ErrIsDirectory indicates the path is a directory, not a file.
 	ErrIsDirectory = errors.New("path is a directory")
+
+	// ErrBackupNotFound indicates a backup snapshot does not exist.
+	ErrBackupNotFound = errors.New("backup not found")
+
+	// ErrBackupCorrupt indicates a backup copy does not match its manifest.
+	ErrBackupCorrupt = errors.New("backup does not match its manifest")
 )
 
 func CheckModified(ctx context.Context, info *FileInfo) (bool, error) {
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package fsutil
+
+import "io/fs"
+
+// linkCount returns 1: hard links are not detected on this platform.
+func linkCount(fs.FileInfo) uint64 {
+	return 1
+}
+
+// copyOwner does nothing: ownership is not kept on this platform.
+func copyOwner(string, fs.FileInfo) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+//go:build unix
+
+package fsutil
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// linkCount returns the number of hard links to the file of info.
+func linkCount(info fs.FileInfo) uint64 {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 1
+	}
+	return uint64(stat.Nlink)
+}
+
+// copyOwner gives the file at path the owner and group of the file of
+// info. If the process may not set the owner, only the group is set, and
+// if it may not set that either, nothing is changed.
+func copyOwner(path string, info fs.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	err := os.Chown(path, int(stat.Uid), int(stat.Gid))
+	if errors.Is(err, fs.ErrPermission) {
+		err = os.Chown(path, -1, int(stat.Gid))
+	}
+	if errors.Is(err, fs.ErrPermission) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+package fsutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ReplaceContent replaces the content of the existing file at path while
+// keeping it the same file:
+//   - A symlink is followed and its target is written; the link is kept.
+//   - A file with more than one hard link is truncated and written in
+//     place, so that every link sees the new content. This write is not
+//     atomic.
+//   - Any other file is replaced atomically as by WriteAtomic, keeping its
+//     mode, and its owner, group and extended attributes where the process
+//     is permitted to set them.
+func ReplaceContent(ctx context.Context, path string, content []byte) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("replace content: %w", ctx.Err())
+	default:
+	}
+
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", path, err)
+	}
+
+	stat, err := os.Stat(target)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", target, err)
+	}
+
+	if linkCount(stat) > 1 {
+		return writeInPlace(target, content)
+	}
+
+	return writeAtomic(ctx, target, content, stat.Mode(), func(tmpPath string) error {
+		if err := copyOwner(tmpPath, stat); err != nil {
+			return fmt.Errorf("copy owner: %w", err)
+		}
+		if err := copyXattrs(tmpPath, target); err != nil {
+			return fmt.Errorf("copy extended attributes: %w", err)
+		}
+		return nil
+	})
+}
+
+// writeInPlace truncates the file at path and writes content to it.
+func writeInPlace(path string, content []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+
+	if _, err := file.Write(content); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("sync %s: %w", path, err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", path, err)
+	}
+	return nil
+}
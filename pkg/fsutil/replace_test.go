@@ -0,0 +1,107 @@
+package fsutil_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
+)
+
+func TestReplaceContent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keeps mode", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "doc.md")
+		if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+
+		if err := fsutil.ReplaceContent(context.Background(), path, []byte("new")); err != nil {
+			t.Fatalf("ReplaceContent() error = %v", err)
+		}
+
+		assertContent(t, path, "new")
+		stat, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+		if stat.Mode().Perm() != 0600 {
+			t.Errorf("mode = %o, want 600", stat.Mode().Perm())
+		}
+	})
+
+	t.Run("writes symlink target", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		target := filepath.Join(dir, "target.md")
+		link := filepath.Join(dir, "link.md")
+		if err := os.WriteFile(target, []byte("old"), 0644); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+		if err := os.Symlink("target.md", link); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+
+		if err := fsutil.ReplaceContent(context.Background(), link, []byte("new")); err != nil {
+			t.Fatalf("ReplaceContent() error = %v", err)
+		}
+
+		stat, err := os.Lstat(link)
+		if err != nil {
+			t.Fatalf("lstat: %v", err)
+		}
+		if stat.Mode()&os.ModeSymlink == 0 {
+			t.Error("link was replaced by a regular file")
+		}
+		assertContent(t, target, "new")
+	})
+
+	t.Run("keeps hard links", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "doc.md")
+		other := filepath.Join(dir, "other.md")
+		if err := os.WriteFile(path, []byte("old content"), 0644); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+		if err := os.Link(path, other); err != nil {
+			t.Skipf("hard links not supported: %v", err)
+		}
+
+		if err := fsutil.ReplaceContent(context.Background(), path, []byte("new")); err != nil {
+			t.Fatalf("ReplaceContent() error = %v", err)
+		}
+
+		assertContent(t, path, "new")
+		assertContent(t, other, "new")
+	})
+
+	t.Run("respects cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := fsutil.ReplaceContent(ctx, filepath.Join(t.TempDir(), "doc.md"), []byte("new")); err == nil {
+			t.Error("expected error for cancelled context")
+		}
+	})
+}
+
+func assertContent(t *testing.T, path, want string) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if string(got) != want {
+		t.Errorf("%s content = %q, want %q", filepath.Base(path), got, want)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build linux || darwin
+
+package fsutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// copyXattrs copies the extended attributes of the file at src to the file
+// at dst. Attributes the file system does not support or the process may
+// not set are skipped.
+func copyXattrs(dst, src string) error {
+	names, err := listXattrs(src)
+	if err != nil {
+		if skippableXattrError(err) {
+			return nil
+		}
+		return fmt.Errorf("list %s: %w", src, err)
+	}
+
+	for _, name := range names {
+		value, err := getXattr(src, name)
+		if err != nil {
+			if skippableXattrError(err) {
+				continue
+			}
+			return fmt.Errorf("get %s of %s: %w", name, src, err)
+		}
+		if err := unix.Setxattr(dst, name, value, 0); err != nil && !skippableXattrError(err) {
+			return fmt.Errorf("set %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// listXattrs returns the names of the extended attributes of the file at
+// path.
+func listXattrs(path string) ([]string, error) {
+	size, err := unix.Listxattr(path, nil)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+	buf := make([]byte, size)
+	size, err = unix.Listxattr(path, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range bytes.SplitSeq(buf[:size], []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	return names, nil
+}
+
+// getXattr returns the value of the extended attribute name of the file at
+// path.
+func getXattr(path, name string) ([]byte, error) {
+	size, err := unix.Getxattr(path, name, nil)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+	buf := make([]byte, size)
+	size, err = unix.Getxattr(path, name, buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:size], nil
+}
+
+// skippableXattrError reports whether err means extended attributes are
+// not supported or not permitted.
+func skippableXattrError(err error) bool {
+	return errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) ||
+		errors.Is(err, unix.EPERM) || errors.Is(err, unix.EACCES)
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package fsutil
+
+// copyXattrs does nothing: extended attributes are not kept on this
+// platform.
+func copyXattrs(_, _ string) error {
+	return nil
+}
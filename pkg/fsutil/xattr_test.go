@@ -0,0 +1,39 @@
+//go:build linux
+
+package fsutil_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
+)
+
+func TestReplaceContent_KeepsXattrs(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "doc.md")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if err := unix.Setxattr(path, "user.gomdlint", []byte("kept"), 0); err != nil {
+		t.Skipf("extended attributes not supported: %v", err)
+	}
+
+	if err := fsutil.ReplaceContent(context.Background(), path, []byte("new")); err != nil {
+		t.Fatalf("ReplaceContent() error = %v", err)
+	}
+
+	buf := make([]byte, 16)
+	n, err := unix.Getxattr(path, "user.gomdlint", buf)
+	if err != nil {
+		t.Fatalf("Getxattr() error = %v", err)
+	}
+	if string(buf[:n]) != "kept" {
+		t.Errorf("attribute = %q, want %q", buf[:n], "kept")
+	}
+}
@@ -1,228 +1,235 @@
 // Package langdetect provides language detection for code content.
-// It uses go-enry to detect programming languages from code snippets,
-// primarily for auto-detecting language identifiers in code blocks.
+// It combines shebang detection, console-transcript detection and weighted
+// pattern heuristics to guess the fence tag for a code snippet, together
+// with a confidence score.
+//
+// go-enry's classifier is not used: it ranks every candidate without a
+// probability, and only reports a guess as safe when a single candidate
+// is left, so its guesses are never confident enough to act on.
 package langdetect
 
 import (
-	"bytes"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/go-enry/go-enry/v2"
 )
 
-// Language constants for common detected languages.
+// langText is returned by Detect when no language is detected confidently.
+const langText = "text"
+
+// Detection methods reported in Result.Method.
 const (
-	langGo         = "go"
-	langPython     = "python"
-	langJavaScript = "javascript"
-	langJSON       = "json"
-	langYAML       = "yaml"
-	langHTML       = "html"
-	langSQL        = "sql"
-	langRust       = "rust"
-	langDockerfile = "dockerfile"
-	langText       = "text"
-	langBash       = "bash"
+	MethodShebang = "shebang"
+	MethodConsole = "console"
+	MethodPattern = "pattern"
+	MethodNone    = "none"
 )
 
-// Detect returns the detected language for code content.
-// Returns "text" if detection fails or confidence is low.
-func Detect(content []byte) string {
-	if len(content) == 0 {
-		return langText
-	}
+// DefaultMinConfidence is the confidence below which a detection should not
+// be acted upon.
+const DefaultMinConfidence = 0.5
 
-	// Strategy 1: Check shebang first (most reliable).
-	if lang, safe := enry.GetLanguageByShebang(content); safe {
-		return normalize(lang)
-	}
+// Fixed confidences for methods that do not produce a score.
+const (
+	shebangConfidence = 1.0
+	consoleConfidence = 0.9
+)
 
-	// Strategy 2: Check for language-specific patterns before using classifier.
-	if lang := detectByPattern(content); lang != "" {
-		return lang
-	}
+// defaultCandidates is the built-in list of fence tags considered by detection.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var defaultCandidates = []string{
+	"go", "python", "bash", "console", "javascript", "typescript",
+	"ruby", "rust", "java", "kotlin", "c", "cpp", "sql", "json",
+	"yaml", "toml", "hcl", "html", "css", "dockerfile", "powershell",
+	"protobuf",
+}
 
-	// Strategy 3: Use classifier with common language candidates.
-	candidates := []string{
-		"Go", "Python", "Shell", "JavaScript", "TypeScript",
-		"Ruby", "Rust", "Java", "C", "C++", "SQL", "JSON",
-		"YAML", "HTML", "CSS", "Markdown", "Dockerfile",
-	}
+// DefaultCandidates returns the built-in list of candidate fence tags.
+func DefaultCandidates() []string {
+	return slices.Clone(defaultCandidates)
+}
 
-	// Use classifier - it returns the most probable language.
-	// Only use the result if confidence is high (safe == true).
-	if lang, safe := enry.GetLanguageByClassifier(content, candidates); safe && lang != "" {
-		return normalize(lang)
-	}
+// Score is a candidate language and its pattern score.
+type Score struct {
+	Language string  `json:"language"`
+	Score    float64 `json:"score"`
+}
+
+// Result is the outcome of language detection.
+type Result struct {
+	// Language is the detected fence tag after aliases are applied,
+	// or "text" if nothing was detected.
+	Language string `json:"language"`
+
+	// Confidence is between 0 and 1.
+	Confidence float64 `json:"confidence"`
+
+	// Method is the detection method that produced Language.
+	Method string `json:"method"`
 
-	return langText
+	// Scores lists non-zero pattern scores, highest first.
+	Scores []Score `json:"scores,omitempty"`
 }
 
-// detectByPattern checks for language-specific patterns that are highly indicative.
-func detectByPattern(content []byte) string {
-	contentStr := string(content)
-	trimmed := bytes.TrimSpace(content)
+// Options configures a Detector.
+type Options struct {
+	// Candidates lists the fence tags to consider. Empty means DefaultCandidates.
+	Candidates []string
 
-	// Check patterns in order of specificity.
-	if lang := detectGo(trimmed); lang != "" {
-		return lang
-	}
-	if lang := detectPython(contentStr); lang != "" {
-		return lang
-	}
-	if lang := detectHTML(trimmed); lang != "" {
-		return lang
-	}
-	if lang := detectJSON(trimmed); lang != "" {
-		return lang
-	}
-	if lang := detectDockerfile(content, trimmed); lang != "" {
-		return lang
-	}
-	if lang := detectSQL(contentStr); lang != "" {
-		return lang
-	}
-	if lang := detectRust(contentStr); lang != "" {
-		return lang
-	}
-	if lang := detectJavaScript(contentStr); lang != "" {
-		return lang
-	}
-	if lang := detectYAML(content); lang != "" {
-		return lang
-	}
+	// Aliases maps a detected fence tag to the tag to report instead.
+	Aliases map[string]string
+}
 
-	return ""
+// Detector classifies code snippets. A Detector is immutable and safe for
+// concurrent use.
+type Detector struct {
+	candidates   []string
+	candidateSet map[string]bool
+	aliases      map[string]string
 }
 
-// detectGo checks for Go language patterns.
-func detectGo(trimmed []byte) string {
-	if bytes.HasPrefix(trimmed, []byte("package ")) {
-		return langGo
+// New creates a Detector from options. Candidate and alias names are
+// canonicalized, so "ts" and "typescript" are equivalent.
+func New(opts Options) *Detector {
+	candidates := opts.Candidates
+	if len(candidates) == 0 {
+		candidates = defaultCandidates
 	}
-	return ""
-}
 
-// detectPython checks for Python language patterns.
-func detectPython(contentStr string) string {
-	// def/class definitions with colon.
-	if strings.Contains(contentStr, "def ") && strings.Contains(contentStr, "):") {
-		return langPython
+	det := &Detector{
+		candidateSet: make(map[string]bool, len(candidates)),
+		aliases:      make(map[string]string, len(opts.Aliases)),
 	}
-	// Python import statements (not Go which uses "import (").
-	if strings.Contains(contentStr, "import ") && !strings.Contains(contentStr, "import (") {
-		if strings.Contains(contentStr, "from ") || strings.HasPrefix(strings.TrimSpace(contentStr), "import ") {
-			return langPython
+	for _, c := range candidates {
+		tag := Canonical(c)
+		if tag == "" || det.candidateSet[tag] {
+			continue
 		}
+		det.candidateSet[tag] = true
+		det.candidates = append(det.candidates, tag)
 	}
-	// Python dunder variables.
-	if strings.Contains(contentStr, "__name__") || strings.Contains(contentStr, "__main__") {
-		return langPython
+	for from, to := range opts.Aliases {
+		det.aliases[Canonical(from)] = strings.TrimSpace(to)
 	}
-	return ""
+
+	return det
 }
 
-// detectHTML checks for HTML language patterns.
-func detectHTML(trimmed []byte) string {
-	lowerTrimmed := bytes.ToLower(trimmed)
-	if bytes.Contains(lowerTrimmed, []byte("<!doctype html")) ||
-		bytes.Contains(lowerTrimmed, []byte("<html")) ||
-		bytes.Contains(lowerTrimmed, []byte("<head>")) ||
-		bytes.Contains(lowerTrimmed, []byte("<body>")) {
-		return langHTML
+// defaultDetector backs the package-level Detect function.
+//
+//nolint:gochecknoglobals // Immutable detector with default options.
+var defaultDetector = New(Options{})
+
+// Detect returns the detected language for code content using the default
+// candidates. Returns "text" if detection fails or confidence is below
+// DefaultMinConfidence.
+func Detect(content []byte) string {
+	result := defaultDetector.Detect(content)
+	if result.Confidence < DefaultMinConfidence {
+		return langText
 	}
-	return ""
+	return result.Language
 }
 
-// detectJSON checks for JSON patterns.
-func detectJSON(trimmed []byte) string {
-	if (bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("["))) &&
-		bytes.Contains(trimmed, []byte(`"`)) {
-		return langJSON
-	}
-	return ""
+// Candidates returns the canonical candidate fence tags, in priority order.
+func (d *Detector) Candidates() []string {
+	return slices.Clone(d.candidates)
 }
 
-// detectDockerfile checks for Dockerfile patterns.
-func detectDockerfile(content, trimmed []byte) string {
-	if bytes.HasPrefix(trimmed, []byte("FROM ")) ||
-		(bytes.Contains(content, []byte("\nFROM ")) && bytes.Contains(content, []byte("\nRUN "))) ||
-		(bytes.Contains(content, []byte("WORKDIR ")) && bytes.Contains(content, []byte("COPY "))) {
-		return langDockerfile
+// Detect classifies content. It always returns a Result; when nothing is
+// detected, Language is "text" with zero confidence.
+func (d *Detector) Detect(content []byte) Result {
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return Result{Language: langText, Method: MethodNone}
 	}
-	return ""
-}
 
-// detectSQL checks for SQL patterns.
-func detectSQL(contentStr string) string {
-	upper := strings.ToUpper(contentStr)
-	trimmedUpper := strings.TrimSpace(upper)
-	if strings.HasPrefix(trimmedUpper, "SELECT ") ||
-		strings.HasPrefix(trimmedUpper, "INSERT ") ||
-		strings.HasPrefix(trimmedUpper, "UPDATE ") ||
-		strings.HasPrefix(trimmedUpper, "DELETE ") ||
-		strings.HasPrefix(trimmedUpper, "CREATE ") {
-		return langSQL
+	// Strategy 1: Check shebang first (most reliable).
+	if lang, safe := enry.GetLanguageByShebang(content); safe {
+		return d.result(fenceTag(lang), shebangConfidence, MethodShebang, nil)
+	}
+
+	// Strategy 2: Prompt transcripts.
+	if d.candidateSet[langConsole] && isConsoleTranscript(content) {
+		return d.result(langConsole, consoleConfidence, MethodConsole, nil)
 	}
-	return ""
-}
 
-// detectRust checks for Rust language patterns.
-func detectRust(contentStr string) string {
-	if strings.Contains(contentStr, "fn main()") ||
-		strings.Contains(contentStr, "println!") ||
-		strings.Contains(contentStr, "let mut ") {
-		return langRust
+	// Strategy 3: Weighted pattern heuristics.
+	scores := d.patternScores(content)
+	if len(scores) > 0 {
+		confidence := scores[0].Score
+		if len(scores) > 1 {
+			// Penalize ambiguity between the top two candidates.
+			confidence -= scores[1].Score / 2
+		}
+		return d.result(scores[0].Language, clamp(confidence), MethodPattern, scores)
 	}
-	return ""
+
+	return Result{Language: langText, Method: MethodNone}
 }
 
-// detectJavaScript checks for JavaScript patterns.
-func detectJavaScript(contentStr string) string {
-	if strings.Contains(contentStr, "=>") ||
-		strings.Contains(contentStr, "const ") ||
-		strings.Contains(contentStr, "let ") ||
-		strings.Contains(contentStr, "console.log") {
-		return langJavaScript
+// result builds a Result, applying aliases.
+func (d *Detector) result(lang string, confidence float64, method string, scores []Score) Result {
+	if alias, ok := d.aliases[lang]; ok && alias != "" {
+		lang = alias
 	}
-	return ""
+	return Result{Language: lang, Confidence: confidence, Method: method, Scores: scores}
 }
 
-// detectYAML checks for YAML patterns by counting key: value pairs.
-func detectYAML(content []byte) string {
-	lines := bytes.Split(content, []byte("\n"))
-	yamlKeyCount := 0
+// patternScores scores every candidate with pattern signals and returns the
+// non-zero scores, highest first. Ties keep candidate order.
+func (d *Detector) patternScores(content []byte) []Score {
+	raw := make(map[string]float64, len(languageSignals))
+	for _, ls := range languageSignals {
+		raw[ls.lang] = ls.score(content)
+	}
 
-	for _, line := range lines {
-		line = bytes.TrimSpace(line)
-		if len(line) == 0 || bytes.HasPrefix(line, []byte("#")) {
-			continue
-		}
-		// Simple key: value (identifier followed by colon and space).
-		// Exclude lines that look like code (contain parentheses, brackets).
-		if bytes.Contains(line, []byte(": ")) {
-			if !bytes.Contains(line, []byte("(")) &&
-				!bytes.Contains(line, []byte("{")) &&
-				!bytes.HasPrefix(line, []byte(`"`)) {
-				yamlKeyCount++
-			}
-		}
-		// YAML list item at root level.
-		if bytes.HasPrefix(line, []byte("- ")) {
-			yamlKeyCount++
+	// A language with evidence of its own absorbs the language it extends,
+	// so TypeScript-only syntax rules out plain JavaScript.
+	for _, ls := range languageSignals {
+		if ls.extends != "" && raw[ls.lang] > 0 && d.candidateSet[ls.lang] {
+			raw[ls.lang] += raw[ls.extends]
+			raw[ls.extends] = 0
 		}
 	}
 
-	if yamlKeyCount >= 2 {
-		return langYAML
+	var scores []Score
+	for _, tag := range d.candidates {
+		if score := raw[tag]; score > 0 {
+			scores = append(scores, Score{Language: tag, Score: clamp(score)})
+		}
 	}
-	return ""
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	return scores
+}
+
+// enryFenceTags maps go-enry language names to fence tags where lowercasing
+// is not enough.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var enryFenceTags = map[string]string{
+	"Shell":           "bash",
+	"ShellSession":    "console",
+	"C++":             "cpp",
+	"C#":              "csharp",
+	"Protocol Buffer": "protobuf",
+	"Objective-C":     "objc",
 }
 
-// normalize converts go-enry language names to fence tags.
-func normalize(lang string) string {
-	if lang == "Shell" {
-		return langBash
+// fenceTag converts a go-enry language name to a fence tag.
+func fenceTag(lang string) string {
+	if tag, ok := enryFenceTags[lang]; ok {
+		return tag
 	}
-	return strings.ToLower(lang)
+	return strings.ReplaceAll(strings.ToLower(lang), " ", "-")
+}
+
+// clamp limits a score to the range [0, 1].
+func clamp(v float64) float64 {
+	return max(0, min(v, 1))
 }
@@ -143,3 +143,172 @@ func TestDetect_NormalizesLanguageNames(t *testing.T) {
 		})
 	}
 }
+
+func TestDetector_Languages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "typescript interface",
+			content: "interface User {\n  id: number;\n  name: string;\n}\n\nconst u: User = { id: 1, name: \"a\" };",
+			want:    "typescript",
+		},
+		{
+			name:    "typescript function",
+			content: "export function greet(name: string): void {\n  console.log(`hi ${name}`);\n}",
+			want:    "typescript",
+		},
+		{
+			name:    "terraform",
+			content: "resource \"aws_s3_bucket\" \"b\" {\n  bucket = var.bucket_name\n}",
+			want:    "hcl",
+		},
+		{
+			name:    "powershell",
+			content: "$items = Get-ChildItem -Path C:\\temp -Recurse\nWrite-Host $items.Count",
+			want:    "powershell",
+		},
+		{
+			name:    "kotlin",
+			content: "data class User(val name: String)\n\nfun main() {\n    val u = User(\"a\")\n    println(u)\n}",
+			want:    "kotlin",
+		},
+		{
+			name:    "protobuf",
+			content: "syntax = \"proto3\";\n\nmessage User {\n  string name = 1;\n}",
+			want:    "protobuf",
+		},
+		{
+			name:    "console transcript",
+			content: "$ go version\ngo version go1.25.4 linux/amd64",
+			want:    "console",
+		},
+		{
+			name:    "console with user prompt",
+			content: "user@host:~/src$ ls\nREADME.md  main.go",
+			want:    "console",
+		},
+		{
+			name:    "commands without output",
+			content: "$ go build ./...\n$ go test ./...",
+			want:    "bash",
+		},
+		{
+			name:    "toml",
+			content: "[server]\nport = 8080\nhost = \"localhost\"",
+			want:    "toml",
+		},
+	}
+
+	detector := langdetect.New(langdetect.Options{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := detector.Detect([]byte(tt.content))
+			if result.Language != tt.want {
+				t.Errorf("Detect() = %q (%.2f, %s, %v), want %q",
+					result.Language, result.Confidence, result.Method, result.Scores, tt.want)
+			}
+			if result.Confidence < langdetect.DefaultMinConfidence {
+				t.Errorf("Detect() confidence = %.2f, want >= %.2f", result.Confidence, langdetect.DefaultMinConfidence)
+			}
+		})
+	}
+}
+
+func TestDetector_Confidence(t *testing.T) {
+	t.Parallel()
+
+	detector := langdetect.New(langdetect.Options{})
+
+	shebang := detector.Detect([]byte("#!/bin/bash\necho hi"))
+	if shebang.Method != langdetect.MethodShebang || shebang.Confidence != 1 {
+		t.Errorf("shebang result = %+v, want method %q with confidence 1", shebang, langdetect.MethodShebang)
+	}
+
+	weak := detector.Detect([]byte("x := 1"))
+	if weak.Language != "go" || weak.Confidence >= langdetect.DefaultMinConfidence {
+		t.Errorf("weak result = %+v, want go below default min confidence", weak)
+	}
+	if langdetect.Detect([]byte("x := 1")) != "text" {
+		t.Error("package Detect should return text below the default min confidence")
+	}
+
+	// Without any signal there is no guess to act on, rather than one below
+	// the default min confidence.
+	unknown := detector.Detect([]byte("body { color: red; }"))
+	if unknown.Language != "text" || unknown.Confidence != 0 || unknown.Method != langdetect.MethodNone {
+		t.Errorf("unknown result = %+v, want text/0/none", unknown)
+	}
+
+	empty := detector.Detect(nil)
+	if empty.Language != "text" || empty.Confidence != 0 || empty.Method != langdetect.MethodNone {
+		t.Errorf("empty result = %+v, want text/0/none", empty)
+	}
+}
+
+func TestDetector_Options(t *testing.T) {
+	t.Parallel()
+
+	ts := []byte("interface User {\n  id: number;\n}")
+
+	t.Run("candidates restrict detection", func(t *testing.T) {
+		t.Parallel()
+
+		detector := langdetect.New(langdetect.Options{Candidates: []string{"js", "go"}})
+		if got := detector.Candidates(); len(got) != 2 || got[0] != "javascript" {
+			t.Errorf("Candidates() = %v, want canonical [javascript go]", got)
+		}
+		if result := detector.Detect(ts); result.Language == "typescript" {
+			t.Errorf("Detect() = %q, typescript is not a candidate", result.Language)
+		}
+	})
+
+	t.Run("console requires candidate", func(t *testing.T) {
+		t.Parallel()
+
+		detector := langdetect.New(langdetect.Options{Candidates: []string{"bash"}})
+		result := detector.Detect([]byte("$ ls\nfile.txt"))
+		if result.Language != "bash" {
+			t.Errorf("Detect() = %q, want bash", result.Language)
+		}
+	})
+
+	t.Run("aliases rename result", func(t *testing.T) {
+		t.Parallel()
+
+		detector := langdetect.New(langdetect.Options{Aliases: map[string]string{"typescript": "ts", "sh": "shell"}})
+		if result := detector.Detect(ts); result.Language != "ts" {
+			t.Errorf("Detect() = %q, want ts", result.Language)
+		}
+		if result := detector.Detect([]byte("#!/bin/sh\necho hi")); result.Language != "shell" {
+			t.Errorf("Detect() = %q, want shell", result.Language)
+		}
+	})
+}
+
+func TestCanonical(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Go":        "go",
+		"golang":    "go",
+		" TS ":      "typescript",
+		"yml":       "yaml",
+		"terraform": "hcl",
+		"pwsh":      "powershell",
+		"proto":     "protobuf",
+		"elixir":    "elixir",
+	}
+
+	for input, want := range tests {
+		if got := langdetect.Canonical(input); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
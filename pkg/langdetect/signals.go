@@ -0,0 +1,309 @@
+package langdetect
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Fence tags with special handling.
+const (
+	langConsole = "console"
+	langJSON    = "json"
+	langYAML    = "yaml"
+)
+
+// tagAliases maps common alternative fence tags to the canonical tag used
+// by the detector.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var tagAliases = map[string]string{
+	"golang":        "go",
+	"py":            "python",
+	"python3":       "python",
+	"sh":            "bash",
+	"shell":         "bash",
+	"zsh":           "bash",
+	"shell-session": "console",
+	"shellsession":  "console",
+	"terminal":      "console",
+	"js":            "javascript",
+	"node":          "javascript",
+	"ts":            "typescript",
+	"rb":            "ruby",
+	"rs":            "rust",
+	"kt":            "kotlin",
+	"kts":           "kotlin",
+	"c++":           "cpp",
+	"cxx":           "cpp",
+	"yml":           "yaml",
+	"terraform":     "hcl",
+	"tf":            "hcl",
+	"ps1":           "powershell",
+	"pwsh":          "powershell",
+	"posh":          "powershell",
+	"proto":         "protobuf",
+	"docker":        "dockerfile",
+}
+
+// Canonical returns the canonical fence tag for tag: lowercased, trimmed,
+// and with common aliases ("ts", "golang", "yml") resolved.
+func Canonical(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if canonical, ok := tagAliases[tag]; ok {
+		return canonical
+	}
+	return tag
+}
+
+// signal is a weighted piece of evidence for a language.
+type signal struct {
+	pattern *regexp.Regexp
+	match   func(content []byte) bool
+	weight  float64
+}
+
+// matches reports whether the signal is present in content.
+func (s signal) matches(content []byte) bool {
+	if s.match != nil {
+		return s.match(content)
+	}
+	return s.pattern.Match(content)
+}
+
+// langSignals holds the signals for one language.
+type langSignals struct {
+	lang string
+
+	// extends names a language whose score is added once this language
+	// has evidence of its own (TypeScript extends JavaScript).
+	extends string
+
+	signals []signal
+}
+
+// score sums the weights of matching signals.
+func (ls langSignals) score(content []byte) float64 {
+	total := 0.0
+	for _, s := range ls.signals {
+		if s.matches(content) {
+			total += s.weight
+		}
+	}
+	return total
+}
+
+// re builds a multi-line pattern signal.
+func re(pattern string, weight float64) signal {
+	return signal{pattern: regexp.MustCompile(`(?m)` + pattern), weight: weight}
+}
+
+// fn builds a function signal.
+func fn(match func([]byte) bool, weight float64) signal {
+	return signal{match: match, weight: weight}
+}
+
+// languageSignals is the heuristic table used for pattern scoring.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var languageSignals = []langSignals{
+	{lang: "go", signals: []signal{
+		re(`^package \w+\s*$`, 0.6),
+		re(`^func (\(\w+ \*?\w+\) )?\w+\(`, 0.5),
+		re(`\bfmt\.\w+\(`, 0.6),
+		re(`\berr != nil\b`, 0.6),
+		re(`^import \($`, 0.5),
+		re(`\w+ := `, 0.3),
+	}},
+	{lang: "python", signals: []signal{
+		re(`^\s*def \w+\(.*\)( -> [\w\[\], .]+)?:\s*$`, 0.7),
+		re(`^\s*class \w+(\(.*\))?:\s*$`, 0.6),
+		re(`^from [\w.]+ import `, 0.7),
+		re(`^import [\w.]+( as \w+)?\s*$`, 0.4),
+		re(`__name__|__main__|__init__`, 0.7),
+		re(`\bprint\(`, 0.2),
+		re(`^\s*(elif|except)\b.*:\s*$`, 0.6),
+	}},
+	{lang: "javascript", signals: []signal{
+		re(`\bconsole\.log\(`, 0.5),
+		re(`=>`, 0.3),
+		re(`\b(const|let|var) \w+ =`, 0.3),
+		re(`\brequire\(['"]`, 0.5),
+		re(`\bfunction\s*\w*\s*\(`, 0.3),
+		re(`^\s*import .* from ['"]`, 0.3),
+		re(`^\s*export (default|const|function|class)\b`, 0.3),
+	}},
+	{lang: "typescript", extends: "javascript", signals: []signal{
+		re(`\w\??:\s*(string|number|boolean|any|unknown|void|never)(\[\])?\b`, 0.6),
+		re(`^\s*(export\s+)?interface \w+`, 0.7),
+		re(`^\s*(export\s+)?type \w+(<.*>)?\s*=`, 0.6),
+		re(`\bas (string|number|const)\b`, 0.4),
+		re(`^\s*(public|private|protected|readonly) \w+`, 0.3),
+	}},
+	{lang: "bash", signals: []signal{
+		re(`^\s*(sudo |apt-get |apt |brew |yum |dnf |curl |wget |npm |yarn |pip |go |git |make |docker |kubectl |mkdir |cd |export |echo )`, 0.4),
+		re(`^\s*if \[\[? .* \]\]?; then`, 0.7),
+		re(`^\s*(fi|done|esac)\s*$`, 0.5),
+		re(`\$\{?[A-Z_][A-Z0-9_]*\}?`, 0.2),
+		re(`^\s*\$ \S`, 0.5),
+		re(` \| (grep|awk|sed|xargs|sort|head|tail)\b`, 0.4),
+	}},
+	{lang: "ruby", signals: []signal{
+		re(`\bputs `, 0.5),
+		re(`^\s*end\s*$`, 0.3),
+		re(`\.each do \|`, 0.7),
+		re(`^\s*require ['"]`, 0.5),
+		re(`^\s*def \w+[?!]?(\(.*\))?\s*$`, 0.5),
+		re(`^\s*attr_(accessor|reader|writer) :`, 0.8),
+	}},
+	{lang: "rust", signals: []signal{
+		re(`\bfn main\(\)`, 0.8),
+		re(`\bprintln!\(`, 0.7),
+		re(`\blet mut `, 0.7),
+		re(`^\s*(pub )?fn \w+(<.*>)?\(`, 0.5),
+		re(`^\s*(impl|use|mod) [\w:]+`, 0.4),
+		re(`&(mut )?self\b`, 0.5),
+	}},
+	{lang: "java", signals: []signal{
+		re(`\bpublic (static )?(final )?(class|void|interface)\b`, 0.7),
+		re(`\bSystem\.out\.print(ln)?\(`, 0.8),
+		re(`^import java\.`, 0.8),
+		re(`^\s*@Override\s*$`, 0.5),
+	}},
+	{lang: "kotlin", signals: []signal{
+		re(`^\s*(private |internal |override |suspend )*fun \w+\(`, 0.6),
+		re(`\bval \w+(: \w+)?\s*=`, 0.4),
+		re(`^\s*data class \w+`, 0.8),
+		re(`^import kotlin(x)?\.`, 0.8),
+		re(`^package [\w]+(\.[\w]+)+\s*$`, 0.3),
+		re(`\bprintln\(`, 0.2),
+	}},
+	{lang: "c", signals: []signal{
+		re(`^#include <\w+\.h>`, 0.7),
+		re(`\bprintf\(`, 0.3),
+		re(`\bint main\(`, 0.4),
+		re(`\bmalloc\(|\bfree\(`, 0.4),
+	}},
+	{lang: "cpp", signals: []signal{
+		re(`^#include <\w+>`, 0.5),
+		re(`\bstd::`, 0.7),
+		re(`\b(std::)?cout <<`, 0.7),
+		re(`\btemplate\s*<`, 0.6),
+		re(`^\s*namespace \w+`, 0.4),
+	}},
+	{lang: "sql", signals: []signal{
+		re(`(?i)^\s*(SELECT|INSERT INTO|UPDATE|DELETE FROM|CREATE (TABLE|INDEX|VIEW)|ALTER TABLE|DROP TABLE)\b`, 0.8),
+		re(`(?i)\bFROM \w+( \w+)? (WHERE|JOIN|GROUP BY|ORDER BY)\b`, 0.4),
+	}},
+	{lang: langJSON, signals: []signal{
+		fn(isJSONDocument, 1.0),
+		re(`^\s*[\[{]\s*$|^\s*"[\w-]+"\s*:\s*`, 0.3),
+	}},
+	{lang: langYAML, signals: []signal{
+		fn(looksLikeYAML, 0.6),
+		re(`^---\s*$`, 0.3),
+		re(`^\s*- [\w"']+:`, 0.3),
+	}},
+	{lang: "toml", signals: []signal{
+		re(`^\[\[?[\w.-]+\]\]?\s*$`, 0.5),
+		re(`^[\w-]+\s*=\s*("|'|\d|true\b|false\b|\[)`, 0.3),
+	}},
+	{lang: "hcl", signals: []signal{
+		re(`^(resource|data|module|provider|variable|output|terraform|locals)\b[^\n{]*\{`, 0.8),
+		re(`^\s*[\w-]+\s*=\s*(var|local|module|data)\.`, 0.5),
+		re(`\$\{(var|local|module|data)\.`, 0.5),
+	}},
+	{lang: "html", signals: []signal{
+		re(`(?i)<!doctype html|<html[\s>]|<head>|<body[\s>]`, 0.9),
+		re(`(?i)<(div|span|p|a|ul|li|table)(\s[^>]*)?>`, 0.3),
+	}},
+	{lang: "css", signals: []signal{
+		re(`^\s*[.#]?[\w-]+(\s*[,>+~]?\s*[.#:]?[\w-]+)*\s*\{\s*$`, 0.3),
+		re(`^\s*[\w-]+:\s*[^;{}]+;\s*$`, 0.3),
+		re(`^\s*@(media|import|keyframes|font-face)\b`, 0.6),
+	}},
+	{lang: "dockerfile", signals: []signal{
+		re(`^FROM \S+`, 0.8),
+		re(`^(RUN|COPY|WORKDIR|ENTRYPOINT|CMD|EXPOSE|ENV|ARG|ADD) `, 0.3),
+	}},
+	{lang: "powershell", signals: []signal{
+		re(`\b(Get|Set|New|Remove|Write|Invoke|Start|Stop|Import|Install)-[A-Z]\w+`, 0.8),
+		re(`\$\w+\s*=`, 0.2),
+		re(`\s-(ErrorAction|Path|Force|Recurse|Name)\b`, 0.4),
+		re(`\$(true|false|null|env:\w+)\b`, 0.5),
+	}},
+	{lang: "protobuf", signals: []signal{
+		re(`^syntax = "proto[23]";`, 0.95),
+		re(`^message \w+ \{`, 0.6),
+		re(`^service \w+ \{`, 0.4),
+		re(`^\s*(repeated |optional )?\w+ \w+ = \d+;`, 0.4),
+		re(`^\s*rpc \w+\(`, 0.6),
+	}},
+}
+
+// isJSONDocument reports whether content is a valid JSON object or array.
+func isJSONDocument(content []byte) bool {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+	return json.Valid(trimmed)
+}
+
+// looksLikeYAML reports whether content has at least two "key: value" lines
+// or root-level list items.
+func looksLikeYAML(content []byte) bool {
+	yamlKeyCount := 0
+
+	for line := range bytes.SplitSeq(content, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || bytes.HasPrefix(line, []byte("#")) {
+			continue
+		}
+		// Simple key: value (identifier followed by colon and space).
+		// Exclude lines that look like code (contain parentheses, brackets).
+		if bytes.Contains(line, []byte(": ")) || bytes.HasSuffix(line, []byte(":")) {
+			if !bytes.ContainsAny(line, "(){};") && !bytes.HasPrefix(line, []byte(`"`)) {
+				yamlKeyCount++
+			}
+		}
+		// YAML list item.
+		if bytes.HasPrefix(line, []byte("- ")) {
+			yamlKeyCount++
+		}
+	}
+
+	return yamlKeyCount >= 2
+}
+
+// promptPattern matches shell prompts at the start of a transcript line:
+// "$ cmd", "% cmd", "user@host:~$ cmd" and "PS C:\> cmd".
+var promptPattern = regexp.MustCompile(`^\s*(?:[$%]|[\w.-]+@[\w.-]+(?::[^$#\s]*)?[$#]|PS [A-Za-z]:[^>]*>)\s+\S`)
+
+// isConsoleTranscript reports whether content is a prompt transcript: it
+// starts with a prompt line and contains at least one line of output.
+func isConsoleTranscript(content []byte) bool {
+	sawPrompt := false
+	continued := false
+
+	for line := range strings.SplitSeq(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		switch {
+		case promptPattern.MatchString(line):
+			sawPrompt = true
+		case !sawPrompt:
+			return false
+		case !continued:
+			return true // Output after a prompt.
+		}
+
+		continued = strings.HasSuffix(strings.TrimSpace(line), `\`)
+	}
+
+	return false
+}
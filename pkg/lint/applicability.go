@@ -0,0 +1,59 @@
+package lint
+
+import "github.com/yaklabco/gomdlint/pkg/config"
+
+// FixApplicability classifies how safely a fix can be applied without review.
+type FixApplicability string
+
+const (
+	// FixSafe fixes are mechanical and keep the meaning of the document.
+	// They are applied by --fix.
+	FixSafe FixApplicability = "safe"
+
+	// FixUnsafe fixes rely on heuristics, such as a guessed code language or
+	// an inferred heading level, and may need review. They are applied only
+	// with --unsafe-fixes.
+	FixUnsafe FixApplicability = "unsafe"
+
+	// FixSuggestion fixes are shown but never applied automatically.
+	FixSuggestion FixApplicability = "suggestion"
+)
+
+// IsValid returns true if the applicability is a known value.
+func (a FixApplicability) IsValid() bool {
+	switch a {
+	case FixSafe, FixUnsafe, FixSuggestion:
+		return true
+	default:
+		return false
+	}
+}
+
+// Enabled reports whether fixes of this applicability are applied, given
+// whether unsafe fixes are enabled. Empty means FixSafe.
+func (a FixApplicability) Enabled(unsafe bool) bool {
+	switch a {
+	case "", FixSafe:
+		return true
+	case FixUnsafe:
+		return unsafe
+	default:
+		return false
+	}
+}
+
+// DefaultFixApplicability returns the applicability of rule's fixes, for
+// diagnostics that do not set their own. Rules declare it with a
+// FixApplicability method; the default is FixSafe.
+func DefaultFixApplicability(rule Rule) FixApplicability {
+	declared, ok := rule.(interface{ FixApplicability() FixApplicability })
+	if !ok || declared.FixApplicability() == "" {
+		return FixSafe
+	}
+	return declared.FixApplicability()
+}
+
+// unsafeFixesEnabled reports whether cfg enables unsafe fixes.
+func unsafeFixesEnabled(cfg *config.Config) bool {
+	return cfg != nil && cfg.UnsafeFixes
+}
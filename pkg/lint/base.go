@@ -63,6 +63,26 @@ func (r *BaseRule) CanFix() bool {
 	return r.fixable
 }
 
+// FixApplicability returns the applicability of this rule's fixes.
+// Override this method for rules whose fixes are heuristic.
+func (r *BaseRule) FixApplicability() FixApplicability {
+	return FixSafe
+}
+
+// FixChangesRendering returns whether this rule's fixes are meant to change
+// the rendered document. Override this method for such rules; see
+// PipelineOptions.VerifyRendering.
+func (r *BaseRule) FixChangesRendering() bool {
+	return false
+}
+
+// FixChangesTextStyle returns whether this rule's fixes set the line
+// endings or byte order mark of the file. Fixes of other rules keep them.
+// Override this method for such rules.
+func (r *BaseRule) FixChangesTextStyle() bool {
+	return false
+}
+
 // Apply must be overridden by concrete rule implementations.
 // The default implementation returns no diagnostics.
 func (r *BaseRule) Apply(_ *RuleContext) ([]Diagnostic, error) {
@@ -0,0 +1,172 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+)
+
+// Identifiers of the diagnostic reported for a fix cycle. It is reported by
+// the fix loop rather than by a rule in the registry.
+const (
+	RuleFixCycle = "MDL014"
+	NameFixCycle = "fix-cycle"
+)
+
+// FixCycle describes fix passes that brought a file back to content an
+// earlier pass had produced, so that further passes would only repeat them.
+type FixCycle struct {
+	// Passes is the number of passes in the cycle.
+	Passes int
+
+	// Rules lists the IDs of the rules whose edits make up the cycle,
+	// sorted.
+	Rules []string
+
+	// StartLine, StartColumn, EndLine and EndColumn are the 1-based range
+	// of the content that the passes of the cycle change.
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+
+	// start is the index of the content the cycle returns to, and edits
+	// the number of edits the cycle applied.
+	start int
+	edits int
+}
+
+// fixHistory records the contents the fix loop produces, to detect when
+// it returns to one of them.
+type fixHistory struct {
+	// contents holds the content after each pass; contents[0] is the
+	// original content.
+	contents [][]byte
+
+	// results holds the lint result of each content that was fixed.
+	results []*FileResult
+
+	// seen maps content hashes to indexes in contents.
+	seen map[[sha256.Size]byte]int
+}
+
+func newFixHistory(content []byte) *fixHistory {
+	return &fixHistory{
+		contents: [][]byte{content},
+		seen:     map[[sha256.Size]byte]int{sha256.Sum256(content): 0},
+	}
+}
+
+// add records content, produced by applying the edits of fileResult to the
+// last content. passes are the passes of the loop so far. If content is an
+// earlier content, the cycle back to it is returned.
+func (h *fixHistory) add(content []byte, fileResult *FileResult, passes []FixPass) *FixCycle {
+	h.results = append(h.results, fileResult)
+
+	hash := sha256.Sum256(content)
+	start, ok := h.seen[hash]
+	if !ok {
+		h.seen[hash] = len(h.contents)
+		h.contents = append(h.contents, content)
+		return nil
+	}
+
+	cycle := &FixCycle{Passes: len(passes) - start, start: start}
+	for _, pass := range passes[start:] {
+		for ruleID, counts := range pass.Rules {
+			cycle.edits += counts.Applied
+			if counts.Applied > 0 && !slices.Contains(cycle.Rules, ruleID) {
+				cycle.Rules = append(cycle.Rules, ruleID)
+			}
+		}
+	}
+	slices.Sort(cycle.Rules)
+
+	// The cycle changes the content between the longest prefix and suffix
+	// that all of its contents share. A cycle of one pass changes nothing,
+	// so it is located by its edits.
+	prefix, suffix := len(content), len(content)
+	for _, other := range h.contents[start+1:] {
+		prefix = min(prefix, commonPrefix(content, other))
+		suffix = min(suffix, commonPrefix(reversed(content), reversed(other)))
+	}
+	end := max(len(content)-suffix, prefix)
+	if cycle.Passes == 1 && len(fileResult.Edits) > 0 {
+		prefix = fileResult.Edits[0].StartOffset
+		end = fileResult.Edits[len(fileResult.Edits)-1].EndOffset
+	}
+	if snapshot := h.results[start].Snapshot; snapshot != nil {
+		cycle.StartLine, cycle.StartColumn = snapshot.LineAt(prefix)
+		cycle.EndLine, cycle.EndColumn = snapshot.LineAt(end)
+	}
+	return cycle
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix(a, b []byte) int {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// reversed returns a reversed copy of b.
+func reversed(b []byte) []byte {
+	r := slices.Clone(b)
+	slices.Reverse(r)
+	return r
+}
+
+// cycleDiagnostic returns the diagnostic reporting cycle in the file at
+// path.
+func cycleDiagnostic(path string, cycle *FixCycle, registry *Registry) Diagnostic {
+	names := make([]string, 0, len(cycle.Rules))
+	for _, ruleID := range cycle.Rules {
+		name := ""
+		if registry != nil {
+			if rule, ok := registry.GetByID(ruleID); ok {
+				name = rule.Name()
+			}
+		}
+		names = append(names, config.FormatRuleID(config.RuleFormatCombined, ruleID, name))
+	}
+
+	message := fmt.Sprintf("Fixes from %s keep changing this text back and forth", strings.Join(names, ", "))
+	suggestion := fmt.Sprintf("The fix loop stopped after a cycle of %d passes; disable or reconfigure one of the rules", cycle.Passes)
+	if cycle.Passes == 1 {
+		// A single pass that changes nothing.
+		message = fmt.Sprintf("Fixes from %s do not change this text", strings.Join(names, ", "))
+		suggestion = "The fix loop stopped because the fixes made no change"
+	}
+	return Diagnostic{
+		RuleID:      RuleFixCycle,
+		RuleName:    NameFixCycle,
+		Severity:    config.SeverityWarning,
+		Message:     message,
+		Suggestion:  suggestion,
+		FilePath:    path,
+		StartLine:   cycle.StartLine,
+		StartColumn: cycle.StartColumn,
+		EndLine:     cycle.EndLine,
+		EndColumn:   cycle.EndColumn,
+	}
+}
+
+// editRules returns the sorted IDs of the rules of edits.
+func editRules(edits []fix.TextEdit) []string {
+	var rules []string
+	for _, edit := range edits {
+		if !slices.Contains(rules, edit.Origin.RuleID) {
+			rules = append(rules, edit.Origin.RuleID)
+		}
+	}
+	slices.Sort(rules)
+	return rules
+}
@@ -0,0 +1,127 @@
+package lint_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/lint"
+)
+
+func TestPipeline_Cycle_BackToOriginal(t *testing.T) {
+	t.Parallel()
+
+	result := processWithFix(t, "Some foo text.\n", 0,
+		newReplaceRule("TEST001", "foo", "bar"),
+		newReplaceRule("TEST002", "bar", "foo"))
+
+	cycle := result.Cycle
+	if cycle == nil {
+		t.Fatal("Cycle should be set")
+	}
+	if cycle.Passes != 2 {
+		t.Errorf("Cycle.Passes = %d, want 2", cycle.Passes)
+	}
+	if !slices.Equal(cycle.Rules, []string{"TEST001", "TEST002"}) {
+		t.Errorf("Cycle.Rules = %v, want [TEST001 TEST002]", cycle.Rules)
+	}
+	if !slices.Equal(result.RemainingRules, cycle.Rules) {
+		t.Errorf("RemainingRules = %v, want %v", result.RemainingRules, cycle.Rules)
+	}
+	if result.Exhausted {
+		t.Error("Exhausted should be false")
+	}
+	if result.Modified || result.ModifiedContent != nil {
+		t.Errorf("Modified = %v, ModifiedContent = %q; want the original content kept", result.Modified, result.ModifiedContent)
+	}
+	if result.TotalEditsApplied != 0 {
+		t.Errorf("TotalEditsApplied = %d, want 0", result.TotalEditsApplied)
+	}
+
+	var diag *lint.Diagnostic
+	for i := range result.Diagnostics {
+		if result.Diagnostics[i].RuleID == lint.RuleFixCycle {
+			diag = &result.Diagnostics[i]
+		}
+	}
+	if diag == nil {
+		t.Fatalf("no %s diagnostic in %+v", lint.RuleFixCycle, result.Diagnostics)
+	}
+	if diag.StartLine != 1 || diag.StartColumn != 6 || diag.EndLine != 1 || diag.EndColumn != 9 {
+		t.Errorf("diagnostic at %d:%d-%d:%d, want 1:6-1:9", diag.StartLine, diag.StartColumn, diag.EndLine, diag.EndColumn)
+	}
+	if diag.RuleName != lint.NameFixCycle {
+		t.Errorf("RuleName = %q, want %q", diag.RuleName, lint.NameFixCycle)
+	}
+}
+
+func TestPipeline_Cycle_AfterProgress(t *testing.T) {
+	t.Parallel()
+
+	// The first pass also makes a lasting fix; the cycle returns to the
+	// content after it.
+	result := processWithFix(t, "x foo\n", 0,
+		newReplaceRule("TEST001", "foo", "bar"),
+		newReplaceRule("TEST002", "bar", "foo"),
+		newReplaceRule("TEST003", "x", "y"))
+
+	if result.Cycle == nil {
+		t.Fatal("Cycle should be set")
+	}
+	if result.FixPasses != 3 {
+		t.Errorf("FixPasses = %d, want 3", result.FixPasses)
+	}
+	if result.Cycle.Passes != 2 {
+		t.Errorf("Cycle.Passes = %d, want 2", result.Cycle.Passes)
+	}
+	if !slices.Equal(result.Cycle.Rules, []string{"TEST001", "TEST002"}) {
+		t.Errorf("Cycle.Rules = %v, want [TEST001 TEST002]", result.Cycle.Rules)
+	}
+	if want := "y bar\n"; string(result.ModifiedContent) != want {
+		t.Errorf("ModifiedContent = %q, want %q", result.ModifiedContent, want)
+	}
+	if result.TotalEditsApplied != 2 {
+		t.Errorf("TotalEditsApplied = %d, want 2", result.TotalEditsApplied)
+	}
+}
+
+func TestPipeline_Exhausted_RemainingRules(t *testing.T) {
+	t.Parallel()
+
+	result := processWithFix(t, "foo\n", 3,
+		newReplaceRule("TEST001", "foo", "foo foo"),
+		newReplaceRule("TEST002", "bar", "baz"))
+
+	if !result.Exhausted {
+		t.Fatal("Exhausted should be true")
+	}
+	if result.Cycle != nil {
+		t.Errorf("Cycle = %+v, want nil", result.Cycle)
+	}
+	if !slices.Equal(result.RemainingRules, []string{"TEST001"}) {
+		t.Errorf("RemainingRules = %v, want [TEST001]", result.RemainingRules)
+	}
+}
+
+func TestPipeline_Cycle_NoChange(t *testing.T) {
+	t.Parallel()
+
+	result := processWithFix(t, "Some foo text.\n", 0, newReplaceRule("TEST001", "foo", "foo"))
+
+	if result.Cycle == nil {
+		t.Fatal("Cycle should be set")
+	}
+	if result.FixPasses != 1 || result.Cycle.Passes != 1 {
+		t.Errorf("FixPasses = %d, Cycle.Passes = %d; want 1 and 1", result.FixPasses, result.Cycle.Passes)
+	}
+	if result.Modified {
+		t.Error("Modified should be false")
+	}
+	last := result.Diagnostics[len(result.Diagnostics)-1]
+	if last.RuleID != lint.RuleFixCycle || last.StartColumn != 6 || last.EndColumn != 9 {
+		t.Errorf("last diagnostic = %s at 1:%d-1:%d, want %s at 1:6-1:9",
+			last.RuleID, last.StartColumn, last.EndColumn, lint.RuleFixCycle)
+	}
+	if last.Message != "Fixes from TEST001/replace-TEST001 do not change this text" {
+		t.Errorf("Message = %q", last.Message)
+	}
+}
@@ -110,6 +110,13 @@ func (b *DiagnosticBuilder) WithEdit(edit fix.TextEdit) *DiagnosticBuilder {
 	return b
 }
 
+// WithApplicability sets the applicability of the fix, overriding the
+// rule's default.
+func (b *DiagnosticBuilder) WithApplicability(a FixApplicability) *DiagnosticBuilder {
+	b.diag.Applicability = a
+	return b
+}
+
 // Build returns the constructed Diagnostic.
 func (b *DiagnosticBuilder) Build() Diagnostic {
 	return b.diag
@@ -0,0 +1,130 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
+)
+
+// Identifiers of the diagnostics reported for files that are not UTF-8.
+// They are reported by the pipeline rather than by a rule in the registry.
+const (
+	RuleEncoding = "MDL017"
+	NameEncoding = "file-encoding"
+)
+
+// decodedFile is the UTF-8 text the pipeline lints for a file.
+type decodedFile struct {
+	// content is the text to lint.
+	content []byte
+
+	// diagnostics report the encoding of the file and its invalid bytes.
+	diagnostics []Diagnostic
+
+	// skipReason is set if the file is not linted.
+	skipReason string
+
+	// readOnly is true if fixes must not be written, because the file is
+	// not UTF-8 and the policy does not convert it.
+	readOnly bool
+
+	// converted is true if content is a conversion of the file that fix
+	// mode writes back.
+	converted bool
+}
+
+// decodeFile returns the text to lint for the file at path, with content
+// in encoding enc, following the encoding policy of cfg.
+func decodeFile(path string, content []byte, enc fsutil.Encoding, cfg *config.Config) decodedFile {
+	if enc == fsutil.EncodingBinary {
+		return decodedFile{
+			skipReason: "binary file",
+			diagnostics: []Diagnostic{encodingDiagnostic(path, content, 0, 0, config.SeverityInfo,
+				"Binary file skipped", "Rename the file or add it to ignore")},
+		}
+	}
+
+	invalid := fsutil.InvalidUTF8(content)
+	if enc == fsutil.EncodingUTF8 && invalid == nil {
+		return decodedFile{content: content}
+	}
+
+	policy := config.EncodingSkip
+	if cfg != nil && cfg.Encoding != "" {
+		policy = cfg.Encoding
+	}
+
+	var diags []Diagnostic
+	if enc == fsutil.EncodingUTF8 {
+		// Invalid bytes are an error only if they keep the file from
+		// being linted.
+		severity := config.SeverityWarning
+		if policy == config.EncodingSkip {
+			severity = config.SeverityError
+		}
+		suggestion := map[config.EncodingPolicy]string{
+			config.EncodingSkip:      "Save the file as UTF-8; it was not linted",
+			config.EncodingTranscode: "Save the file as UTF-8; it was linted as Windows-1252",
+			config.EncodingConvert:   "Run with --fix to convert the file to UTF-8 from Windows-1252",
+		}[policy]
+		for _, seq := range invalid {
+			diags = append(diags, encodingDiagnostic(path, content, seq.Offset, seq.Offset+len(seq.Bytes),
+				severity, fmt.Sprintf("Invalid UTF-8 byte sequence % X", seq.Bytes), suggestion))
+		}
+	} else {
+		suggestion := map[config.EncodingPolicy]string{
+			config.EncodingSkip:      "Save the file as UTF-8, or set encoding to transcode or convert to lint it",
+			config.EncodingTranscode: "Save the file as UTF-8",
+			config.EncodingConvert:   "Run with --fix to convert the file to UTF-8",
+		}[policy]
+		diags = append(diags, encodingDiagnostic(path, content, 0, 0, config.SeverityWarning,
+			fmt.Sprintf("File is encoded in %s, not UTF-8", enc), suggestion))
+	}
+
+	if policy == config.EncodingSkip {
+		reason := "invalid UTF-8"
+		if enc != fsutil.EncodingUTF8 {
+			reason = string(enc) + " encoding"
+		}
+		return decodedFile{skipReason: reason, diagnostics: diags}
+	}
+
+	decoded, err := fsutil.DecodeText(content, enc)
+	if err != nil {
+		return decodedFile{skipReason: err.Error(), diagnostics: diags}
+	}
+	return decodedFile{
+		content:     decoded,
+		diagnostics: diags,
+		readOnly:    policy != config.EncodingConvert,
+		converted:   policy == config.EncodingConvert,
+	}
+}
+
+// encodingDiagnostic reports the bytes [start, end) of content. Columns are
+// byte columns of the file as it is on disk.
+func encodingDiagnostic(path string, content []byte, start, end int, severity config.Severity, msg, suggestion string) Diagnostic {
+	startLine, startColumn := byteLineColumn(content, start)
+	endLine, endColumn := byteLineColumn(content, end)
+	return Diagnostic{
+		RuleID:      RuleEncoding,
+		RuleName:    NameEncoding,
+		Severity:    severity,
+		Message:     msg,
+		Suggestion:  suggestion,
+		FilePath:    path,
+		StartLine:   startLine,
+		StartColumn: startColumn,
+		EndLine:     endLine,
+		EndColumn:   endColumn,
+	}
+}
+
+// byteLineColumn returns the 1-based line and byte column of offset in
+// content.
+func byteLineColumn(content []byte, offset int) (int, int) {
+	before := content[:min(offset, len(content))]
+	return bytes.Count(before, []byte("\n")) + 1, len(before) - bytes.LastIndexByte(before, '\n')
+}
@@ -0,0 +1,218 @@
+package lint_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+// utf16LE encodes the ASCII text s as UTF-16LE with a byte order mark.
+func utf16LE(s string) []byte {
+	out := []byte{0xFF, 0xFE}
+	for i := range len(s) {
+		out = append(out, s[i], 0)
+	}
+	return out
+}
+
+// processEncodedFile writes content to a file and runs the fix pipeline on
+// it with a rule replacing "foo" with "bar", under the encoding policy.
+func processEncodedFile(t *testing.T, content []byte, policy config.EncodingPolicy) (*lint.PipelineResult, string) {
+	t.Helper()
+
+	cfg := config.NewConfig()
+	cfg.Encoding = policy
+	return processEncodedFileConfig(t, content, cfg)
+}
+
+// processEncodedFileConfig is processEncodedFile with the configuration cfg.
+func processEncodedFileConfig(t *testing.T, content []byte, cfg *config.Config) (*lint.PipelineResult, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.md")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	registry := lint.NewRegistry()
+	registry.Register(newReplaceRule("TEST001", "foo", "bar"))
+	pipeline := lint.NewPipeline(lint.NewEngine(goldmark.New(goldmark.FlavorCommonMark), registry))
+
+	cfg.Fix = true
+	result, err := pipeline.ProcessFile(context.Background(), path, cfg, lint.PipelineOptions{Fix: true})
+	if err != nil {
+		t.Fatalf("ProcessFile() error = %v", err)
+	}
+	return result, path
+}
+
+// ruleIDs returns the rule IDs of the diagnostics of result.
+func ruleIDs(result *lint.PipelineResult) []string {
+	var ids []string
+	for _, diag := range result.Diagnostics {
+		ids = append(ids, diag.RuleID)
+	}
+	return ids
+}
+
+func assertFileContent(t *testing.T, path string, want []byte) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("file content = %q, want %q", got, want)
+	}
+}
+
+func TestPipeline_Encoding_BinarySkipped(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("\x89PNG\r\n\x1a\n\x00\x00foo")
+	result, path := processEncodedFile(t, content, config.EncodingConvert)
+
+	if !result.Skipped || result.SkipReason != "binary file" {
+		t.Errorf("Skipped = %v, SkipReason = %q; want binary file", result.Skipped, result.SkipReason)
+	}
+	if len(result.Diagnostics) != 1 || result.Diagnostics[0].Severity != config.SeverityInfo {
+		t.Errorf("Diagnostics = %+v, want one info diagnostic", result.Diagnostics)
+	}
+	assertFileContent(t, path, content)
+}
+
+func TestPipeline_Encoding_InvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("# Caf\xe9 foo\n\nok\n\xff\xfe bytes\n")
+	result, path := processEncodedFile(t, content, "")
+
+	if !result.Skipped || result.SkipReason != "invalid UTF-8" {
+		t.Errorf("Skipped = %v, SkipReason = %q; want invalid UTF-8", result.Skipped, result.SkipReason)
+	}
+	if len(result.Diagnostics) != 2 {
+		t.Fatalf("Diagnostics = %+v, want 2", result.Diagnostics)
+	}
+	first, second := result.Diagnostics[0], result.Diagnostics[1]
+	if first.RuleID != lint.RuleEncoding || first.Message != "Invalid UTF-8 byte sequence E9" ||
+		first.StartLine != 1 || first.StartColumn != 6 || first.EndColumn != 7 {
+		t.Errorf("first diagnostic = %+v", first)
+	}
+	if second.Message != "Invalid UTF-8 byte sequence FF FE" || second.StartLine != 4 ||
+		second.StartColumn != 1 || second.EndColumn != 3 {
+		t.Errorf("second diagnostic = %+v", second)
+	}
+	assertFileContent(t, path, content)
+}
+
+func TestPipeline_Encoding_Transcode(t *testing.T) {
+	t.Parallel()
+
+	content := utf16LE("# Title\r\n\r\nSome foo.\r\n")
+	result, path := processEncodedFile(t, content, config.EncodingTranscode)
+
+	ids := ruleIDs(result)
+	if len(ids) != 2 || ids[0] != lint.RuleEncoding || ids[1] != "TEST001" {
+		t.Errorf("diagnostics from %v, want %s and TEST001", ids, lint.RuleEncoding)
+	}
+	if result.Modified || result.Written {
+		t.Errorf("Modified = %v, Written = %v; want the file left alone", result.Modified, result.Written)
+	}
+	assertFileContent(t, path, content)
+}
+
+func TestPipeline_Encoding_Convert(t *testing.T) {
+	t.Parallel()
+
+	result, path := processEncodedFile(t, utf16LE("# Title\r\n\r\nSome foo.\r\n"), config.EncodingConvert)
+
+	if ids := ruleIDs(result); len(ids) != 0 {
+		t.Errorf("diagnostics from %v, want none", ids)
+	}
+	if !result.Written || !result.Converted {
+		t.Errorf("Written = %v, Converted = %v; want both", result.Written, result.Converted)
+	}
+	if result.TotalEditsApplied != 1 {
+		t.Errorf("TotalEditsApplied = %d, want 1", result.TotalEditsApplied)
+	}
+	assertFileContent(t, path, []byte("# Title\r\n\r\nSome bar.\r\n"))
+}
+
+func TestPipeline_Encoding_TranscodeInvalidUTF8IsWarning(t *testing.T) {
+	t.Parallel()
+
+	result, _ := processEncodedFile(t, []byte("# Caf\xe9 foo\n"), config.EncodingTranscode)
+
+	if len(result.Diagnostics) != 2 || result.Diagnostics[0].RuleID != lint.RuleEncoding {
+		t.Fatalf("Diagnostics = %+v, want %s and TEST001", result.Diagnostics, lint.RuleEncoding)
+	}
+	if got := result.Diagnostics[0].Severity; got != config.SeverityWarning {
+		t.Errorf("Severity = %q, want warning", got)
+	}
+}
+
+func TestPipeline_Encoding_RuleConfig(t *testing.T) {
+	t.Parallel()
+
+	binary := []byte("\x89PNG\r\n\x1a\n\x00\x00foo")
+	errorSeverity := string(config.SeverityError)
+	disabled := false
+
+	tests := []struct {
+		name      string
+		configure func(cfg *config.Config)
+		wantDiags int
+		wantSev   config.Severity
+	}{
+		{
+			name:      "default",
+			configure: func(*config.Config) {},
+			wantDiags: 1,
+			wantSev:   config.SeverityInfo,
+		},
+		{
+			name:      "disabled on the command line",
+			configure: func(cfg *config.Config) { cfg.DisableRules = []string{lint.RuleEncoding} },
+		},
+		{
+			name: "disabled by name in the config",
+			configure: func(cfg *config.Config) {
+				cfg.Rules[lint.NameEncoding] = config.RuleConfig{Enabled: &disabled}
+			},
+		},
+		{
+			name: "severity set in the config",
+			configure: func(cfg *config.Config) {
+				cfg.Rules[lint.RuleEncoding] = config.RuleConfig{Severity: &errorSeverity}
+			},
+			wantDiags: 1,
+			wantSev:   config.SeverityError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.NewConfig()
+			tt.configure(cfg)
+			result, _ := processEncodedFileConfig(t, binary, cfg)
+
+			if !result.Skipped {
+				t.Error("Skipped should be true")
+			}
+			if len(result.Diagnostics) != tt.wantDiags {
+				t.Fatalf("Diagnostics = %+v, want %d", result.Diagnostics, tt.wantDiags)
+			}
+			if tt.wantDiags > 0 && result.Diagnostics[0].Severity != tt.wantSev {
+				t.Errorf("Severity = %q, want %q", result.Diagnostics[0].Severity, tt.wantSev)
+			}
+		})
+	}
+}
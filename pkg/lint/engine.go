@@ -25,9 +25,19 @@ type FileResult struct {
 	// When multiple edits overlap, earlier edits (by start position) take precedence.
 	SkippedEdits []fix.TextEdit
 
+	// MergedEdits contains deletions that overlapped a deletion of Edits
+	// and were merged into it. The merged edit keeps the origin of the
+	// first deletion it covers.
+	MergedEdits []fix.TextEdit
+
 	// EditConflicts is true if any edits were skipped due to conflicts.
 	EditConflicts bool
 
+	// HeldBackFixes counts the fixes of auto-fix rules that were not added
+	// to Edits because their applicability is not enabled, by applicability.
+	// It is nil if there are none, and without --fix.
+	HeldBackFixes map[FixApplicability]int
+
 	// RuleErrors contains any errors from rule execution.
 	RuleErrors map[string]error
 }
@@ -47,11 +57,12 @@ func (fr *FileResult) IssueCount() int {
 	return len(fr.Diagnostics)
 }
 
-// FixableCount returns the number of diagnostics with fixes.
+// FixableCount returns the number of diagnostics with fixes that fix mode
+// can apply (see Diagnostic.AutoFixable).
 func (fr *FileResult) FixableCount() int {
 	count := 0
 	for _, d := range fr.Diagnostics {
-		if d.HasFix() {
+		if d.AutoFixable() {
 			count++
 		}
 	}
@@ -136,30 +147,68 @@ func (e *Engine) LintFile(
 				diags[diagIdx].RuleName = rr.Rule.Name()
 			}
 
-			// Collect edits if auto-fix is enabled for this rule.
-			if rr.AutoFix && len(diags[diagIdx].FixEdits) > 0 {
-				allEdits = append(allEdits, diags[diagIdx].FixEdits...)
+			if len(diags[diagIdx].FixEdits) == 0 {
+				continue
+			}
+
+			// Record which diagnostic proposed each edit.
+			origin := fix.Origin{
+				RuleID: rr.Rule.ID(),
+				Line:   diags[diagIdx].StartLine,
+				Column: diags[diagIdx].StartColumn,
+			}
+			for editIdx := range diags[diagIdx].FixEdits {
+				diags[diagIdx].FixEdits[editIdx].Origin = origin
+			}
+
+			// Resolve the applicability of the fix.
+			if diags[diagIdx].Applicability == "" {
+				diags[diagIdx].Applicability = DefaultFixApplicability(rr.Rule)
+			}
+
+			// Collect edits if auto-fix is enabled for this rule and the
+			// fix's applicability, or hold them back.
+			if !rr.AutoFix {
+				continue
+			}
+			if !diags[diagIdx].Applicability.Enabled(unsafeFixesEnabled(cfg)) {
+				if result.HeldBackFixes == nil {
+					result.HeldBackFixes = make(map[FixApplicability]int)
+				}
+				result.HeldBackFixes[diags[diagIdx].Applicability]++
+				continue
 			}
+			allEdits = append(allEdits, diags[diagIdx].FixEdits...)
 		}
 
 		result.Diagnostics = append(result.Diagnostics, diags...)
 	}
 
 	// Validate and prepare edits, merging deletions and filtering conflicts.
-	if len(allEdits) > 0 {
-		accepted, skipped, _, err := fix.PrepareEditsFiltered(allEdits, len(content))
-		if err != nil {
-			// Validation error (not conflicts - those are filtered).
-			// Still include diagnostics but clear edits.
-			result.Edits = nil
-			result.SkippedEdits = nil
-			result.EditConflicts = true
-		} else {
-			result.Edits = accepted
-			result.SkippedEdits = skipped
-			result.EditConflicts = len(skipped) > 0
-		}
-	}
+	result.setEdits(allEdits, len(content))
 
 	return result, nil
 }
+
+// setEdits validates and prepares edits as the fix edits of the result.
+func (fr *FileResult) setEdits(edits []fix.TextEdit, contentLen int) {
+	fr.Edits = nil
+	fr.SkippedEdits = nil
+	fr.MergedEdits = nil
+	fr.EditConflicts = false
+	if len(edits) == 0 {
+		return
+	}
+
+	accepted, skipped, merged, err := fix.PrepareEditsFiltered(edits, contentLen)
+	if err != nil {
+		// Validation error (not conflicts - those are filtered).
+		// Still include diagnostics but clear edits.
+		fr.EditConflicts = true
+		return
+	}
+	fr.Edits = accepted
+	fr.SkippedEdits = skipped
+	fr.MergedEdits = merged
+	fr.EditConflicts = len(skipped) > 0
+}
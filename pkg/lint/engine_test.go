@@ -3,6 +3,7 @@ package lint_test
 import (
 	"context"
 	"errors"
+	"maps"
 	"testing"
 
 	"github.com/yaklabco/gomdlint/pkg/config"
@@ -279,6 +280,123 @@ func TestEngine_LintFile_WithFixes(t *testing.T) {
 	}
 }
 
+// unsafeRule is a fixable test rule whose fixes are unsafe by default.
+type unsafeRule struct {
+	fixableRule
+}
+
+func (r *unsafeRule) FixApplicability() lint.FixApplicability {
+	return lint.FixUnsafe
+}
+
+func TestEngine_LintFile_FixApplicability(t *testing.T) {
+	t.Parallel()
+
+	newDiag := func(id string, start int, applicability lint.FixApplicability) lint.Diagnostic {
+		return lint.Diagnostic{
+			RuleID:        id,
+			Message:       "fixable issue",
+			FixEdits:      []fix.TextEdit{{StartOffset: start, EndOffset: start + 1, NewText: "x"}},
+			Applicability: applicability,
+		}
+	}
+	newRegistry := func() *lint.Registry {
+		registry := lint.NewRegistry()
+		registry.Register(&fixableRule{
+			BaseRule: lint.NewBaseRule("TEST001", "safe-rule", "", nil, true),
+			diags: []lint.Diagnostic{
+				newDiag("TEST001", 0, ""),
+				newDiag("TEST001", 1, lint.FixSuggestion),
+			},
+		})
+		registry.Register(&unsafeRule{fixableRule{
+			BaseRule: lint.NewBaseRule("TEST002", "unsafe-rule", "", nil, true),
+			diags: []lint.Diagnostic{
+				newDiag("TEST002", 2, ""),
+				newDiag("TEST002", 3, lint.FixSafe),
+			},
+		}})
+		return registry
+	}
+
+	tests := []struct {
+		name         string
+		unsafe       bool
+		wantEdits    int
+		wantHeldBack map[lint.FixApplicability]int
+	}{
+		{
+			name:         "safe only",
+			wantEdits:    2,
+			wantHeldBack: map[lint.FixApplicability]int{lint.FixUnsafe: 1, lint.FixSuggestion: 1},
+		},
+		{
+			name:         "unsafe enabled",
+			unsafe:       true,
+			wantEdits:    3,
+			wantHeldBack: map[lint.FixApplicability]int{lint.FixSuggestion: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.NewConfig()
+			cfg.Fix = true
+			cfg.UnsafeFixes = tt.unsafe
+			engine := lint.NewEngine(&mockParser{}, newRegistry())
+
+			result, err := engine.LintFile(context.Background(), "test.md", []byte("abcdef"), cfg)
+			if err != nil {
+				t.Fatalf("LintFile error: %v", err)
+			}
+
+			if len(result.Edits) != tt.wantEdits {
+				t.Errorf("len(Edits) = %d, want %d", len(result.Edits), tt.wantEdits)
+			}
+			if !maps.Equal(result.HeldBackFixes, tt.wantHeldBack) {
+				t.Errorf("HeldBackFixes = %v, want %v", result.HeldBackFixes, tt.wantHeldBack)
+			}
+
+			// The engine fills in the rule's default applicability.
+			want := []lint.FixApplicability{lint.FixSafe, lint.FixSuggestion, lint.FixUnsafe, lint.FixSafe}
+			for i, diag := range result.Diagnostics {
+				if diag.Applicability != want[i] {
+					t.Errorf("Diagnostics[%d].Applicability = %q, want %q", i, diag.Applicability, want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFixApplicability_Enabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		applicability lint.FixApplicability
+		safeOnly      bool
+		withUnsafe    bool
+	}{
+		{"", true, true},
+		{lint.FixSafe, true, true},
+		{lint.FixUnsafe, false, true},
+		{lint.FixSuggestion, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.applicability.Enabled(false); got != tt.safeOnly {
+			t.Errorf("%q.Enabled(false) = %v, want %v", tt.applicability, got, tt.safeOnly)
+		}
+		if got := tt.applicability.Enabled(true); got != tt.withUnsafe {
+			t.Errorf("%q.Enabled(true) = %v, want %v", tt.applicability, got, tt.withUnsafe)
+		}
+	}
+	if lint.FixApplicability("risky").IsValid() {
+		t.Error("IsValid() = true for an unknown applicability")
+	}
+}
+
 func TestEngine_LintFile_EditConflicts(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"github.com/yaklabco/gomdlint/pkg/fix"
+)
+
+// FixPass records the edits of one pass of the fix loop, for explaining
+// what the loop did (see PipelineResult.Passes).
+type FixPass struct {
+	// Rules maps rule IDs to the number of their edits applied and skipped
+	// in the pass.
+	Rules map[string]EditCounts
+
+	// Conflicts lists the edits skipped because they overlap an applied
+	// edit.
+	Conflicts []EditConflict
+
+	// Reversals lists the edits of the pass that undo an edit of the
+	// previous pass.
+	Reversals []EditReversal
+}
+
+// EditCounts counts the edits of a rule in a fix pass.
+type EditCounts struct {
+	Applied int
+	Skipped int
+}
+
+// EditConflict is an edit skipped because it overlaps an applied edit.
+type EditConflict struct {
+	fix.Conflict
+
+	// Line and Column are the 1-based position of the applied edit in the
+	// content the pass started from.
+	Line   int
+	Column int
+}
+
+// EditReversal is an edit that restores the text an edit of the previous
+// pass replaced, so that the rules of the two edits undo each other.
+type EditReversal struct {
+	// Undone is the edit of the previous pass.
+	Undone fix.TextEdit
+
+	// Undoing is the edit that reverts it.
+	Undoing fix.TextEdit
+}
+
+// appliedEdit is an edit applied by a fix pass, located in the content the
+// pass produced.
+type appliedEdit struct {
+	edit fix.TextEdit
+
+	// start is the offset of the edit's new text in the new content.
+	start int
+
+	// oldText is the text the edit replaced.
+	oldText string
+}
+
+// recordFixPass records the edits fileResult applies to content. applied
+// are its edits as applied, with the line endings of the file. previous
+// holds the edits the previous pass applied; the edits of this pass are
+// returned for the next one.
+func recordFixPass(content []byte, fileResult *FileResult, applied []fix.TextEdit, previous []appliedEdit) (FixPass, []appliedEdit) {
+	pass := FixPass{Rules: make(map[string]EditCounts)}
+
+	for _, edit := range fileResult.Edits {
+		counts := pass.Rules[edit.Origin.RuleID]
+		counts.Applied++
+		pass.Rules[edit.Origin.RuleID] = counts
+	}
+	// A merged deletion is applied as part of the edit it was merged into.
+	for _, edit := range fileResult.MergedEdits {
+		counts := pass.Rules[edit.Origin.RuleID]
+		counts.Applied++
+		pass.Rules[edit.Origin.RuleID] = counts
+	}
+	for _, edit := range fileResult.SkippedEdits {
+		counts := pass.Rules[edit.Origin.RuleID]
+		counts.Skipped++
+		pass.Rules[edit.Origin.RuleID] = counts
+	}
+
+	for _, conflict := range fix.FindConflicts(fileResult.Edits, fileResult.SkippedEdits) {
+		located := EditConflict{Conflict: conflict}
+		if fileResult.Snapshot != nil {
+			located.Line, located.Column = fileResult.Snapshot.LineAt(conflict.Accepted.StartOffset)
+		}
+		pass.Conflicts = append(pass.Conflicts, located)
+	}
+
+	// An edit undoes an edit of the previous pass if it replaces exactly
+	// that edit's new text with the text it replaced.
+	previousAt := make(map[int][]appliedEdit, len(previous))
+	for _, prev := range previous {
+		previousAt[prev.start] = append(previousAt[prev.start], prev)
+	}
+	for _, edit := range applied {
+		for _, prev := range previousAt[edit.StartOffset] {
+			if edit.EndOffset == prev.start+len(prev.edit.NewText) && edit.NewText == prev.oldText {
+				pass.Reversals = append(pass.Reversals, EditReversal{Undone: prev.edit, Undoing: edit})
+				break
+			}
+		}
+	}
+
+	located := make([]appliedEdit, 0, len(applied))
+	delta := 0
+	for _, edit := range applied {
+		located = append(located, appliedEdit{
+			edit:    edit,
+			start:   edit.StartOffset + delta,
+			oldText: string(content[edit.StartOffset:edit.EndOffset]),
+		})
+		delta += len(edit.NewText) - (edit.EndOffset - edit.StartOffset)
+	}
+
+	return pass, located
+}
@@ -0,0 +1,163 @@
+package lint_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+func processWithFix(t *testing.T, content string, maxPasses int, rules ...lint.Rule) *lint.PipelineResult {
+	t.Helper()
+
+	registry := lint.NewRegistry()
+	for _, rule := range rules {
+		registry.Register(rule)
+	}
+	pipeline := lint.NewPipeline(lint.NewEngine(goldmark.New(goldmark.FlavorCommonMark), registry))
+
+	cfg := config.NewConfig()
+	cfg.Fix = true
+	opts := lint.PipelineOptions{Fix: true, MaxFixPasses: maxPasses}
+
+	result, err := pipeline.ProcessContent(context.Background(), "test.md", []byte(content), cfg, opts)
+	if err != nil {
+		t.Fatalf("ProcessContent() error = %v", err)
+	}
+	return result
+}
+
+func TestPipeline_Passes_CountsAndConflicts(t *testing.T) {
+	t.Parallel()
+
+	result := processWithFix(t, "Some text\nwith abcd in it.\n", 0,
+		newReplaceRule("TEST001", "abc", "x"),
+		newReplaceRule("TEST002", "bcd", "y"))
+
+	if len(result.Passes) != 1 {
+		t.Fatalf("got %d passes, want 1", len(result.Passes))
+	}
+	pass := result.Passes[0]
+
+	if got := pass.Rules["TEST001"]; got != (lint.EditCounts{Applied: 1}) {
+		t.Errorf("TEST001 counts = %+v, want 1 applied", got)
+	}
+	if got := pass.Rules["TEST002"]; got != (lint.EditCounts{Skipped: 1}) {
+		t.Errorf("TEST002 counts = %+v, want 1 skipped", got)
+	}
+
+	if len(pass.Conflicts) != 1 {
+		t.Fatalf("got %d conflicts, want 1", len(pass.Conflicts))
+	}
+	conflict := pass.Conflicts[0]
+	if conflict.Skipped.Origin.RuleID != "TEST002" || conflict.Accepted.Origin.RuleID != "TEST001" {
+		t.Errorf("conflict = %s skipped for %s, want TEST002 skipped for TEST001",
+			conflict.Skipped.Origin.RuleID, conflict.Accepted.Origin.RuleID)
+	}
+	if conflict.Line != 2 || conflict.Column != 6 {
+		t.Errorf("conflict at %d:%d, want 2:6", conflict.Line, conflict.Column)
+	}
+	if len(pass.Reversals) != 0 {
+		t.Errorf("Reversals = %+v, want none", pass.Reversals)
+	}
+}
+
+func TestPipeline_Passes_CountsMergedDeletions(t *testing.T) {
+	t.Parallel()
+
+	result := processWithFix(t, "Some text\nwith abcd in it.\n", 0,
+		newReplaceRule("TEST001", "abc", ""),
+		newReplaceRule("TEST002", "bcd", ""))
+
+	if want := "Some text\nwith  in it.\n"; string(result.ModifiedContent) != want {
+		t.Errorf("ModifiedContent = %q, want %q", result.ModifiedContent, want)
+	}
+	if len(result.Passes) != 1 {
+		t.Fatalf("got %d passes, want 1", len(result.Passes))
+	}
+	pass := result.Passes[0]
+
+	// The overlapping deletions are merged into one edit, which counts for
+	// both rules.
+	for _, ruleID := range []string{"TEST001", "TEST002"} {
+		if got := pass.Rules[ruleID]; got != (lint.EditCounts{Applied: 1}) {
+			t.Errorf("%s counts = %+v, want 1 applied", ruleID, got)
+		}
+	}
+	if _, ok := pass.Rules[""]; ok {
+		t.Errorf("Rules = %+v, want no edits without a rule", pass.Rules)
+	}
+	if len(pass.Conflicts) != 0 {
+		t.Errorf("Conflicts = %+v, want none", pass.Conflicts)
+	}
+}
+
+func TestPipeline_Passes_Reversals(t *testing.T) {
+	t.Parallel()
+
+	result := processWithFix(t, "Some foo text.\n", 3,
+		newReplaceRule("TEST001", "foo", "bar"),
+		newReplaceRule("TEST002", "bar", "foo"))
+
+	// The second pass restores the original content, which ends the loop.
+	if result.Cycle == nil {
+		t.Fatal("Cycle should be set")
+	}
+	if len(result.Passes) != 2 {
+		t.Fatalf("got %d passes, want 2", len(result.Passes))
+	}
+	if len(result.Passes[0].Reversals) != 0 {
+		t.Errorf("pass 1 reversals = %+v, want none", result.Passes[0].Reversals)
+	}
+
+	reversals := result.Passes[1].Reversals
+	if len(reversals) != 1 {
+		t.Fatalf("pass 2: got %d reversals, want 1", len(reversals))
+	}
+	if reversals[0].Undone.Origin.RuleID != "TEST001" || reversals[0].Undoing.Origin.RuleID != "TEST002" {
+		t.Errorf("pass 2: %s undoes %s, want TEST002 undoing TEST001",
+			reversals[0].Undoing.Origin.RuleID, reversals[0].Undone.Origin.RuleID)
+	}
+	if reversals[0].Undoing.Origin.Line != 1 || reversals[0].Undoing.Origin.Column != 6 {
+		t.Errorf("pass 2: undoing edit for %d:%d, want 1:6",
+			reversals[0].Undoing.Origin.Line, reversals[0].Undoing.Origin.Column)
+	}
+}
+
+func TestPipeline_Passes_ReversalsInCRLFFile(t *testing.T) {
+	t.Parallel()
+
+	// The first rule inserts a line break, which becomes CRLF in the file;
+	// the second joins the lines again.
+	result := processWithFix(t, "# Title\r\n\r\nSome foo and foo.\r\n", 3,
+		newReplaceRule("TEST001", "foo", "a\nb"),
+		newReplaceRule("TEST002", "a\r\nb", "foo"))
+
+	if result.Cycle == nil {
+		t.Fatal("Cycle should be set")
+	}
+	if len(result.Passes) != 2 {
+		t.Fatalf("got %d passes, want 2", len(result.Passes))
+	}
+
+	reversals := result.Passes[1].Reversals
+	if len(reversals) != 2 {
+		t.Fatalf("pass 2: got %d reversals, want 2", len(reversals))
+	}
+	for i, reversal := range reversals {
+		if reversal.Undone.Origin.RuleID != "TEST001" || reversal.Undoing.Origin.RuleID != "TEST002" {
+			t.Errorf("reversal %d: %s undoes %s, want TEST002 undoing TEST001",
+				i, reversal.Undoing.Origin.RuleID, reversal.Undone.Origin.RuleID)
+		}
+		if reversal.Undone.NewText != "a\r\nb" {
+			t.Errorf("reversal %d: undone edit inserted %q, want %q", i, reversal.Undone.NewText, "a\r\nb")
+		}
+	}
+	// The second edit of the first pass starts after the CRLF the first
+	// one inserted.
+	if got := reversals[1].Undoing.StartOffset; got != 25 {
+		t.Errorf("second undoing edit at offset %d, want 25", got)
+	}
+}
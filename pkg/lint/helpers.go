@@ -72,6 +72,31 @@ func CodeBlockInfo(n *mdast.Node) string {
 	return n.Block.CodeBlock.Info
 }
 
+// CodeBlockContent returns the raw content of a fenced code block, excluding
+// the opening and closing fences. Returns nil if the block has no content.
+// Note: for fenced code blocks, pos.StartLine points to the first content line
+// (not the opening fence), and pos.EndLine includes the closing fence.
+func CodeBlockContent(file *mdast.FileSnapshot, n *mdast.Node) []byte {
+	if file == nil || n == nil {
+		return nil
+	}
+	pos := n.SourcePosition()
+	if !pos.IsValid() {
+		return nil
+	}
+
+	startLine := pos.StartLine
+	endLine := pos.EndLine - 1
+	if startLine > endLine || startLine < 1 || endLine > len(file.Lines) {
+		return nil
+	}
+
+	startOffset := file.Lines[startLine-1].StartOffset
+	endOffset := min(file.Lines[endLine-1].NewlineStart, len(file.Content))
+
+	return file.Content[startOffset:endOffset]
+}
+
 // LinkDestination returns the destination URL for a link or image.
 func LinkDestination(n *mdast.Node) string {
 	if n == nil || n.Inline == nil || n.Inline.Link == nil {
@@ -333,6 +358,27 @@ func ExtractHTMLTagName(content []byte) string {
 	return string(bytes.ToLower(content[start:idx]))
 }
 
+// IsJSXTag reports whether content starts with a JSX component tag or fragment
+// as used in MDX: a tag whose name begins with an uppercase letter (<Note>,
+// </Tabs.Item>) or an empty fragment (<>, </>).
+func IsJSXTag(content []byte) bool {
+	content = bytes.TrimSpace(content)
+	if len(content) < 2 || content[0] != '<' {
+		return false
+	}
+
+	idx := 1
+	if content[idx] == '/' {
+		idx++
+	}
+	if idx >= len(content) {
+		return false
+	}
+
+	ch := content[idx]
+	return ch == '>' || (ch >= 'A' && ch <= 'Z')
+}
+
 // Table helpers (GFM).
 
 // Tables returns all table nodes in the document.
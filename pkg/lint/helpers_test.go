@@ -524,3 +524,30 @@ func TestIsBlankLine(t *testing.T) {
 		})
 	}
 }
+
+func TestIsJSXTag(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"<Callout>", true},
+		{"</Callout>", true},
+		{"<Tabs.Item label=\"a\">", true},
+		{"<>", true},
+		{"</>", true},
+		{"  <Chart />", true},
+		{"<div>", false},
+		{"</span>", false},
+		{"<!-- comment -->", false},
+		{"text", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := lint.IsJSXTag([]byte(tt.input)); got != tt.want {
+				t.Errorf("IsJSXTag(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -70,11 +70,34 @@ type PipelineResult struct {
 	// TotalEditsApplied is the total number of edits applied across all passes.
 	TotalEditsApplied int
 
+	// Converted is true if the file is written back as UTF-8 because the
+	// encoding policy converts it (see config.EncodingConvert).
+	Converted bool
+
 	// Exhausted is true if the fix loop hit MaxFixPasses with edits remaining.
 	Exhausted bool
 
 	// RemainingEdits is the count of edits that could not be applied due to exhaustion.
 	RemainingEdits int
+
+	// Cycle is set if the fix loop stopped because its fixes brought the
+	// content back to an earlier state. The content is left in that state
+	// and a RuleFixCycle diagnostic points at the lines the cycle changes.
+	Cycle *FixCycle
+
+	// RemainingRules lists the IDs of the rules still producing edits when
+	// the fix loop stopped early, because of a cycle or exhaustion.
+	RemainingRules []string
+
+	// Passes records the edits of each fix pass: the number applied and
+	// skipped per rule, the conflicts and the edits undoing the previous
+	// pass.
+	Passes []FixPass
+
+	// RenderingRejected lists the IDs of the rules whose fixes were not
+	// applied because they changed the rendered document (see
+	// PipelineOptions.VerifyRendering).
+	RenderingRejected []string
 }
 
 // Summary returns a human-readable summary of the pipeline result.
@@ -115,6 +138,16 @@ type PipelineOptions struct {
 	// ReParseAfterFix re-parses the modified content to validate fixes.
 	ReParseAfterFix bool
 
+	// VerifyRendering renders the content before and after each fix pass
+	// and rejects the fixes that change the rendered document, unless their
+	// rule declares that it does (see FixChangesRendering). The engine's
+	// parser must implement Renderer.
+	VerifyRendering bool
+
+	// Reviewer, if set, chooses the fixes applied in each pass. Fixes it
+	// does not accept are left in the file.
+	Reviewer FixReviewer
+
 	// MaxFixPasses limits the number of fix iterations to prevent infinite loops.
 	// When conflicting edits are skipped, a subsequent pass may be able to fix them.
 	// Set to 0 to use DefaultMaxFixPasses.
@@ -146,17 +179,20 @@ func NewPipeline(engine *Engine) *Pipeline {
 // ProcessFile runs the full safety pipeline for a single file.
 //
 // The pipeline performs the following steps:
-//  1. Read and hash the original file.
+//  1. Read and hash the original file, and decode it to UTF-8 following
+//     the encoding policy; binary files and, by default, files that are
+//     not UTF-8 are skipped with a diagnostic.
 //  2. Multi-pass fix loop (if fix mode enabled):
 //     a. Run the lint engine.
-//     b. If no edits, exit loop.
-//     c. Apply edits in memory.
-//     d. Repeat with modified content until stable or max passes.
+//     b. Optionally reject fixes that change the rendered document.
+//     c. If no edits, or the reviewer accepts none, exit loop.
+//     d. Apply edits in memory, keeping line endings and BOM.
+//     e. Repeat until stable, back at earlier content, or max passes.
 //  3. Optionally re-parse to validate fixes.
 //  4. Generate diff (if dry-run mode).
 //  5. Check for concurrent modifications.
 //  6. Create backup (if enabled).
-//  7. Write the modified content atomically.
+//  7. Write the modified content to the file, keeping its links and owner.
 func (p *Pipeline) ProcessFile(
 	ctx context.Context,
 	path string,
@@ -167,60 +203,40 @@ func (p *Pipeline) ProcessFile(
 		Path: path,
 	}
 
-	// Step 1: Read and hash the original file.
+	// Step 1: Read and hash the original file, and decode it to UTF-8.
 	originalContent, info, err := fsutil.ReadFile(ctx, path)
 	if err != nil {
 		return nil, categorizeError(err)
 	}
 	result.OriginalInfo = info
 
-	// Determine max passes (use default if not set).
-	maxPasses := opts.MaxFixPasses
-	if maxPasses <= 0 {
-		maxPasses = DefaultMaxFixPasses
+	text := decodeFile(path, originalContent, info.Encoding, cfg)
+	text.diagnostics = resolveReported(text.diagnostics, RuleEncoding, cfg)
+	if text.skipReason != "" {
+		result.Skipped = true
+		result.SkipReason = text.skipReason
+		result.FileResult = &FileResult{Diagnostics: text.diagnostics}
+		return result, nil
 	}
 
-	// Working content starts as original.
-	content := originalContent
-	var fileResult *FileResult
-
 	// Step 2: Multi-pass fix loop.
-	for range maxPasses {
-		// Check for cancellation.
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("processing cancelled: %w", ctx.Err())
-		default:
-		}
-
-		// Run the lint engine on current content.
-		var lintErr error
-		fileResult, lintErr = p.Engine.LintFile(ctx, path, content, cfg)
-		if lintErr != nil {
-			return nil, fmt.Errorf("%w: %w", ErrParseFailure, lintErr)
-		}
-
-		// If not in fix mode or no edits available, we're done.
-		if !opts.Fix || len(fileResult.Edits) == 0 {
-			break
-		}
-
-		// Apply edits in memory.
-		content = fix.ApplyEdits(content, fileResult.Edits)
-		result.FixPasses++
-		result.TotalEditsApplied += len(fileResult.Edits)
-		result.Modified = true
+	loopOpts := opts
+	if text.readOnly {
+		loopOpts.Fix = false
 	}
-
-	// Check if we exhausted passes with edits remaining.
-	if result.FixPasses >= maxPasses && len(fileResult.Edits) > 0 {
-		result.Exhausted = true
-		result.RemainingEdits = len(fileResult.Edits)
+	content, err := p.fixLoop(ctx, path, text.content, cfg, loopOpts, result)
+	if err != nil {
+		return nil, err
 	}
 
-	// Store the final lint result.
-	result.FileResult = fileResult
-	result.ModifiedContent = content
+	// A converted file is written as UTF-8 even if no fix changed it.
+	if text.converted && opts.Fix {
+		result.Modified = true
+		result.ModifiedContent = content
+		result.Converted = true
+	} else {
+		result.Diagnostics = append(text.diagnostics, result.Diagnostics...)
+	}
 
 	// If no modifications were made, clear ModifiedContent.
 	if !result.Modified {
@@ -241,9 +257,10 @@ func (p *Pipeline) ProcessFile(
 		}
 	}
 
-	// Step 4: Handle dry-run mode.
+	// Step 4: Handle dry-run mode. The diff of a converted file is against
+	// its decoded text, to show the fixes readably.
 	if opts.DryRun {
-		result.Diff = fix.GenerateDiff(path, originalContent, content)
+		result.Diff = fix.GenerateDiff(path, text.content, content)
 		return result, nil
 	}
 
@@ -267,8 +284,9 @@ func (p *Pipeline) ProcessFile(
 		result.BackupCreated = created
 	}
 
-	// Step 7: Write the modified content atomically.
-	if err := fsutil.WriteAtomic(ctx, path, content, info.Mode); err != nil {
+	// Step 7: Write the modified content, following symlinks and keeping
+	// hard links, ownership and extended attributes.
+	if err := fsutil.ReplaceContent(ctx, path, content); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrWriteFailure, err)
 	}
 	result.Written = true
@@ -290,17 +308,65 @@ func (p *Pipeline) ProcessContent(
 		Path: path,
 	}
 
+	// Multi-pass fix loop.
+	content, err := p.fixLoop(ctx, path, originalContent, cfg, opts, result)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no modifications were made, clear ModifiedContent.
+	if !result.Modified {
+		result.ModifiedContent = nil
+		return result, nil
+	}
+
+	// Optional re-parse to validate fixes.
+	if opts.ReParseAfterFix {
+		_, err := p.Engine.Parser.Parse(ctx, path, content)
+		if err != nil {
+			result.Skipped = true
+			result.SkipReason = fmt.Sprintf("re-parse failed: %v", err)
+			result.Modified = false
+			result.ModifiedContent = nil
+			return result, nil
+		}
+	}
+
+	// Generate diff for review.
+	if opts.DryRun {
+		result.Diff = fix.GenerateDiff(path, originalContent, content)
+	}
+
+	return result, nil
+}
+
+// fixLoop lints content and, in fix mode, applies the fixes and lints
+// again until no fixes are left, the fixes bring the content back to an
+// earlier state, or MaxFixPasses is reached. It records the passes and the
+// final lint result in result and returns the final content.
+func (p *Pipeline) fixLoop(
+	ctx context.Context,
+	path string,
+	content []byte,
+	cfg *config.Config,
+	opts PipelineOptions,
+	result *PipelineResult,
+) ([]byte, error) {
 	// Determine max passes (use default if not set).
 	maxPasses := opts.MaxFixPasses
 	if maxPasses <= 0 {
 		maxPasses = DefaultMaxFixPasses
 	}
 
-	// Working content starts as original.
-	content := originalContent
+	verifier, err := p.renderVerifier(opts)
+	if err != nil {
+		return nil, err
+	}
+	history := newFixHistory(content)
+	style := fix.DetectTextStyle(content)
 	var fileResult *FileResult
+	var previous []appliedEdit
 
-	// Multi-pass fix loop.
 	for range maxPasses {
 		// Check for cancellation.
 		select {
@@ -316,52 +382,106 @@ func (p *Pipeline) ProcessContent(
 			return nil, fmt.Errorf("%w: %w", ErrParseFailure, lintErr)
 		}
 
+		// Drop the fixes that change the rendered document.
+		if verifier != nil && len(fileResult.Edits) > 0 {
+			if err := verifier.verify(ctx, p.Engine.Registry, cfg, content, fileResult); err != nil {
+				return nil, fmt.Errorf("verify rendering: %w", err)
+			}
+		}
+
 		// If not in fix mode or no edits available, we're done.
 		if !opts.Fix || len(fileResult.Edits) == 0 {
 			break
 		}
 
-		// Apply edits in memory.
-		content = fix.ApplyEdits(content, fileResult.Edits)
+		// Keep only the fixes the reviewer accepts.
+		accepted, err := reviewFixes(ctx, opts.Reviewer, path, content, fileResult)
+		if err != nil {
+			return nil, err
+		}
+		if !accepted {
+			break
+		}
+
+		// Apply the edits in memory, keeping the line endings and byte
+		// order mark of the file, and record where the edits as applied
+		// come from.
+		fixed, applied := p.applyEdits(content, fileResult.Edits, &style)
+		var pass FixPass
+		pass, previous = recordFixPass(content, fileResult, applied, previous)
+		result.Passes = append(result.Passes, pass)
+		content = fixed
 		result.FixPasses++
 		result.TotalEditsApplied += len(fileResult.Edits)
 		result.Modified = true
+
+		// Stop if the fixes brought back earlier content: more passes
+		// would only repeat the ones since.
+		if cycle := history.add(content, fileResult, result.Passes); cycle != nil {
+			fileResult = history.results[cycle.start]
+			result.Cycle = cycle
+			result.RemainingRules = cycle.Rules
+			result.TotalEditsApplied -= cycle.edits
+			result.Modified = cycle.start > 0
+			fileResult.Diagnostics = append(fileResult.Diagnostics,
+				resolveReported([]Diagnostic{cycleDiagnostic(path, cycle, p.Engine.Registry)}, RuleFixCycle, cfg)...)
+			break
+		}
 	}
 
 	// Check if we exhausted passes with edits remaining.
-	if result.FixPasses >= maxPasses && len(fileResult.Edits) > 0 {
+	if result.Cycle == nil && result.FixPasses >= maxPasses && len(fileResult.Edits) > 0 {
 		result.Exhausted = true
 		result.RemainingEdits = len(fileResult.Edits)
+		result.RemainingRules = editRules(fileResult.Edits)
 	}
 
 	// Store the final lint result.
 	result.FileResult = fileResult
 	result.ModifiedContent = content
+	result.RenderingRejected = verifier.rejectedRules()
 
-	// If no modifications were made, clear ModifiedContent.
-	if !result.Modified {
-		result.ModifiedContent = nil
-		return result, nil
-	}
+	return content, nil
+}
 
-	// Optional re-parse to validate fixes.
-	if opts.ReParseAfterFix {
-		_, err := p.Engine.Parser.Parse(ctx, path, content)
-		if err != nil {
-			result.Skipped = true
-			result.SkipReason = fmt.Sprintf("re-parse failed: %v", err)
-			result.Modified = false
-			result.ModifiedContent = nil
-			return result, nil
+// applyEdits applies edits to content in style, and returns the result
+// and the edits as applied. If a rule that sets the line endings or byte
+// order mark made some of the edits, they are applied as they are, and
+// style becomes the style of the result.
+func (p *Pipeline) applyEdits(content []byte, edits []fix.TextEdit, style *fix.TextStyle) ([]byte, []fix.TextEdit) {
+	registry := p.Engine.Registry
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+	for _, edit := range edits {
+		if rule, ok := registry.GetByID(edit.Origin.RuleID); ok && FixChangesTextStyle(rule) {
+			content = fix.ApplyEdits(content, edits)
+			*style = fix.DetectTextStyle(content)
+			return content, edits
 		}
 	}
+	return style.ApplyEdits(content, edits), style.StyleEdits(edits)
+}
 
-	// Generate diff for review.
-	if opts.DryRun {
-		result.Diff = fix.GenerateDiff(path, originalContent, content)
-	}
+// FixChangesTextStyle reports whether rule declares that its fixes set the
+// line endings or byte order mark of the file. The fixes of other rules
+// keep the ones the file has.
+func FixChangesTextStyle(rule Rule) bool {
+	declared, ok := rule.(interface{ FixChangesTextStyle() bool })
+	return ok && declared.FixChangesTextStyle()
+}
 
-	return result, nil
+// renderVerifier returns the verifier of the fixes, or nil if they are not
+// verified.
+func (p *Pipeline) renderVerifier(opts PipelineOptions) (*renderVerifier, error) {
+	if !opts.Fix || !opts.VerifyRendering {
+		return nil, nil
+	}
+	verifier, err := newRenderVerifier(p.Engine.Parser)
+	if err != nil {
+		return nil, fmt.Errorf("verify rendering: %w", err)
+	}
+	return verifier, nil
 }
 
 // checkModified checks if a file has been modified since it was read.
@@ -431,5 +551,6 @@ func PipelineOptionsFromConfig(cfg *config.Config) PipelineOptions {
 		Backup:              BackupConfigFromConfig(cfg),
 		StrictRaceDetection: true,
 		ReParseAfterFix:     false,
+		VerifyRendering:     cfg.VerifyFixes,
 	}
 }
@@ -684,3 +684,28 @@ func TestIsPipelineError(t *testing.T) {
 		})
 	}
 }
+
+func TestPipeline_FixKeepsLineEndingsAndBOM(t *testing.T) {
+	t.Parallel()
+
+	result := processWithFix(t, "\ufeffSome foo\r\ntext.\r\n", 0, newReplaceRule("TEST001", "foo", "bar\nbaz"))
+
+	if want := "\ufeffSome bar\r\nbaz\r\ntext.\r\n"; string(result.ModifiedContent) != want {
+		t.Errorf("ModifiedContent = %q, want %q", result.ModifiedContent, want)
+	}
+}
+
+func TestPipeline_FixSetsLineEndings(t *testing.T) {
+	t.Parallel()
+
+	toLF := newReplaceRule("TEST002", "\r\n", "\n")
+	toLF.changesTextStyle = true
+	result := processWithFix(t, "Some foo\r\ntext.\r\n", 0, newReplaceRule("TEST001", "foo", "bar\nbaz"), toLF)
+
+	if want := "Some bar\nbaz\ntext.\n"; string(result.ModifiedContent) != want {
+		t.Errorf("ModifiedContent = %q, want %q", result.ModifiedContent, want)
+	}
+	if result.Cycle != nil || result.FixPasses != 1 {
+		t.Errorf("FixPasses = %d, Cycle = %+v; want 1 pass without a cycle", result.FixPasses, result.Cycle)
+	}
+}
@@ -301,13 +301,18 @@ var refDefPattern = regexp.MustCompile(
 // buildCodeBlockLines returns a set of line numbers that are inside code blocks.
 // These lines should be skipped when scanning for reference definitions.
 func (c *collector) buildCodeBlockLines() map[int]struct{} {
+	return codeBlockLineSet(c.root)
+}
+
+// codeBlockLineSet returns the set of 1-based line numbers covered by code blocks.
+func codeBlockLineSet(root *mdast.Node) map[int]struct{} {
 	lines := make(map[int]struct{})
-	if c.root == nil {
+	if root == nil {
 		return lines
 	}
 
 	//nolint:errcheck // Walk visitor never returns error in this usage
-	mdast.Walk(c.root, func(node *mdast.Node) error {
+	mdast.Walk(root, func(node *mdast.Node) error {
 		if node.Kind == mdast.NodeCodeBlock {
 			pos := node.SourcePosition()
 			if pos.IsValid() {
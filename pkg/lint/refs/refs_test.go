@@ -400,3 +400,52 @@ const resource = {
 		t.Errorf("Expected line 9, got %d", def.LineNumber)
 	}
 }
+
+func TestCollectWikilinks(t *testing.T) {
+	content := []byte("See [[Page]], [[Other#Heading|alias]] and ![[image.png]].\n" +
+		"\n" +
+		"Inline `[[Code]]` is skipped, so is [[ ]].\n" +
+		"\n" +
+		"```\n" +
+		"[[InBlock]]\n" +
+		"```\n" +
+		"\n" +
+		"[[#Local]]\n" +
+		"\n" +
+		"A reference link [[Ref][r]] is not a wikilink, but [[x] [[After]] is.\n")
+
+	file, err := goldmark.New("obsidian").Parse(context.Background(), "test.md", content)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	links := CollectWikilinks(file.Root, file)
+
+	want := []Wikilink{
+		{Raw: "Page", Target: "Page"},
+		{Raw: "Other#Heading|alias", Target: "Other", Fragment: "Heading", Alias: "alias"},
+		{Raw: "image.png", Target: "image.png", Embed: true},
+		{Raw: "#Local", Fragment: "Local"},
+		{Raw: "After", Target: "After"},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("got %d wikilinks, want %d", len(links), len(want))
+	}
+
+	for i, w := range want {
+		got := links[i]
+		if got.Raw != w.Raw || got.Target != w.Target || got.Fragment != w.Fragment ||
+			got.Alias != w.Alias || got.Embed != w.Embed {
+			t.Errorf("link %d = %+v, want %+v", i, *got, w)
+		}
+	}
+
+	embed := links[2]
+	if text := string(content[embed.StartOffset:embed.EndOffset]); text != "![[image.png]]" {
+		t.Errorf("embed offsets cover %q", text)
+	}
+	if embed.Position.StartLine != 1 || embed.Position.StartColumn != 43 || embed.Position.EndColumn != 57 {
+		t.Errorf("embed position = %d:%d-%d, want 1:43-57",
+			embed.Position.StartLine, embed.Position.StartColumn, embed.Position.EndColumn)
+	}
+}
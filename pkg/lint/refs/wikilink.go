@@ -0,0 +1,141 @@
+package refs
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+// Wikilink represents an Obsidian-style wikilink ([[Page]]) or embed (![[file.png]]).
+type Wikilink struct {
+	// Raw is the text between the double brackets as written in the source.
+	Raw string
+
+	// Target is the linked page or file name, without heading, block, or alias.
+	// Empty for same-file links such as [[#Heading]].
+	Target string
+
+	// Fragment is the heading or block reference after '#' (without the '#').
+	Fragment string
+
+	// Alias is the display text after '|', if any.
+	Alias string
+
+	// Embed is true for embeds (![[...]]).
+	Embed bool
+
+	// StartOffset is the byte offset of the opening bracket (or '!' for embeds).
+	StartOffset int
+
+	// EndOffset is the byte offset just after the closing brackets.
+	EndOffset int
+
+	// Position in source.
+	Position mdast.SourcePosition
+}
+
+// CollectWikilinks scans the source for wikilinks and embeds.
+// Wikilinks inside code blocks and code spans are ignored, as are
+// wikilinks that span multiple lines.
+func CollectWikilinks(root *mdast.Node, file *mdast.FileSnapshot) []*Wikilink {
+	if root == nil || file == nil || len(file.Content) == 0 {
+		return nil
+	}
+
+	codeBlockLines := codeBlockLineSet(root)
+	codeSpans := mdast.FindByKind(root, mdast.NodeCodeSpan)
+
+	var links []*Wikilink
+	for lineIdx, lineInfo := range file.Lines {
+		if _, inCodeBlock := codeBlockLines[lineIdx+1]; inCodeBlock {
+			continue
+		}
+
+		line := file.Content[lineInfo.StartOffset:lineInfo.NewlineStart]
+		searchFrom := 0
+		for {
+			open := bytes.Index(line[searchFrom:], []byte("[["))
+			if open < 0 {
+				break
+			}
+			open += searchFrom
+
+			closeIdx := bytes.Index(line[open+2:], []byte("]]"))
+			if closeIdx < 0 {
+				break
+			}
+			closeIdx += open + 2
+
+			// A target with brackets is not a wikilink but, for example,
+			// a bracketed reference link such as [[Page][ref]]. A wikilink
+			// may still start after its first bracket.
+			raw := string(line[open+2 : closeIdx])
+			if strings.ContainsAny(raw, "[]") {
+				searchFrom = open + 1
+				continue
+			}
+
+			start := open
+			embed := open > 0 && line[open-1] == '!'
+			if embed {
+				start--
+			}
+
+			startOffset := lineInfo.StartOffset + start
+			endOffset := lineInfo.StartOffset + closeIdx + 2
+			searchFrom = closeIdx + 2
+
+			if inCodeSpan(codeSpans, startOffset) {
+				continue
+			}
+
+			if strings.TrimSpace(raw) == "" {
+				continue
+			}
+
+			link := parseWikilink(raw)
+			link.Embed = embed
+			link.StartOffset = startOffset
+			link.EndOffset = endOffset
+			link.Position = mdast.SourcePosition{
+				StartLine:   lineIdx + 1,
+				StartColumn: start + 1,
+				EndLine:     lineIdx + 1,
+				EndColumn:   closeIdx + 3,
+			}
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
+
+// parseWikilink splits the inner text of a wikilink into its parts.
+func parseWikilink(raw string) *Wikilink {
+	link := &Wikilink{Raw: raw}
+
+	target := raw
+	if idx := strings.IndexByte(target, '|'); idx >= 0 {
+		link.Alias = strings.TrimSpace(target[idx+1:])
+		target = target[:idx]
+	}
+	if idx := strings.IndexByte(target, '#'); idx >= 0 {
+		link.Fragment = strings.TrimSpace(target[idx+1:])
+		target = target[:idx]
+	}
+	link.Target = strings.TrimSpace(target)
+
+	return link
+}
+
+// inCodeSpan reports whether offset falls inside any of the code span nodes.
+func inCodeSpan(codeSpans []*mdast.Node, offset int) bool {
+	for _, span := range codeSpans {
+		r := span.SourceRange()
+		if r.Contains(offset) {
+			return true
+		}
+	}
+	return false
+}
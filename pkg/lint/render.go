@@ -0,0 +1,230 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+)
+
+// ErrRenderUnsupported indicates that fixes cannot be verified because the
+// engine's parser does not implement Renderer.
+var ErrRenderUnsupported = errors.New("parser cannot render Markdown")
+
+// Renderer renders Markdown content to HTML.
+//
+// Parsers implement it to let the pipeline check that fixes preserve the
+// rendered document (see PipelineOptions.VerifyRendering). The output must
+// use the same configuration the parser uses, and raw HTML must be kept so
+// that changes to it are visible.
+type Renderer interface {
+	Render(ctx context.Context, content []byte) ([]byte, error)
+}
+
+// FixChangesRendering reports whether rule declares that its fixes are meant
+// to change the rendered document, like adding a code block language or
+// turning emphasis into a heading. Fixes of other rules are expected to
+// change only the Markdown source.
+func FixChangesRendering(rule Rule) bool {
+	declared, ok := rule.(interface{ FixChangesRendering() bool })
+	return ok && declared.FixChangesRendering()
+}
+
+// renderVerifier checks the fixes of one file against its rendered output.
+type renderVerifier struct {
+	renderer Renderer
+
+	// rejected holds the rules whose fixes changed the rendered output.
+	// They stay rejected for the remaining passes.
+	rejected map[string]bool
+}
+
+// newRenderVerifier returns a verifier for the engine's parser.
+func newRenderVerifier(parser Parser) (*renderVerifier, error) {
+	renderer, ok := parser.(Renderer)
+	if !ok {
+		return nil, ErrRenderUnsupported
+	}
+	return &renderVerifier{renderer: renderer, rejected: make(map[string]bool)}, nil
+}
+
+// rejectedRules returns the IDs of the rejected rules in sorted order.
+func (v *renderVerifier) rejectedRules() []string {
+	if v == nil || len(v.rejected) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(v.rejected))
+	for id := range v.rejected {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// verify rejects the fixes in fileResult that should not change the rendered
+// output of content but do. The edits of the remaining fixes replace
+// fileResult.Edits.
+func (v *renderVerifier) verify(
+	ctx context.Context,
+	registry *Registry,
+	cfg *config.Config,
+	content []byte,
+	fileResult *FileResult,
+) error {
+	// Group the edits of the fixes that are applied by rule.
+	autoFix := make(map[string]Rule)
+	for _, rr := range ResolveRules(registry, cfg) {
+		if rr.AutoFix {
+			autoFix[rr.Rule.ID()] = rr.Rule
+		}
+	}
+
+	applied := func(diag *Diagnostic) bool {
+		_, ok := autoFix[diag.RuleID]
+		return ok && len(diag.FixEdits) > 0 && diag.Applicability.Enabled(unsafeFixesEnabled(cfg))
+	}
+
+	var ruleIDs []string
+	edits := make(map[string][]fix.TextEdit)
+	for i := range fileResult.Diagnostics {
+		diag := &fileResult.Diagnostics[i]
+		if !applied(diag) {
+			continue
+		}
+		rule := autoFix[diag.RuleID]
+		if _, seen := edits[diag.RuleID]; !seen && !v.rejected[diag.RuleID] && !FixChangesRendering(rule) {
+			ruleIDs = append(ruleIDs, diag.RuleID)
+		}
+		edits[diag.RuleID] = append(edits[diag.RuleID], diag.FixEdits...)
+	}
+
+	rejected, err := v.check(ctx, content, ruleIDs, edits)
+	if err != nil {
+		return err
+	}
+	if len(rejected) == 0 && !hasRejected(v.rejected, edits) {
+		return nil
+	}
+	for _, id := range rejected {
+		v.rejected[id] = true
+	}
+
+	var accepted []fix.TextEdit
+	for i := range fileResult.Diagnostics {
+		diag := &fileResult.Diagnostics[i]
+		if applied(diag) && !v.rejected[diag.RuleID] {
+			accepted = append(accepted, diag.FixEdits...)
+		}
+	}
+	fileResult.setEdits(accepted, len(content))
+	return nil
+}
+
+// check returns the rules among ruleIDs whose edits change the rendered
+// output of content. If only their combination does, all of them are
+// returned.
+func (v *renderVerifier) check(
+	ctx context.Context,
+	content []byte,
+	ruleIDs []string,
+	edits map[string][]fix.TextEdit,
+) ([]string, error) {
+	if len(ruleIDs) == 0 {
+		return nil, nil
+	}
+
+	original, err := v.render(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var combined []fix.TextEdit
+	for _, id := range ruleIDs {
+		combined = append(combined, edits[id]...)
+	}
+	same, err := v.sameRendering(ctx, original, content, combined)
+	if err != nil || same {
+		return nil, err
+	}
+
+	var rejected []string
+	for _, id := range ruleIDs {
+		same, err := v.sameRendering(ctx, original, content, edits[id])
+		if err != nil {
+			return nil, err
+		}
+		if !same {
+			rejected = append(rejected, id)
+		}
+	}
+	if len(rejected) == 0 {
+		return ruleIDs, nil
+	}
+	return rejected, nil
+}
+
+// sameRendering reports whether applying edits to content leaves its
+// rendered output, original, unchanged. Conflicting edits are dropped as
+// the engine drops them.
+func (v *renderVerifier) sameRendering(
+	ctx context.Context,
+	original, content []byte,
+	edits []fix.TextEdit,
+) (bool, error) {
+	prepared, _, _, err := fix.PrepareEditsFiltered(edits, len(content))
+	if err != nil {
+		// Invalid edits are never applied.
+		return true, nil //nolint:nilerr // the engine rejects them too
+	}
+
+	fixed, err := v.render(ctx, fix.ApplyEdits(content, prepared))
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(original, fixed), nil
+}
+
+// render returns the normalized HTML of content.
+func (v *renderVerifier) render(ctx context.Context, content []byte) ([]byte, error) {
+	html, err := v.renderer.Render(ctx, content)
+	if err != nil {
+		return nil, fmt.Errorf("render: %w", err)
+	}
+	return normalizeHTML(html), nil
+}
+
+var (
+	preBlockPattern   = regexp.MustCompile(`(?s)<pre[\s>].*?</pre>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	tagGapPattern     = regexp.MustCompile(`>\s+<`)
+)
+
+// normalizeHTML removes the differences in rendered HTML that readers do not
+// see: whitespace between tags, and runs of whitespace outside preformatted
+// blocks, which collapse to a single space.
+func normalizeHTML(html []byte) []byte {
+	var out bytes.Buffer
+	last := 0
+	for _, loc := range preBlockPattern.FindAllIndex(html, -1) {
+		out.Write(whitespacePattern.ReplaceAll(html[last:loc[0]], []byte(" ")))
+		out.Write(html[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	out.Write(whitespacePattern.ReplaceAll(html[last:], []byte(" ")))
+	return bytes.TrimSpace(tagGapPattern.ReplaceAll(out.Bytes(), []byte("><")))
+}
+
+// hasRejected reports whether any rule with edits was rejected before.
+func hasRejected(rejected map[string]bool, edits map[string][]fix.TextEdit) bool {
+	for id := range edits {
+		if rejected[id] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package lint_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+// replaceRule is a test rule that replaces every occurrence of old with
+// replacement.
+type replaceRule struct {
+	lint.BaseRule
+	old, replacement string
+	changesRendering bool
+	changesTextStyle bool
+}
+
+func newReplaceRule(id, old, replacement string) *replaceRule {
+	return &replaceRule{
+		BaseRule:    lint.NewBaseRule(id, "replace-"+id, "", nil, true),
+		old:         old,
+		replacement: replacement,
+	}
+}
+
+func (r *replaceRule) FixChangesRendering() bool {
+	return r.changesRendering
+}
+
+func (r *replaceRule) FixChangesTextStyle() bool {
+	return r.changesTextStyle
+}
+
+func (r *replaceRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	var diags []lint.Diagnostic
+	content := ctx.File.Content
+	for offset := 0; ; {
+		i := bytes.Index(content[offset:], []byte(r.old))
+		if i < 0 {
+			return diags, nil
+		}
+		start := offset + i
+		offset = start + len(r.old)
+		line, column := ctx.File.LineAt(start)
+		diags = append(diags, lint.Diagnostic{
+			RuleID:      r.ID(),
+			StartLine:   line,
+			StartColumn: column,
+			Message:     "replace " + r.old,
+			FixEdits:    []fix.TextEdit{{StartOffset: start, EndOffset: offset, NewText: r.replacement}},
+		})
+	}
+}
+
+func processWithVerify(t *testing.T, content string, rules ...lint.Rule) *lint.PipelineResult {
+	t.Helper()
+
+	registry := lint.NewRegistry()
+	for _, rule := range rules {
+		registry.Register(rule)
+	}
+	pipeline := lint.NewPipeline(lint.NewEngine(goldmark.New(goldmark.FlavorCommonMark), registry))
+
+	cfg := config.NewConfig()
+	cfg.Fix = true
+	opts := lint.PipelineOptions{Fix: true, VerifyRendering: true}
+
+	result, err := pipeline.ProcessContent(context.Background(), "test.md", []byte(content), cfg, opts)
+	if err != nil {
+		t.Fatalf("ProcessContent() error = %v", err)
+	}
+	return result
+}
+
+func TestPipeline_VerifyRendering_AcceptsNeutralFix(t *testing.T) {
+	t.Parallel()
+
+	result := processWithVerify(t, "Some *emphasis*  and\nmore   text.\n",
+		newReplaceRule("TEST001", "*", "_"),
+		newReplaceRule("TEST002", "   ", " "))
+
+	want := "Some _emphasis_  and\nmore text.\n"
+	if string(result.ModifiedContent) != want {
+		t.Errorf("ModifiedContent = %q, want %q", result.ModifiedContent, want)
+	}
+	if len(result.RenderingRejected) != 0 {
+		t.Errorf("RenderingRejected = %v, want none", result.RenderingRejected)
+	}
+}
+
+func TestPipeline_VerifyRendering_RejectsChangingFix(t *testing.T) {
+	t.Parallel()
+
+	// Removing the trailing spaces removes a hard line break.
+	content := "Line one  \nline two with *emphasis*.\n"
+	result := processWithVerify(t, content,
+		newReplaceRule("TEST001", "  \n", "\n"),
+		newReplaceRule("TEST002", "*", "_"))
+
+	want := "Line one  \nline two with _emphasis_.\n"
+	if string(result.ModifiedContent) != want {
+		t.Errorf("ModifiedContent = %q, want %q", result.ModifiedContent, want)
+	}
+	if !slices.Equal(result.RenderingRejected, []string{"TEST001"}) {
+		t.Errorf("RenderingRejected = %v, want [TEST001]", result.RenderingRejected)
+	}
+	if len(result.Edits) != 0 {
+		t.Errorf("Edits = %v, want none", result.Edits)
+	}
+	if result.Exhausted {
+		t.Error("Exhausted should be false")
+	}
+}
+
+func TestPipeline_VerifyRendering_DeclaredChange(t *testing.T) {
+	t.Parallel()
+
+	rule := newReplaceRule("TEST001", "  \n", "\n")
+	rule.changesRendering = true
+	result := processWithVerify(t, "Line one  \nline two.\n", rule)
+
+	if string(result.ModifiedContent) != "Line one\nline two.\n" {
+		t.Errorf("ModifiedContent = %q, want the fix applied", result.ModifiedContent)
+	}
+	if len(result.RenderingRejected) != 0 {
+		t.Errorf("RenderingRejected = %v, want none", result.RenderingRejected)
+	}
+}
+
+func TestPipeline_VerifyRendering_CodeBlockWhitespace(t *testing.T) {
+	t.Parallel()
+
+	// Whitespace is significant in code blocks.
+	result := processWithVerify(t, "```\na   b\n```\n", newReplaceRule("TEST001", "   ", " "))
+
+	if result.Modified {
+		t.Errorf("ModifiedContent = %q, want no change", result.ModifiedContent)
+	}
+	if !slices.Equal(result.RenderingRejected, []string{"TEST001"}) {
+		t.Errorf("RenderingRejected = %v, want [TEST001]", result.RenderingRejected)
+	}
+}
+
+func TestPipeline_VerifyRendering_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	registry := lint.NewRegistry()
+	pipeline := lint.NewPipeline(lint.NewEngine(&mockParser{}, registry))
+	opts := lint.PipelineOptions{Fix: true, VerifyRendering: true}
+
+	_, err := pipeline.ProcessContent(context.Background(), "test.md", []byte("text\n"), config.NewConfig(), opts)
+	if !errors.Is(err, lint.ErrRenderUnsupported) {
+		t.Errorf("error = %v, want ErrRenderUnsupported", err)
+	}
+}
+
+func TestFixChangesRendering(t *testing.T) {
+	t.Parallel()
+
+	rule := newReplaceRule("TEST001", "a", "b")
+	if lint.FixChangesRendering(rule) {
+		t.Error("FixChangesRendering() = true, want false")
+	}
+	rule.changesRendering = true
+	if !lint.FixChangesRendering(rule) {
+		t.Error("FixChangesRendering() = false, want true")
+	}
+	if lint.FixChangesRendering(&fixableRule{BaseRule: lint.NewBaseRule("TEST002", "test", "", nil, true)}) {
+		t.Error("FixChangesRendering() of BaseRule = true, want false")
+	}
+}
@@ -96,3 +96,63 @@ func resolveRule(rule Rule, cfg *config.Config) ResolvedRule {
 
 	return rr
 }
+
+// pipelineRules maps the IDs of the rules whose diagnostics the pipeline
+// reports itself, rather than a rule in the registry, to their names.
+var pipelineRules = map[string]string{
+	RuleFixCycle: NameFixCycle,
+	RuleEncoding: NameEncoding,
+}
+
+// IsPipelineRule reports whether key, a rule ID or name, names a rule whose
+// diagnostics the pipeline reports itself. Such rules are configured like
+// the rules in the registry.
+func IsPipelineRule(key string) bool {
+	for id, name := range pipelineRules {
+		if key == id || key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveReported applies cfg to diagnostics the pipeline reports for the
+// rule ruleID, as resolveRule does for the rules in the registry: they are
+// dropped if the rule is disabled, and take its severity if one is
+// configured. Otherwise each keeps its own severity. The rule may be named
+// by its ID or its name.
+func resolveReported(diags []Diagnostic, ruleID string, cfg *config.Config) []Diagnostic {
+	if cfg == nil || len(diags) == 0 {
+		return diags
+	}
+	name := pipelineRules[ruleID]
+	matches := func(key string) bool { return key == ruleID || key == name }
+
+	enabled := true
+	var severity *string
+	for _, key := range cfg.DisableRules {
+		if matches(key) {
+			enabled = false
+		}
+	}
+	for key, ruleCfg := range cfg.Rules {
+		if !matches(key) {
+			continue
+		}
+		if ruleCfg.Enabled != nil {
+			enabled = *ruleCfg.Enabled
+		}
+		if ruleCfg.Severity != nil {
+			severity = ruleCfg.Severity
+		}
+	}
+	if !enabled {
+		return nil
+	}
+	if severity != nil {
+		for i := range diags {
+			diags[i].Severity = config.Severity(*severity)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,83 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/yaklabco/gomdlint/pkg/fix"
+)
+
+// FixReviewer chooses which fixes of a fix pass are applied, such as by
+// asking the user about each one (see PipelineOptions.Reviewer).
+type FixReviewer interface {
+	// ReviewFixes returns the edits of fileResult to apply to content, a
+	// subset of fileResult.Edits in the same order. Returning no edits ends
+	// the fix loop for the file.
+	ReviewFixes(ctx context.Context, path string, content []byte, fileResult *FileResult) ([]fix.TextEdit, error)
+}
+
+// reviewFixes replaces the edits of fileResult with the ones reviewer
+// accepts. It returns false if none are accepted.
+func reviewFixes(ctx context.Context, reviewer FixReviewer, path string, content []byte, fileResult *FileResult) (bool, error) {
+	if reviewer == nil {
+		return true, nil
+	}
+	accepted, err := reviewer.ReviewFixes(ctx, path, content, fileResult)
+	if err != nil {
+		return false, fmt.Errorf("review fixes: %w", err)
+	}
+	fileResult.Edits = accepted
+	fileResult.MergedEdits = coveredEdits(fileResult.MergedEdits, accepted)
+	return len(accepted) > 0, nil
+}
+
+// coveredEdits returns the edits that lie within one of the sorted edits
+// of cover, such as the merged deletions of the accepted edits.
+func coveredEdits(edits, cover []fix.TextEdit) []fix.TextEdit {
+	var covered []fix.TextEdit
+	for _, edit := range edits {
+		i := sort.Search(len(cover), func(i int) bool {
+			return cover[i].EndOffset >= edit.EndOffset
+		})
+		if i < len(cover) && cover[i].StartOffset <= edit.StartOffset {
+			covered = append(covered, edit)
+		}
+	}
+	return covered
+}
+
+// FixHunk is a diagnostic together with the edits of its fix that a fix
+// pass applies.
+type FixHunk struct {
+	Diagnostic *Diagnostic
+	Edits      []fix.TextEdit
+}
+
+// FixHunks groups the edits of fileResult by the diagnostic that proposed
+// them, in the order of the edits. Edits without a matching diagnostic
+// form a hunk of their own with a nil Diagnostic.
+func FixHunks(fileResult *FileResult) []FixHunk {
+	var hunks []FixHunk
+	index := make(map[fix.Origin]int)
+	for _, edit := range fileResult.Edits {
+		if i, ok := index[edit.Origin]; ok {
+			hunks[i].Edits = append(hunks[i].Edits, edit)
+			continue
+		}
+		index[edit.Origin] = len(hunks)
+		hunks = append(hunks, FixHunk{Diagnostic: findDiagnostic(fileResult.Diagnostics, edit.Origin), Edits: []fix.TextEdit{edit}})
+	}
+	return hunks
+}
+
+// findDiagnostic returns the diagnostic at origin, or nil.
+func findDiagnostic(diagnostics []Diagnostic, origin fix.Origin) *Diagnostic {
+	for i := range diagnostics {
+		diag := &diagnostics[i]
+		if diag.RuleID == origin.RuleID && diag.StartLine == origin.Line && diag.StartColumn == origin.Column {
+			return diag
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package lint_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+// ruleReviewer accepts the fixes of one rule.
+type ruleReviewer struct {
+	ruleID  string
+	reviews int
+}
+
+func (r *ruleReviewer) ReviewFixes(_ context.Context, _ string, _ []byte, fileResult *lint.FileResult) ([]fix.TextEdit, error) {
+	r.reviews++
+	var accepted []fix.TextEdit
+	for _, hunk := range lint.FixHunks(fileResult) {
+		if hunk.Diagnostic == nil {
+			return nil, nil
+		}
+		if hunk.Diagnostic.RuleID == r.ruleID {
+			accepted = append(accepted, hunk.Edits...)
+		}
+	}
+	return accepted, nil
+}
+
+func TestPipeline_Reviewer(t *testing.T) {
+	t.Parallel()
+
+	registry := lint.NewRegistry()
+	registry.Register(newReplaceRule("TEST001", "foo", "bar"))
+	registry.Register(newReplaceRule("TEST002", "baz", "qux"))
+	pipeline := lint.NewPipeline(lint.NewEngine(goldmark.New(goldmark.FlavorCommonMark), registry))
+
+	cfg := config.NewConfig()
+	cfg.Fix = true
+	reviewer := &ruleReviewer{ruleID: "TEST002"}
+	opts := lint.PipelineOptions{Fix: true, Reviewer: reviewer}
+
+	result, err := pipeline.ProcessContent(context.Background(), "test.md", []byte("foo baz foo baz\n"), cfg, opts)
+	if err != nil {
+		t.Fatalf("ProcessContent() error = %v", err)
+	}
+
+	want := "foo qux foo qux\n"
+	if string(result.ModifiedContent) != want {
+		t.Errorf("ModifiedContent = %q, want %q", result.ModifiedContent, want)
+	}
+	if result.TotalEditsApplied != 2 {
+		t.Errorf("TotalEditsApplied = %d, want 2", result.TotalEditsApplied)
+	}
+	// The second pass accepts nothing, which ends the loop.
+	if reviewer.reviews != 2 {
+		t.Errorf("reviews = %d, want 2", reviewer.reviews)
+	}
+	if result.Exhausted {
+		t.Error("Exhausted should be false")
+	}
+}
+
+func TestPipeline_Reviewer_MergedDeletions(t *testing.T) {
+	t.Parallel()
+
+	registry := lint.NewRegistry()
+	registry.Register(newReplaceRule("TEST001", "abc", ""))
+	registry.Register(newReplaceRule("TEST002", "bcd", ""))
+	pipeline := lint.NewPipeline(lint.NewEngine(goldmark.New(goldmark.FlavorCommonMark), registry))
+
+	cfg := config.NewConfig()
+	cfg.Fix = true
+	reviewer := &ruleReviewer{ruleID: "TEST001"}
+	opts := lint.PipelineOptions{Fix: true, Reviewer: reviewer}
+
+	result, err := pipeline.ProcessContent(context.Background(), "test.md", []byte("with abcd in it\n"), cfg, opts)
+	if err != nil {
+		t.Fatalf("ProcessContent() error = %v", err)
+	}
+
+	// The merged deletion belongs to the diagnostic of its first edit.
+	if want := "with  in it\n"; string(result.ModifiedContent) != want {
+		t.Errorf("ModifiedContent = %q, want %q", result.ModifiedContent, want)
+	}
+	if len(result.Passes) == 0 || result.Passes[0].Rules["TEST002"] != (lint.EditCounts{Applied: 1}) {
+		t.Errorf("Passes = %+v, want TEST002 applied once in the first pass", result.Passes)
+	}
+}
@@ -41,6 +41,10 @@ type Diagnostic struct {
 
 	// FixEdits contains the text edits to fix this issue (may be empty).
 	FixEdits []fix.TextEdit
+
+	// Applicability classifies the fix. Empty means the rule's default
+	// (see DefaultFixApplicability), which the engine fills in.
+	Applicability FixApplicability
 }
 
 // HasFix returns true if this diagnostic has associated fix edits.
@@ -48,6 +52,12 @@ func (d *Diagnostic) HasFix() bool {
 	return len(d.FixEdits) > 0
 }
 
+// AutoFixable returns true if this diagnostic has a fix that fix mode can
+// apply. A fix that is only a suggestion is not.
+func (d *Diagnostic) AutoFixable() bool {
+	return d.HasFix() && d.Applicability != FixSuggestion
+}
+
 // SourcePosition returns the diagnostic position as a SourcePosition.
 func (d *Diagnostic) SourcePosition() mdast.SourcePosition {
 	return mdast.SourcePosition{
@@ -29,6 +29,17 @@ func NewCodeBlockLanguageRule() *CodeBlockLanguageRule {
 	}
 }
 
+// FixApplicability returns lint.FixUnsafe: the language is guessed from the code.
+// A guess below the confidence threshold is only a lint.FixSuggestion.
+func (r *CodeBlockLanguageRule) FixApplicability() lint.FixApplicability {
+	return lint.FixUnsafe
+}
+
+// FixChangesRendering returns true: the language it adds becomes a class on the code element.
+func (r *CodeBlockLanguageRule) FixChangesRendering() bool {
+	return true
+}
+
 // Apply checks that fenced code blocks have an info string.
 func (r *CodeBlockLanguageRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
 	if ctx.Root == nil {
@@ -46,6 +57,8 @@ func (r *CodeBlockLanguageRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic,
 		}
 	}
 
+	detector, minConfidence := languageDetector(ctx)
+
 	codeBlocks := ctx.CodeBlocks()
 	var diags []lint.Diagnostic
 
@@ -75,8 +88,8 @@ func (r *CodeBlockLanguageRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic,
 
 			// Add autofix if file is available.
 			if ctx.File != nil {
-				if fixer := r.buildLanguageFix(ctx.File, cb); fixer != nil {
-					diagBuilder = diagBuilder.WithFix(fixer)
+				if fixer, applicability := r.buildLanguageFix(ctx.File, cb, detector, minConfidence); fixer != nil {
+					diagBuilder = diagBuilder.WithFix(fixer).WithApplicability(applicability)
 				}
 			}
 
@@ -98,21 +111,49 @@ func (r *CodeBlockLanguageRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic,
 	return diags, nil
 }
 
-// buildLanguageFix detects the language and creates a fix to insert it.
+// languageDetector builds the detector and confidence threshold from the
+// langdetect section of the configuration.
+func languageDetector(ctx *lint.RuleContext) (*langdetect.Detector, float64) {
+	if ctx.Config == nil {
+		return langdetect.New(langdetect.Options{}), langdetect.DefaultMinConfidence
+	}
+
+	cfg := ctx.Config.LangDetect
+	minConfidence := langdetect.DefaultMinConfidence
+	if cfg.MinConfidence != nil {
+		minConfidence = *cfg.MinConfidence
+	}
+
+	return langdetect.New(langdetect.Options{
+		Candidates: cfg.Candidates,
+		Aliases:    cfg.Aliases,
+	}), minConfidence
+}
+
+// buildLanguageFix detects the language and creates a fix to insert it,
+// with its applicability. A detection below minConfidence is only
+// suggested, and no fix is proposed when nothing is detected.
 func (r *CodeBlockLanguageRule) buildLanguageFix(
 	file *mdast.FileSnapshot,
 	cb *mdast.Node,
-) *fix.EditBuilder {
+	detector *langdetect.Detector,
+	minConfidence float64,
+) (*fix.EditBuilder, lint.FixApplicability) {
 	// Get code block content for detection.
-	content := r.getCodeBlockContent(file, cb)
+	content := lint.CodeBlockContent(file, cb)
 	if len(content) == 0 {
-		return nil
+		return nil, ""
 	}
 
 	// Detect language.
-	detectedLang := langdetect.Detect(content)
-	if detectedLang == "text" {
-		return nil // Don't insert "text" as language.
+	result := detector.Detect(content)
+	if result.Language == "text" {
+		return nil, "" // Don't insert "text".
+	}
+	detectedLang := result.Language
+	applicability := lint.FixUnsafe
+	if result.Confidence < minConfidence {
+		applicability = lint.FixSuggestion
 	}
 
 	// Find position right after opening fence.
@@ -120,7 +161,7 @@ func (r *CodeBlockLanguageRule) buildLanguageFix(
 	pos := cb.SourcePosition()
 	fenceLine := pos.StartLine - 1
 	if !pos.IsValid() || fenceLine < 1 || fenceLine > len(file.Lines) {
-		return nil
+		return nil, ""
 	}
 
 	fenceLineInfo := file.Lines[fenceLine-1]
@@ -137,44 +178,13 @@ func (r *CodeBlockLanguageRule) buildLanguageFix(
 	}
 
 	if fenceEnd == 0 {
-		return nil
+		return nil, ""
 	}
 
 	// Insert language right after fence.
 	builder := fix.NewEditBuilder()
 	builder.Insert(fenceLineInfo.StartOffset+fenceEnd, detectedLang)
-	return builder
-}
-
-// getCodeBlockContent extracts the content of a code block (excluding fences).
-// Note: For fenced code blocks, pos.StartLine points to the first content line
-// (not the opening fence), and pos.EndLine includes the closing fence.
-func (r *CodeBlockLanguageRule) getCodeBlockContent(
-	file *mdast.FileSnapshot,
-	cb *mdast.Node,
-) []byte {
-	pos := cb.SourcePosition()
-	if !pos.IsValid() {
-		return nil
-	}
-
-	// StartLine is already the first content line.
-	// EndLine includes the closing fence, so we skip it.
-	startLine := pos.StartLine
-	endLine := pos.EndLine - 1
-
-	if startLine > endLine || startLine < 1 || endLine > len(file.Lines) {
-		return nil
-	}
-
-	startOffset := file.Lines[startLine-1].StartOffset
-	endOffset := file.Lines[endLine-1].NewlineStart
-
-	if endOffset > len(file.Content) {
-		endOffset = len(file.Content)
-	}
-
-	return file.Content[startOffset:endOffset]
+	return builder, applicability
 }
 
 // CodeBlockStyleRule enforces consistent code block style (fenced vs indented).
@@ -234,6 +244,12 @@ func (r *CodeBlockStyleRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, er
 			detectedStyle = CodeBlockIndented
 		}
 
+		// MkDocs admonition and tab bodies parse as indented code in CommonMark.
+		if detectedStyle == CodeBlockIndented && ctx.File != nil && flavorIs(ctx, config.FlavorMkDocs) &&
+			isMkDocsContainerBody(ctx.File, cb.SourcePosition().StartLine) {
+			continue
+		}
+
 		// Set consistent style from first code block.
 		if effectiveStyle == "" {
 			effectiveStyle = detectedStyle
@@ -378,6 +394,11 @@ func NewCommandsShowOutputRule() *CommandsShowOutputRule {
 	}
 }
 
+// FixChangesRendering returns true: removing the dollar signs changes the code shown.
+func (r *CommandsShowOutputRule) FixChangesRendering() bool {
+	return true
+}
+
 // Apply checks for unnecessary dollar signs in code blocks.
 func (r *CommandsShowOutputRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
 	if ctx.Root == nil || ctx.File == nil {
@@ -150,6 +150,11 @@ func NewNoSpaceInCodeRule() *NoSpaceInCodeRule {
 	}
 }
 
+// FixChangesRendering returns true: the spaces removed are part of the code span.
+func (r *NoSpaceInCodeRule) FixChangesRendering() bool {
+	return true
+}
+
 // codeSpanPattern matches inline code spans with their content.
 var codeSpanPattern = regexp.MustCompile("`+[^`]+`+")
 
@@ -210,6 +210,86 @@ func TestCodeBlockLanguageRule_AllowedLanguages(t *testing.T) {
 	}
 }
 
+func TestCodeBlockLanguageRule_LangDetectConfig(t *testing.T) {
+	high := 0.95
+	tests := []struct {
+		name          string
+		input         string
+		langDetect    config.LangDetectConfig
+		wantFixedLang string // Empty means no fix expected
+
+		// wantSuggestion is true if the fix is only a suggestion.
+		wantSuggestion bool
+	}{
+		{
+			name:          "default config",
+			input:         "```\nfunc add(a, b int) int {\n\treturn a + b\n}\n```",
+			wantFixedLang: "go",
+		},
+		{
+			name:           "min confidence makes the fix a suggestion",
+			input:          "```\nx := 1\n```",
+			langDetect:     config.LangDetectConfig{MinConfidence: &high},
+			wantFixedLang:  "go",
+			wantSuggestion: true,
+		},
+		{
+			name:          "alias applied",
+			input:         "```\nfrom os import path\n```",
+			langDetect:    config.LangDetectConfig{Aliases: map[string]string{"python": "py"}},
+			wantFixedLang: "py",
+		},
+		{
+			name:       "language not in candidates",
+			input:      "```\nfrom os import path\n```",
+			langDetect: config.LangDetectConfig{Candidates: []string{"go", "json"}},
+		},
+		{
+			name:          "console transcript",
+			input:         "```\n$ go version\ngo version go1.24.0 linux/amd64\n```",
+			wantFixedLang: "console",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := goldmark.New(string(config.FlavorCommonMark))
+			snapshot, err := parser.Parse(context.Background(), "test.md", []byte(tt.input))
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			cfg := config.NewConfig()
+			cfg.LangDetect = tt.langDetect
+
+			rule := NewCodeBlockLanguageRule()
+			diags, err := rule.Apply(lint.NewRuleContext(context.Background(), snapshot, cfg, nil))
+			if err != nil {
+				t.Fatalf("Apply error: %v", err)
+			}
+			if len(diags) != 1 {
+				t.Fatalf("got %d diagnostics, want 1", len(diags))
+			}
+
+			if tt.wantFixedLang == "" {
+				if len(diags[0].FixEdits) > 0 {
+					t.Errorf("unexpected fix %q", diags[0].FixEdits[0].NewText)
+				}
+				return
+			}
+			if len(diags[0].FixEdits) == 0 {
+				t.Fatal("expected a fix")
+			}
+			if got := diags[0].FixEdits[0].NewText; got != tt.wantFixedLang {
+				t.Errorf("fix text = %q, want %q", got, tt.wantFixedLang)
+			}
+			if got := diags[0].Applicability == lint.FixSuggestion; got != tt.wantSuggestion {
+				t.Errorf("Applicability = %q, want suggestion %v", diags[0].Applicability, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
 func TestCodeBlockStyleRule(t *testing.T) {
 	tests := []struct {
 		name  string
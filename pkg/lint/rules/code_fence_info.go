@@ -0,0 +1,376 @@
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/langdetect"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+// CodeFenceInfoRule normalizes code fence info strings: languages are mapped
+// through an alias table to canonical names, unknown languages are reported
+// against the Linguist language list, and trailing attributes are validated.
+type CodeFenceInfoRule struct {
+	lint.BaseRule
+}
+
+// NewCodeFenceInfoRule creates a new code fence info string rule.
+func NewCodeFenceInfoRule() *CodeFenceInfoRule {
+	return &CodeFenceInfoRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL011",
+			"code-fence-info",
+			"Code fence info strings should use canonical, known languages and valid attributes",
+			[]string{"code"},
+			true, // Auto-fixable by rewriting the language.
+		),
+	}
+}
+
+// FixChangesRendering returns true: the rewritten language changes the class of the code element.
+func (r *CodeFenceInfoRule) FixChangesRendering() bool {
+	return true
+}
+
+// DefaultEnabled returns false - this rule is opt-in.
+func (r *CodeFenceInfoRule) DefaultEnabled() bool {
+	return false
+}
+
+// defaultKnownLanguages are accepted in addition to the Linguist list.
+//
+//nolint:gochecknoglobals // Read-only default option value.
+var defaultKnownLanguages = []string{"plaintext", "math"}
+
+// Apply checks the info string of every fenced code block.
+func (r *CodeFenceInfoRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if ctx.Root == nil || ctx.File == nil {
+		return nil, nil
+	}
+
+	aliases := optionStringMap(ctx.Option("aliases", nil))
+	checkUnknown := ctx.OptionBool("check_unknown", true)
+	checkAttributes := ctx.OptionBool("check_attributes", true)
+	known := make(map[string]bool)
+	for _, lang := range ctx.OptionStringSlice("known_languages", defaultKnownLanguages) {
+		known[strings.ToLower(lang)] = true
+	}
+	for _, target := range aliases {
+		known[target] = true
+	}
+	var allowedAttrs map[string]bool
+	if attrs := ctx.OptionStringSlice("allowed_attributes", nil); len(attrs) > 0 {
+		allowedAttrs = make(map[string]bool, len(attrs))
+		for _, a := range attrs {
+			allowedAttrs[a] = true
+		}
+	}
+
+	infoTokens := make(map[int]mdast.Token)
+	for _, tok := range ctx.File.Tokens {
+		if tok.Kind == mdast.TokCodeFenceInfo {
+			line, _ := ctx.File.LineAt(tok.StartOffset)
+			infoTokens[line] = tok
+		}
+	}
+
+	var diags []lint.Diagnostic
+	for _, cb := range ctx.CodeBlocks() {
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+
+		info := lint.CodeBlockInfo(cb)
+		if !lint.IsFencedCodeBlock(cb) || info == "" {
+			continue
+		}
+
+		start, end, ok := fenceInfoSpan(ctx.File, cb, infoTokens)
+		if !ok || strings.TrimSpace(string(ctx.File.Content[start:end])) != info {
+			continue
+		}
+		span := ctx.File.Content[start:end]
+
+		// The language is the first word, unless the info string starts with
+		// an attribute block ("{.python}").
+		langStart := len(span) - len(bytes.TrimLeft(span, " \t"))
+		langEnd := langStart
+		for langEnd < len(span) && !isInfoSeparator(span[langEnd]) {
+			langEnd++
+		}
+		lang := string(span[langStart:langEnd])
+
+		if lang != "" {
+			canonical := canonicalFenceLanguage(lang, aliases)
+			switch {
+			case canonical != lang:
+				builder := fix.NewEditBuilder()
+				builder.ReplaceRange(start+langStart, start+langEnd, canonical)
+				diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, spanPosition(ctx.File, start+langStart, start+langEnd),
+					fmt.Sprintf("Code fence language '%s' should be '%s'", lang, canonical)).
+					WithSeverity(config.SeverityWarning).
+					WithSuggestion(fmt.Sprintf("Use '%s' as the language", canonical)).
+					WithFix(builder).
+					Build()
+				diags = append(diags, diag)
+			case checkUnknown && !isKnownLanguage(lang, known):
+				diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, spanPosition(ctx.File, start+langStart, start+langEnd),
+					fmt.Sprintf("Unknown code fence language '%s'", lang)).
+					WithSeverity(config.SeverityWarning).
+					WithSuggestion("Use a language name or alias from the Linguist language list").
+					Build()
+				diags = append(diags, diag)
+			}
+		}
+
+		if !checkAttributes {
+			continue
+		}
+
+		attrStart := start + langEnd
+		attrs, err := parseFenceAttributes(string(span[langEnd:]))
+		if err != nil {
+			diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, spanPosition(ctx.File, attrStart, end),
+				fmt.Sprintf("Invalid code fence attributes: %v", err)).
+				WithSeverity(config.SeverityWarning).
+				WithSuggestion(`Use key="value" pairs or a {...} attribute block`).
+				Build()
+			diags = append(diags, diag)
+			continue
+		}
+
+		if allowedAttrs == nil {
+			continue
+		}
+		for _, attr := range attrs {
+			if attr.name == "" || allowedAttrs[attr.name] {
+				continue
+			}
+			pos := spanPosition(ctx.File, attrStart+attr.offset, attrStart+attr.offset+len(attr.name))
+			diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos,
+				fmt.Sprintf("Code fence attribute '%s' is not allowed", attr.name)).
+				WithSeverity(config.SeverityWarning).
+				WithSuggestion("Remove the attribute or add it to allowed_attributes").
+				Build()
+			diags = append(diags, diag)
+		}
+	}
+
+	return diags, nil
+}
+
+// fenceInfoSpan returns the byte range of the info string on the opening
+// fence line, preferring the tokenizer's TokCodeFenceInfo span.
+func fenceInfoSpan(file *mdast.FileSnapshot, cb *mdast.Node, infoTokens map[int]mdast.Token) (int, int, bool) {
+	pos := cb.SourcePosition()
+	fenceLine := pos.StartLine - 1
+	if !pos.IsValid() || fenceLine < 1 || fenceLine > len(file.Lines) {
+		return 0, 0, false
+	}
+
+	if tok, ok := infoTokens[fenceLine]; ok {
+		return tok.StartOffset, tok.EndOffset, true
+	}
+
+	// Fences inside blockquotes or on a list marker line are not tokenized
+	// as fences; locate the info string on the line instead.
+	lineStart := file.Lines[fenceLine-1].StartOffset
+	line := file.LineContent(fenceLine)
+	fenceChar := lint.CodeFenceChar(cb)
+	idx := bytes.IndexByte(line, fenceChar)
+	if idx < 0 {
+		return 0, 0, false
+	}
+	for idx < len(line) && line[idx] == fenceChar {
+		idx++
+	}
+	return lineStart + idx, lineStart + len(line), true
+}
+
+// spanPosition converts a byte range on a single line to a source position.
+func spanPosition(file *mdast.FileSnapshot, start, end int) mdast.SourcePosition {
+	line, col := file.LineAt(start)
+	return mdast.SourcePosition{
+		StartLine:   line,
+		StartColumn: col,
+		EndLine:     line,
+		EndColumn:   col + max(end-start, 1),
+	}
+}
+
+// isInfoSeparator reports whether c ends the language word of an info string.
+func isInfoSeparator(c byte) bool {
+	return c == ' ' || c == '\t' || c == '{'
+}
+
+// canonicalFenceLanguage maps a language through the configured aliases and
+// the built-in alias table.
+func canonicalFenceLanguage(lang string, aliases map[string]string) string {
+	lower := strings.ToLower(lang)
+	if alias, ok := aliases[lower]; ok {
+		return alias
+	}
+
+	canonical := langdetect.Canonical(lower)
+	if alias, ok := aliases[canonical]; ok {
+		return alias
+	}
+	if isKnownLanguage(canonical, nil) {
+		return canonical
+	}
+	return lang
+}
+
+// isKnownLanguage reports whether lang is a Linguist language name, alias or
+// file extension, or one of the extra known languages.
+func isKnownLanguage(lang string, extra map[string]bool) bool {
+	lower := strings.ToLower(lang)
+	if extra[lower] {
+		return true
+	}
+	if _, ok := enry.GetLanguageByAlias(lower); ok {
+		return true
+	}
+	return len(enry.GetLanguagesByExtension("file."+lower, nil, nil)) > 0
+}
+
+// optionStringMap converts a map option to map[string]string with lowercase keys.
+func optionStringMap(value any) map[string]string {
+	result := make(map[string]string)
+	switch m := value.(type) {
+	case map[string]any:
+		for k, v := range m {
+			if s, ok := v.(string); ok {
+				result[strings.ToLower(k)] = s
+			}
+		}
+	case map[string]string:
+		for k, v := range m {
+			result[strings.ToLower(k)] = v
+		}
+	}
+	return result
+}
+
+// fenceAttribute is a named attribute or flag in an info string.
+type fenceAttribute struct {
+	name   string // attribute key or flag; empty for classes, ids and line ranges
+	offset int    // byte offset of the attribute within the parsed text
+}
+
+// Attribute parsing errors.
+var (
+	errUnclosedBrace     = errors.New("unclosed '{'")
+	errUnexpectedBrace   = errors.New("unexpected '}'")
+	errUnterminatedQuote = errors.New("unterminated quoted value")
+)
+
+// parseFenceAttributes parses the part of an info string after the language:
+// key=value pairs (values optionally quoted), bare flags, and {...} blocks
+// containing .classes, #ids, key=value pairs, flags and line ranges.
+func parseFenceAttributes(text string) ([]fenceAttribute, error) {
+	var attrs []fenceAttribute
+	inBlock := false
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || (inBlock && c == ','):
+			i++
+		case c == '{':
+			if inBlock {
+				return nil, errors.New("nested '{'")
+			}
+			inBlock = true
+			i++
+		case c == '}':
+			if !inBlock {
+				return nil, errUnexpectedBrace
+			}
+			inBlock = false
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(text[i+1:], c)
+			if end < 0 {
+				return nil, errUnterminatedQuote
+			}
+			i += end + 2
+		default:
+			start := i
+			for i < len(text) && !isAttributeDelimiter(text[i], inBlock) {
+				i++
+			}
+			word := text[start:i]
+
+			if i < len(text) && text[i] == '=' {
+				i++
+				next, err := skipAttributeValue(text, i, inBlock)
+				if err != nil {
+					return nil, err
+				}
+				if next == i {
+					return nil, fmt.Errorf("missing value for '%s'", word)
+				}
+				i = next
+			}
+
+			name := word
+			if strings.HasPrefix(word, ".") || strings.HasPrefix(word, "#") || isLineRange(word) {
+				name = ""
+			}
+			attrs = append(attrs, fenceAttribute{name: name, offset: start})
+		}
+	}
+
+	if inBlock {
+		return nil, errUnclosedBrace
+	}
+	return attrs, nil
+}
+
+// skipAttributeValue returns the offset just past the value starting at i.
+func skipAttributeValue(text string, i int, inBlock bool) (int, error) {
+	if i < len(text) && (text[i] == '"' || text[i] == '\'') {
+		end := strings.IndexByte(text[i+1:], text[i])
+		if end < 0 {
+			return 0, errUnterminatedQuote
+		}
+		return i + end + 2, nil
+	}
+	for i < len(text) && !isAttributeDelimiter(text[i], inBlock) {
+		i++
+	}
+	return i, nil
+}
+
+// isAttributeDelimiter reports whether c ends an attribute key or bare value.
+func isAttributeDelimiter(c byte, inBlock bool) bool {
+	switch c {
+	case ' ', '\t', '=', '{', '}', '"', '\'':
+		return true
+	case ',':
+		return inBlock
+	}
+	return false
+}
+
+// isLineRange reports whether word is a line number or range such as "3-5".
+func isLineRange(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, c := range word {
+		if (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+)
+
+func TestCodeFenceInfoRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		options     map[string]any
+		wantDiags   int
+		wantMessage string
+		wantCol     int
+		wantFix     string
+	}{
+		{
+			name:      "canonical language",
+			input:     "```go\nx := 1\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:        "yml alias",
+			input:       "```yml\na: 1\n```\n",
+			wantDiags:   1,
+			wantMessage: "Code fence language 'yml' should be 'yaml'",
+			wantCol:     4,
+			wantFix:     "```yaml\na: 1\n```\n",
+		},
+		{
+			name:      "sh alias keeps attributes",
+			input:     "~~~ sh title=\"install.sh\"\nmake\n~~~\n",
+			wantDiags: 1,
+			wantCol:   5,
+			wantFix:   "~~~ bash title=\"install.sh\"\nmake\n~~~\n",
+		},
+		{
+			name:      "uppercase language",
+			input:     "```JSON\n{}\n```\n",
+			wantDiags: 1,
+			wantFix:   "```json\n{}\n```\n",
+		},
+		{
+			name:      "console is canonical",
+			input:     "```console\n$ ls\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "configured alias",
+			input:     "```bash\nls\n```\n\n```shell\nls\n```\n",
+			options:   map[string]any{"aliases": map[string]any{"bash": "sh"}},
+			wantDiags: 2,
+			wantFix:   "```sh\nls\n```\n\n```sh\nls\n```\n",
+		},
+		{
+			name:      "blockquote fence",
+			input:     "> ```js\n> let x = 1\n> ```\n",
+			wantDiags: 1,
+			wantCol:   6,
+			wantFix:   "> ```javascript\n> let x = 1\n> ```\n",
+		},
+		{
+			name:        "unknown language",
+			input:       "```foobar\ncode\n```\n",
+			wantDiags:   1,
+			wantMessage: "Unknown code fence language 'foobar'",
+		},
+		{
+			name:      "unknown language allowed by option",
+			input:     "```foobar\ncode\n```\n",
+			options:   map[string]any{"known_languages": []any{"foobar"}},
+			wantDiags: 0,
+		},
+		{
+			name:      "unknown language check disabled",
+			input:     "```foobar\ncode\n```\n",
+			options:   map[string]any{"check_unknown": false},
+			wantDiags: 0,
+		},
+		{
+			name:      "linguist names and extensions",
+			input:     "```mermaid\ngraph TD\n```\n\n```jsx\n<A />\n```\n\n```text\nx\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "valid attributes",
+			input:     "```python {linenos, .numbered #main hl_lines=\"1 3\"} title='a b'\npass\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "line ranges",
+			input:     "```go {1,3-5}\nx := 1\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "pandoc attribute block without language",
+			input:     "```{.python}\npass\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:        "unterminated quote",
+			input:       "```go title=\"main.go\nx := 1\n```\n",
+			wantDiags:   1,
+			wantMessage: "Invalid code fence attributes: unterminated quoted value",
+			wantCol:     6,
+		},
+		{
+			name:        "unclosed brace",
+			input:       "```go {linenos\nx := 1\n```\n",
+			wantDiags:   1,
+			wantMessage: "Invalid code fence attributes: unclosed '{'",
+		},
+		{
+			name:        "missing value",
+			input:       "```go title=\nx := 1\n```\n",
+			wantDiags:   1,
+			wantMessage: "Invalid code fence attributes: missing value for 'title'",
+		},
+		{
+			name:        "attribute not allowed",
+			input:       "```go title=\"x\" {linenos}\nx := 1\n```\n",
+			options:     map[string]any{"allowed_attributes": []any{"title"}},
+			wantDiags:   1,
+			wantMessage: "Code fence attribute 'linenos' is not allowed",
+			wantCol:     18,
+		},
+		{
+			name:      "no info string",
+			input:     "```\ncode\n```\n",
+			wantDiags: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ruleCfg *config.RuleConfig
+			if tt.options != nil {
+				ruleCfg = &config.RuleConfig{Options: tt.options}
+			}
+
+			diags := applyFlavorRule(t, NewCodeFenceInfoRule(), config.FlavorCommonMark, "test.md", tt.input, ruleCfg)
+			require.Len(t, diags, tt.wantDiags)
+			if tt.wantDiags == 0 {
+				return
+			}
+
+			if tt.wantMessage != "" {
+				assert.Equal(t, tt.wantMessage, diags[0].Message)
+			}
+			if tt.wantCol != 0 {
+				assert.Equal(t, tt.wantCol, diags[0].StartColumn)
+			}
+
+			var allEdits []fix.TextEdit
+			for _, d := range diags {
+				allEdits = append(allEdits, d.FixEdits...)
+			}
+			if tt.wantFix == "" {
+				assert.Empty(t, allEdits)
+				return
+			}
+
+			prepared, err := fix.PrepareEdits(allEdits, len(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFix, string(fix.ApplyEdits([]byte(tt.input), prepared)))
+		})
+	}
+}
+
+func TestParseFenceAttributes(t *testing.T) {
+	attrs, err := parseFenceAttributes(` title="a b" {linenos .cls #id 2-4 start=3} hl`)
+	require.NoError(t, err)
+
+	var names []string
+	for _, a := range attrs {
+		names = append(names, a.name)
+	}
+	assert.Equal(t, []string{"title", "linenos", "", "", "", "start", "hl"}, names)
+	assert.Equal(t, 1, attrs[0].offset)
+
+	_, err = parseFenceAttributes("}")
+	require.ErrorIs(t, err, errUnexpectedBrace)
+}
@@ -0,0 +1,286 @@
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/codecheck"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+// CodeBlockSyntaxRule checks that fenced code blocks in supported languages
+// (JSON, YAML, TOML, Go) parse without syntax errors.
+type CodeBlockSyntaxRule struct {
+	lint.BaseRule
+}
+
+// NewCodeBlockSyntaxRule creates a new code block syntax rule.
+func NewCodeBlockSyntaxRule() *CodeBlockSyntaxRule {
+	return &CodeBlockSyntaxRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL009",
+			"code-block-syntax",
+			"Fenced code blocks should contain valid code for their language",
+			[]string{"code"},
+			false, // Not auto-fixable.
+		),
+	}
+}
+
+// DefaultEnabled returns false - this rule is opt-in, and enabled as an
+// error by the strict pack.
+func (r *CodeBlockSyntaxRule) DefaultEnabled() bool {
+	return false
+}
+
+// Apply parses each supported fenced code block and reports the first syntax
+// error at its position in the Markdown file.
+func (r *CodeBlockSyntaxRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if ctx.Root == nil || ctx.File == nil {
+		return nil, nil
+	}
+
+	languages := languageSet(ctx.OptionStringSlice("languages", codecheck.Languages()))
+
+	var diags []lint.Diagnostic
+	for _, cb := range ctx.CodeBlocks() {
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+
+		lang := fencedCodeLanguage(cb)
+		if lang == "" || !languages[lang] {
+			continue
+		}
+
+		body := newFencedCodeBody(ctx.File, cb)
+		if body == nil {
+			continue
+		}
+
+		var synErr *codecheck.SyntaxError
+		if err := codecheck.Check(lang, body.text); !errors.As(err, &synErr) {
+			continue
+		}
+
+		pos := body.position(synErr.Line, synErr.Column)
+		diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos,
+			fmt.Sprintf("Invalid %s in code block: %s", codecheck.DisplayName(lang), synErr.Message)).
+			WithSeverity(config.SeverityError).
+			WithSuggestion("Fix the syntax error or change the code block language").
+			Build()
+		diags = append(diags, diag)
+	}
+
+	return diags, nil
+}
+
+// CodeBlockFormatRule checks that Go and JSON code blocks are formatted in
+// their canonical style, and reformats them in place when fixing.
+type CodeBlockFormatRule struct {
+	lint.BaseRule
+}
+
+// NewCodeBlockFormatRule creates a new code block format rule.
+func NewCodeBlockFormatRule() *CodeBlockFormatRule {
+	return &CodeBlockFormatRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL010",
+			"code-block-format",
+			"Go and JSON code blocks should be formatted (gofmt, indented JSON)",
+			[]string{"code"},
+			true, // Auto-fixable by reformatting.
+		),
+	}
+}
+
+// FixChangesRendering returns true: reformatting changes the code shown.
+func (r *CodeBlockFormatRule) FixChangesRendering() bool {
+	return true
+}
+
+// DefaultEnabled returns false - this rule is opt-in.
+func (r *CodeBlockFormatRule) DefaultEnabled() bool {
+	return false
+}
+
+// Apply reports code blocks whose content differs from its formatted form.
+// Blocks with syntax errors are left to MDL009.
+func (r *CodeBlockFormatRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if ctx.Root == nil || ctx.File == nil {
+		return nil, nil
+	}
+
+	languages := languageSet(ctx.OptionStringSlice("languages", []string{codecheck.LangGo, codecheck.LangJSON}))
+	opts := codecheck.FormatOptions{
+		JSONIndent: strings.Repeat(" ", ctx.OptionInt("json_indent", 2)),
+	}
+
+	var diags []lint.Diagnostic
+	for _, cb := range ctx.CodeBlocks() {
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+
+		lang := fencedCodeLanguage(cb)
+		if lang == "" || !languages[lang] || !codecheck.CanFormat(lang) {
+			continue
+		}
+
+		body := newFencedCodeBody(ctx.File, cb)
+		if body == nil {
+			continue
+		}
+
+		formatted, err := codecheck.Format(lang, body.text, opts)
+		if err != nil || bytes.Equal(formatted, body.text) {
+			continue
+		}
+
+		diag := lint.NewDiagnostic(r.ID(), cb,
+			fmt.Sprintf("%s code block is not formatted", codecheck.DisplayName(lang))).
+			WithSeverity(config.SeverityWarning).
+			WithSuggestion("Reformat the code block").
+			WithFix(body.replaceWith(formatted)).
+			Build()
+		diags = append(diags, diag)
+	}
+
+	return diags, nil
+}
+
+// languageSet canonicalizes a configured list of languages.
+func languageSet(langs []string) map[string]bool {
+	set := make(map[string]bool, len(langs))
+	for _, l := range langs {
+		if c := codecheck.Canonical(l); c != "" {
+			set[c] = true
+		}
+	}
+	return set
+}
+
+// fencedCodeLanguage returns the canonical codecheck language of a fenced
+// code block, or "" if the block is indented or its language is unsupported.
+func fencedCodeLanguage(cb *mdast.Node) string {
+	if !lint.IsFencedCodeBlock(cb) {
+		return ""
+	}
+	fields := strings.Fields(lint.CodeBlockInfo(cb))
+	if len(fields) == 0 {
+		return ""
+	}
+	return codecheck.Canonical(fields[0])
+}
+
+// fencedCodeLine is one content line of a fenced code block.
+type fencedCodeLine struct {
+	lineNum     int // 1-based line number in the Markdown file
+	startOffset int // byte offset of the line start in the file
+	prefixLen   int // bytes of container prefix (indentation, "> ") before the code
+}
+
+// fencedCodeBody is the content of a fenced code block with container
+// prefixes removed, plus the mapping back to file positions.
+type fencedCodeBody struct {
+	text       []byte
+	lines      []fencedCodeLine
+	prefix     string // container prefix taken from the opening fence line
+	endOffset  int    // end of the last content line, excluding its newline
+	lineEnding string
+}
+
+// newFencedCodeBody extracts the body of a fenced code block.
+// Returns nil for empty blocks.
+func newFencedCodeBody(file *mdast.FileSnapshot, cb *mdast.Node) *fencedCodeBody {
+	rng := cb.SourceRange()
+	if rng.IsEmpty() {
+		return nil
+	}
+
+	firstLine, _ := file.LineAt(rng.StartOffset)
+	lastLine, _ := file.LineAt(rng.EndOffset - 1)
+	if firstLine < 2 || lastLine < firstLine {
+		return nil
+	}
+
+	// The opening fence sits on the line before the content; anything before
+	// the fence characters is the container prefix for every content line.
+	fenceLine := file.LineContent(firstLine - 1)
+	fenceIdx := bytes.IndexByte(fenceLine, lint.CodeFenceChar(cb))
+	if fenceIdx < 0 {
+		return nil
+	}
+	prefix := string(fenceLine[:fenceIdx])
+
+	body := &fencedCodeBody{
+		prefix:     prefix,
+		endOffset:  file.Lines[lastLine-1].NewlineStart,
+		lineEnding: "\n",
+	}
+	if info := file.Lines[firstLine-1]; info.EndOffset > info.NewlineStart {
+		body.lineEnding = string(file.Content[info.NewlineStart:info.EndOffset])
+	}
+
+	var text bytes.Buffer
+	for lineNum := firstLine; lineNum <= lastLine; lineNum++ {
+		content := file.LineContent(lineNum)
+		prefixLen := matchingPrefixLen(content, prefix)
+		body.lines = append(body.lines, fencedCodeLine{
+			lineNum:     lineNum,
+			startOffset: file.Lines[lineNum-1].StartOffset,
+			prefixLen:   prefixLen,
+		})
+		text.Write(content[prefixLen:])
+		text.WriteByte('\n')
+	}
+	body.text = text.Bytes()
+
+	return body
+}
+
+// matchingPrefixLen returns how many leading bytes of line match prefix.
+func matchingPrefixLen(line []byte, prefix string) int {
+	n := 0
+	for n < len(line) && n < len(prefix) && line[n] == prefix[n] {
+		n++
+	}
+	return n
+}
+
+// position maps a 1-based line and column within the body to a file position.
+func (b *fencedCodeBody) position(line, col int) mdast.SourcePosition {
+	line = max(1, min(line, len(b.lines)))
+	codeLine := b.lines[line-1]
+	column := codeLine.prefixLen + max(col, 1)
+	return mdast.SourcePosition{
+		StartLine:   codeLine.lineNum,
+		StartColumn: column,
+		EndLine:     codeLine.lineNum,
+		EndColumn:   column,
+	}
+}
+
+// replaceWith builds an edit replacing the body with text, re-applying the
+// container prefix to every line.
+func (b *fencedCodeBody) replaceWith(text []byte) *fix.EditBuilder {
+	blankPrefix := strings.TrimRight(b.prefix, " \t")
+
+	newLines := strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+	for i, line := range newLines {
+		if line == "" {
+			newLines[i] = blankPrefix
+		} else {
+			newLines[i] = b.prefix + line
+		}
+	}
+
+	builder := fix.NewEditBuilder()
+	builder.ReplaceRange(b.lines[0].startOffset, b.endOffset, strings.Join(newLines, b.lineEnding))
+	return builder
+}
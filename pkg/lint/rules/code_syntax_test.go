@@ -0,0 +1,232 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+func TestCodeBlockSyntaxRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		languages []any
+		wantLine  int
+		wantCol   int
+		wantDiags int
+	}{
+		{
+			name:      "valid json",
+			input:     "# Doc\n\n```json\n{\"a\": 1}\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "invalid json",
+			input:     "# Doc\n\n```json\n{\n  \"a\": 1,\n}\n```\n",
+			wantDiags: 1,
+			wantLine:  6,
+			wantCol:   1,
+		},
+		{
+			name:      "invalid yaml alias",
+			input:     "```yml\na: 1\n  b: 2\n```\n",
+			wantDiags: 1,
+			wantLine:  3,
+			wantCol:   1,
+		},
+		{
+			name:      "invalid toml",
+			input:     "```toml\n[server]\nport = \n```\n",
+			wantDiags: 1,
+			wantLine:  3,
+			wantCol:   8,
+		},
+		{
+			name:      "go statements",
+			input:     "```go\nx := 1\nfmt.Println(x)\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "invalid go",
+			input:     "```golang\nx := 1\ny := )\n```\n",
+			wantDiags: 1,
+			wantLine:  3,
+			wantCol:   6,
+		},
+		{
+			name:      "blockquote prefix",
+			input:     "> ```json\n> {\"a\": tru}\n> ```\n",
+			wantDiags: 1,
+			wantLine:  2,
+			wantCol:   12,
+		},
+		{
+			name:      "list indentation",
+			input:     "- item\n\n  ```json\n  [1, 2,]\n  ```\n",
+			wantDiags: 1,
+			wantLine:  4,
+			wantCol:   9,
+		},
+		{
+			name:      "info string with attributes",
+			input:     "```json title=\"config.json\"\n{\n```\n",
+			wantDiags: 1,
+			wantLine:  2,
+			wantCol:   2,
+		},
+		{
+			name:      "unsupported language ignored",
+			input:     "```python\ndef (\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "empty block ignored",
+			input:     "```json\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "language disabled by option",
+			input:     "```json\n{\n```\n",
+			languages: []any{"yaml"},
+			wantDiags: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ruleCfg *config.RuleConfig
+			if tt.languages != nil {
+				ruleCfg = &config.RuleConfig{Options: map[string]any{"languages": tt.languages}}
+			}
+
+			diags := applyFlavorRule(t, NewCodeBlockSyntaxRule(), config.FlavorCommonMark, "test.md", tt.input, ruleCfg)
+			require.Len(t, diags, tt.wantDiags)
+			if tt.wantDiags == 0 {
+				return
+			}
+
+			assert.Equal(t, tt.wantLine, diags[0].StartLine, diags[0].Message)
+			assert.Equal(t, tt.wantCol, diags[0].StartColumn, diags[0].Message)
+			assert.Equal(t, config.SeverityError, diags[0].Severity)
+		})
+	}
+}
+
+func TestCodeBlockSyntaxRule_OptIn(t *testing.T) {
+	rule := NewCodeBlockSyntaxRule()
+	assert.False(t, rule.DefaultEnabled())
+
+	strict := PackByName("strict")
+	require.NotNil(t, strict)
+	ruleCfg, ok := strict.Rules[rule.ID()]
+	require.True(t, ok, "strict pack should enable %s", rule.ID())
+	require.NotNil(t, ruleCfg.Enabled)
+	assert.True(t, *ruleCfg.Enabled)
+}
+
+func TestCodeBlockFormatRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		options   map[string]any
+		wantDiags int
+		wantFix   string
+	}{
+		{
+			name:      "formatted go",
+			input:     "```go\nx := 1\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "unformatted go",
+			input:     "```go\nfunc f(){\nreturn}\n```\n",
+			wantDiags: 1,
+			wantFix:   "```go\nfunc f() {\n\treturn\n}\n```\n",
+		},
+		{
+			name:      "unformatted json",
+			input:     "Text\n\n```json\n{\"a\":[1,2]}\n```\n",
+			wantDiags: 1,
+			wantFix:   "Text\n\n```json\n{\n  \"a\": [\n    1,\n    2\n  ]\n}\n```\n",
+		},
+		{
+			name:      "json indent option",
+			input:     "```json\n{\"a\":1}\n```\n",
+			options:   map[string]any{"json_indent": 4},
+			wantDiags: 1,
+			wantFix:   "```json\n{\n    \"a\": 1\n}\n```\n",
+		},
+		{
+			name:      "blockquote prefix preserved",
+			input:     "> ```go\n> func f(){\n> x:=1\n>\n> _ = x}\n> ```\n",
+			wantDiags: 1,
+			wantFix:   "> ```go\n> func f() {\n> \tx := 1\n>\n> \t_ = x\n> }\n> ```\n",
+		},
+		{
+			name:      "crlf preserved",
+			input:     "```json\r\n{\"a\":1}\r\n```\r\n",
+			wantDiags: 1,
+			wantFix:   "```json\r\n{\r\n  \"a\": 1\r\n}\r\n```\r\n",
+		},
+		{
+			name:      "syntax error not formatted",
+			input:     "```json\n{\"a\":\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "yaml not formatted",
+			input:     "```yaml\na:   1\n```\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "go only",
+			input:     "```json\n{\"a\":1}\n```\n",
+			options:   map[string]any{"languages": []any{"go"}},
+			wantDiags: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := goldmark.New(string(config.FlavorCommonMark))
+			snapshot, err := parser.Parse(context.Background(), "test.md", []byte(tt.input))
+			require.NoError(t, err)
+
+			rule := NewCodeBlockFormatRule()
+			cfg := config.NewConfig()
+			var ruleCfg *config.RuleConfig
+			if tt.options != nil {
+				ruleCfg = &config.RuleConfig{Options: tt.options}
+			}
+			diags, err := rule.Apply(lint.NewRuleContext(context.Background(), snapshot, cfg, ruleCfg))
+			require.NoError(t, err)
+			require.Len(t, diags, tt.wantDiags)
+			if tt.wantDiags == 0 {
+				return
+			}
+
+			var allEdits []fix.TextEdit
+			for _, d := range diags {
+				allEdits = append(allEdits, d.FixEdits...)
+			}
+			prepared, err := fix.PrepareEdits(allEdits, len(tt.input))
+			require.NoError(t, err)
+			fixed := fix.ApplyEdits([]byte(tt.input), prepared)
+			assert.Equal(t, tt.wantFix, string(fixed))
+
+			// Re-running on the fixed content should produce no diagnostics.
+			snapshot2, err := parser.Parse(context.Background(), "test.md", fixed)
+			require.NoError(t, err)
+			diags2, err := rule.Apply(lint.NewRuleContext(context.Background(), snapshot2, cfg, ruleCfg))
+			require.NoError(t, err)
+			assert.Empty(t, diags2, "fix should be idempotent")
+		})
+	}
+}
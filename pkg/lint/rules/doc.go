@@ -14,6 +14,10 @@
 //
 //   - MD047: single-trailing-newline - Files should end with a single newline
 //
+//   - MDL015: line-endings - Line endings and byte order mark should be consistent (opt-in)
+//
+//   - MDL016: suspicious-unicode - Invisible and bidirectional Unicode characters should not be used (opt-in)
+//
 //   - Headings:
 //
 //   - MD001: heading-increment - Heading levels should only increment by one
@@ -90,6 +94,12 @@
 //
 //   - MD048: code-fence-style - Code fence style should be consistent
 //
+//   - MDL009: code-block-syntax - JSON, YAML, TOML and Go code blocks should parse (opt-in)
+//
+//   - MDL010: code-block-format - Go and JSON code blocks should be formatted (opt-in)
+//
+//   - MDL011: code-fence-info - Code fence languages should be canonical and known (opt-in)
+//
 //   - Emphasis:
 //
 //   - MD036: no-emphasis-as-heading - Emphasis used instead of heading
@@ -116,6 +126,16 @@
 //
 //   - MDL004: table-blank-lines - Tables should be surrounded by blank lines
 //
+//   - Flavor-specific (active only for the named flavor):
+//
+//   - MDL005: wikilink-target - Wikilinks should resolve to a vault file (obsidian)
+//
+//   - MDL006: mdx-jsx-balanced - JSX component tags should be closed (mdx)
+//
+//   - MDL007: fenced-div-balanced - Fenced divs should be closed (pandoc)
+//
+//   - MDL008: admonition-indent - Admonition bodies should be indented (mkdocs)
+//
 // # Rule IDs
 //
 // Rule IDs follow the markdownlint MDxxx convention for compatibility.
@@ -140,4 +160,9 @@
 // Rules are registered with the default registry via RegisterAll.
 // Each rule follows the lint.Rule interface and uses the RuleContext,
 // DiagnosticBuilder, and EditBuilder infrastructure.
+//
+// MDL012 (orphaned-page) and MDL013 (unused-asset) are project-level checks
+// reported by "gomdlint orphans" (package orphans) and are not registered here.
+// MDL014 (fix-cycle) and MDL017 (file-encoding) are reported by the safety
+// pipeline in package lint.
 package rules
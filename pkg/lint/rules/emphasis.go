@@ -30,6 +30,17 @@ func NewNoEmphasisAsHeadingRule() *NoEmphasisAsHeadingRule {
 	}
 }
 
+// FixApplicability returns lint.FixUnsafe: the heading level is inferred
+// from the surrounding headings.
+func (r *NoEmphasisAsHeadingRule) FixApplicability() lint.FixApplicability {
+	return lint.FixUnsafe
+}
+
+// FixChangesRendering returns true: emphasized paragraphs become headings.
+func (r *NoEmphasisAsHeadingRule) FixChangesRendering() bool {
+	return true
+}
+
 // defaultEmphasisPunctuation is the default punctuation that indicates emphasis is not a heading.
 const defaultEmphasisPunctuation = ".,;:!?"
 
@@ -185,6 +196,11 @@ func NewNoSpaceInEmphasisRule() *NoSpaceInEmphasisRule {
 	}
 }
 
+// FixChangesRendering returns true: markers that were literal text become emphasis.
+func (r *NoSpaceInEmphasisRule) FixChangesRendering() bool {
+	return true
+}
+
 // emphasisSpacePattern matches emphasis with spaces inside.
 var emphasisSpacePattern = regexp.MustCompile(`(\*{1,2}|_{1,2})\s+([^*_]+)\s+(\*{1,2}|_{1,2})`)
 
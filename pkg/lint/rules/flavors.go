@@ -0,0 +1,514 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/lint/refs"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+// flavorIs reports whether the context is configured for the given flavor.
+func flavorIs(ctx *lint.RuleContext, flavor config.Flavor) bool {
+	return ctx.Config != nil && ctx.Config.Flavor == flavor
+}
+
+// WikilinkTargetRule checks that Obsidian wikilinks and embeds resolve to
+// files in the vault.
+type WikilinkTargetRule struct {
+	lint.BaseRule
+
+	mu     sync.Mutex
+	vaults map[string]*vaultIndex // vault root -> file index
+}
+
+// NewWikilinkTargetRule creates a new wikilink target rule.
+func NewWikilinkTargetRule() *WikilinkTargetRule {
+	return &WikilinkTargetRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL005",
+			"wikilink-target",
+			"Wikilinks and embeds should resolve to a file in the vault (Obsidian)",
+			[]string{"links", "obsidian"},
+			false, // Not auto-fixable.
+		),
+		vaults: make(map[string]*vaultIndex),
+	}
+}
+
+// Apply checks that every wikilink target exists in the vault.
+func (r *WikilinkTargetRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if !flavorIs(ctx, config.FlavorObsidian) || ctx.Root == nil || ctx.File == nil || ctx.File.Path == "" {
+		return nil, nil
+	}
+
+	links := refs.CollectWikilinks(ctx.Root, ctx.File)
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	root := ctx.OptionString("vault_root", "")
+	if root == "" {
+		root = findVaultRoot(filepath.Dir(ctx.File.Path))
+	}
+
+	index, err := r.index(root)
+	if err != nil {
+		return nil, fmt.Errorf("index vault %s: %w", root, err)
+	}
+
+	var diags []lint.Diagnostic
+	for _, link := range links {
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+
+		// Same-file links ([[#Heading]]) have no target to resolve.
+		if link.Target == "" || index.resolves(link.Target) {
+			continue
+		}
+
+		kind := "Wikilink"
+		if link.Embed {
+			kind = "Embed"
+		}
+		diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, link.Position,
+			fmt.Sprintf("%s target '%s' does not resolve to a file in the vault", kind, link.Target)).
+			WithSeverity(config.SeverityWarning).
+			WithSuggestion("Create the note or fix the link target").
+			Build()
+		diags = append(diags, diag)
+	}
+
+	return diags, nil
+}
+
+// index returns the cached file index for a vault root, building it on first use.
+func (r *WikilinkTargetRule) index(root string) (*vaultIndex, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if idx, ok := r.vaults[root]; ok {
+		return idx, nil
+	}
+
+	idx, err := buildVaultIndex(root)
+	if err != nil {
+		return nil, err
+	}
+	r.vaults[root] = idx
+	return idx, nil
+}
+
+// findVaultRoot walks up from dir looking for an .obsidian directory.
+// Falls back to dir itself when no vault marker is found.
+func findVaultRoot(dir string) string {
+	for current := dir; ; {
+		if info, err := os.Stat(filepath.Join(current, ".obsidian")); err == nil && info.IsDir() {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// vaultIndex holds the lowercase names and relative paths of all vault files.
+type vaultIndex struct {
+	names map[string]bool // base names, with and without ".md"
+	paths map[string]bool // slash-separated relative paths, with and without ".md"
+}
+
+// buildVaultIndex walks root and indexes every non-hidden file.
+func buildVaultIndex(root string) (*vaultIndex, error) {
+	idx := &vaultIndex{
+		names: make(map[string]bool),
+		paths: make(map[string]bool),
+	}
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if os.IsPermission(walkErr) {
+				return nil
+			}
+			return walkErr
+		}
+		if path != root && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil //nolint:nilerr // Skip files outside the vault root.
+		}
+		rel = strings.ToLower(filepath.ToSlash(rel))
+		name := strings.ToLower(entry.Name())
+
+		idx.paths[rel] = true
+		idx.names[name] = true
+		if strings.HasSuffix(name, ".md") {
+			idx.paths[strings.TrimSuffix(rel, ".md")] = true
+			idx.names[strings.TrimSuffix(name, ".md")] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk vault: %w", err)
+	}
+
+	return idx, nil
+}
+
+// resolves reports whether target matches a vault file the way Obsidian
+// resolves links: by unique base name, or by path relative to the vault root.
+func (v *vaultIndex) resolves(target string) bool {
+	target = strings.ToLower(strings.TrimPrefix(filepath.ToSlash(target), "/"))
+	if strings.Contains(target, "/") {
+		return v.paths[target]
+	}
+	return v.names[target]
+}
+
+// MDXJSXBalanceRule checks that JSX component tags in MDX are closed.
+type MDXJSXBalanceRule struct {
+	lint.BaseRule
+}
+
+// NewMDXJSXBalanceRule creates a new MDX JSX balance rule.
+func NewMDXJSXBalanceRule() *MDXJSXBalanceRule {
+	return &MDXJSXBalanceRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL006",
+			"mdx-jsx-balanced",
+			"JSX component tags should be closed (MDX)",
+			[]string{"html", "mdx"},
+			false, // Not auto-fixable.
+		),
+	}
+}
+
+// jsxTagPattern matches single-line JSX component tags and fragments.
+var jsxTagPattern = regexp.MustCompile(`<(/?)([A-Z][\w.]*)?(?:\s[^<>]*?)?(/?)>`)
+
+// jsxTag is an open JSX tag awaiting its closing tag.
+type jsxTag struct {
+	name string
+	pos  mdast.SourcePosition
+}
+
+// Apply checks that every JSX opening tag has a matching closing tag.
+func (r *MDXJSXBalanceRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if !flavorIs(ctx, config.FlavorMDX) || ctx.Root == nil || ctx.File == nil {
+		return nil, nil
+	}
+
+	codeSpans := ctx.CodeSpans()
+	var stack []jsxTag
+	var diags []lint.Diagnostic
+
+	for lineNum := 1; lineNum <= len(ctx.File.Lines); lineNum++ {
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+		if ctx.IsLineInCodeBlock(lineNum) {
+			continue
+		}
+
+		lineInfo := ctx.File.Lines[lineNum-1]
+		line := ctx.File.Content[lineInfo.StartOffset:lineInfo.NewlineStart]
+
+		for _, match := range jsxTagPattern.FindAllSubmatchIndex(line, -1) {
+			if inAnyRange(codeSpans, lineInfo.StartOffset+match[0]) {
+				continue
+			}
+
+			closing := match[3] > match[2]
+			name := ""
+			if match[4] >= 0 {
+				name = string(line[match[4]:match[5]])
+			}
+			selfClosing := match[7] > match[6]
+
+			// Only fragments (<>, </>) may omit the component name.
+			if name == "" && match[1]-match[0] > len("</>") {
+				continue
+			}
+			if selfClosing {
+				continue
+			}
+
+			pos := mdast.SourcePosition{
+				StartLine:   lineNum,
+				StartColumn: match[0] + 1,
+				EndLine:     lineNum,
+				EndColumn:   match[1] + 1,
+			}
+
+			if !closing {
+				stack = append(stack, jsxTag{name: name, pos: pos})
+				continue
+			}
+
+			var diag *lint.Diagnostic
+			stack, diag = r.closeTag(ctx, stack, name, pos)
+			if diag != nil {
+				diags = append(diags, *diag)
+			}
+		}
+	}
+
+	for _, open := range stack {
+		diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, open.pos,
+			fmt.Sprintf("JSX element <%s> is never closed", open.name)).
+			WithSeverity(config.SeverityError).
+			WithSuggestion(fmt.Sprintf("Add </%s> or make the tag self-closing", open.name)).
+			Build()
+		diags = append(diags, diag)
+	}
+
+	return diags, nil
+}
+
+// closeTag pops the matching open tag from stack. A closing tag with no
+// matching open tag is reported; unclosed tags above the match are left for
+// the end-of-document report.
+func (r *MDXJSXBalanceRule) closeTag(
+	ctx *lint.RuleContext,
+	stack []jsxTag,
+	name string,
+	pos mdast.SourcePosition,
+) ([]jsxTag, *lint.Diagnostic) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].name != name {
+			continue
+		}
+		unclosed := stack[i+1:]
+		stack = append(stack[:i], unclosed...)
+		return stack, nil
+	}
+
+	diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos,
+		fmt.Sprintf("Closing tag </%s> has no matching opening tag", name)).
+		WithSeverity(config.SeverityError).
+		WithSuggestion("Remove the closing tag or add the missing opening tag").
+		Build()
+	return stack, &diag
+}
+
+// inAnyRange reports whether offset falls inside the source range of any node.
+func inAnyRange(nodes []*mdast.Node, offset int) bool {
+	for _, node := range nodes {
+		if node.SourceRange().Contains(offset) {
+			return true
+		}
+	}
+	return false
+}
+
+// PandocFencedDivRule checks that Pandoc fenced divs (::: class) are closed.
+type PandocFencedDivRule struct {
+	lint.BaseRule
+}
+
+// NewPandocFencedDivRule creates a new Pandoc fenced div rule.
+func NewPandocFencedDivRule() *PandocFencedDivRule {
+	return &PandocFencedDivRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL007",
+			"fenced-div-balanced",
+			"Fenced divs should be closed and have attributes (Pandoc)",
+			[]string{"blocks", "pandoc"},
+			false, // Not auto-fixable.
+		),
+	}
+}
+
+// Apply checks that each opening ::: fence has a closing fence.
+func (r *PandocFencedDivRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if !flavorIs(ctx, config.FlavorPandoc) || ctx.Root == nil || ctx.File == nil {
+		return nil, nil
+	}
+
+	var open []mdast.SourcePosition
+	var diags []lint.Diagnostic
+
+	for lineNum := 1; lineNum <= len(ctx.File.Lines); lineNum++ {
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+		if ctx.IsLineInCodeBlock(lineNum) {
+			continue
+		}
+
+		line := bytes.TrimRight(lint.LineContent(ctx.File, lineNum), " \t")
+		colons := countLeading(line, ':')
+		if colons < 3 {
+			continue
+		}
+
+		pos := mdast.SourcePosition{
+			StartLine:   lineNum,
+			StartColumn: 1,
+			EndLine:     lineNum,
+			EndColumn:   len(line) + 1,
+		}
+
+		// A fence with attributes opens a div; a bare fence closes one.
+		attrs := bytes.TrimSpace(bytes.TrimRight(line[colons:], ":"))
+		if len(attrs) > 0 {
+			open = append(open, pos)
+			continue
+		}
+
+		if len(open) == 0 {
+			diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos,
+				"Closing fence ':::' has no matching fenced div").
+				WithSeverity(config.SeverityWarning).
+				WithSuggestion("Add a class or attributes to open a div, e.g. '::: note'").
+				Build()
+			diags = append(diags, diag)
+			continue
+		}
+		open = open[:len(open)-1]
+	}
+
+	for _, pos := range open {
+		diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos,
+			"Fenced div is never closed").
+			WithSeverity(config.SeverityWarning).
+			WithSuggestion("Add a closing ':::' fence").
+			Build()
+		diags = append(diags, diag)
+	}
+
+	return diags, nil
+}
+
+// countLeading returns the number of leading bytes equal to ch.
+func countLeading(line []byte, ch byte) int {
+	n := 0
+	for n < len(line) && line[n] == ch {
+		n++
+	}
+	return n
+}
+
+// MkDocsAdmonitionRule checks that MkDocs admonition bodies are indented.
+type MkDocsAdmonitionRule struct {
+	lint.BaseRule
+}
+
+// NewMkDocsAdmonitionRule creates a new MkDocs admonition rule.
+func NewMkDocsAdmonitionRule() *MkDocsAdmonitionRule {
+	return &MkDocsAdmonitionRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL008",
+			"admonition-indent",
+			"Admonition and content tab bodies should be indented by 4 spaces (MkDocs)",
+			[]string{"blocks", "mkdocs"},
+			false, // Not auto-fixable.
+		),
+	}
+}
+
+// mkdocsContainerPattern matches admonitions (!!! note), collapsible blocks
+// (??? note, ???+ note) and content tabs (=== "Tab").
+var mkdocsContainerPattern = regexp.MustCompile(`^\s*(?:(?:!!!|\?\?\?\+?)\s+\S|===\s+")`)
+
+// isMkDocsContainerLine reports whether line opens an indented MkDocs container.
+func isMkDocsContainerLine(line []byte) bool {
+	return mkdocsContainerPattern.Match(line)
+}
+
+// Apply checks that the first non-blank line after a container marker is indented.
+func (r *MkDocsAdmonitionRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if !flavorIs(ctx, config.FlavorMkDocs) || ctx.Root == nil || ctx.File == nil {
+		return nil, nil
+	}
+
+	var diags []lint.Diagnostic
+	lineCount := len(ctx.File.Lines)
+
+	for lineNum := 1; lineNum <= lineCount; lineNum++ {
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+		if ctx.IsLineInCodeBlock(lineNum) {
+			continue
+		}
+
+		marker := lint.LineContent(ctx.File, lineNum)
+		if !isMkDocsContainerLine(marker) {
+			continue
+		}
+		markerIndent := leadingWidth(marker)
+
+		next := lineNum + 1
+		for next <= lineCount && lint.IsBlankLine(ctx.File, next) {
+			next++
+		}
+
+		if next <= lineCount && leadingWidth(lint.LineContent(ctx.File, next)) >= markerIndent+4 {
+			continue
+		}
+
+		pos := mdast.SourcePosition{
+			StartLine:   lineNum,
+			StartColumn: markerIndent + 1,
+			EndLine:     lineNum,
+			EndColumn:   len(marker) + 1,
+		}
+		diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos,
+			"Admonition has no indented body").
+			WithSeverity(config.SeverityWarning).
+			WithSuggestion("Indent the content under the marker by 4 spaces").
+			Build()
+		diags = append(diags, diag)
+	}
+
+	return diags, nil
+}
+
+// leadingWidth returns the indentation width of line, counting tabs as 4 columns.
+func leadingWidth(line []byte) int {
+	width := 0
+	for _, ch := range line {
+		switch ch {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// isMkDocsContainerBody reports whether the indented block starting at
+// startLine is the body of an admonition or content tab.
+func isMkDocsContainerBody(file *mdast.FileSnapshot, startLine int) bool {
+	for lineNum := startLine - 1; lineNum >= 1; lineNum-- {
+		if lint.IsBlankLine(file, lineNum) {
+			continue
+		}
+		return isMkDocsContainerLine(lint.LineContent(file, lineNum))
+	}
+	return false
+}
@@ -0,0 +1,384 @@
+package rules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+// applyFlavorRule parses input with the given flavor and applies rule.
+func applyFlavorRule(
+	t *testing.T, rule lint.Rule, flavor config.Flavor, path, input string, ruleCfg *config.RuleConfig,
+) []lint.Diagnostic {
+	t.Helper()
+
+	parser := goldmark.New(string(flavor))
+	snapshot, err := parser.Parse(context.Background(), path, []byte(input))
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.Flavor = flavor
+
+	ctx := lint.NewRuleContext(context.Background(), snapshot, cfg, ruleCfg)
+	diags, err := rule.Apply(ctx)
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	return diags
+}
+
+func TestWikilinkTargetRule(t *testing.T) {
+	vault := t.TempDir()
+	if err := os.Mkdir(filepath.Join(vault, ".obsidian"), 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(vault, "notes", "sub"), 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	for _, name := range []string{"Home.md", "notes/Ideas.md", "notes/sub/Deep.md", "diagram.png"} {
+		if err := os.WriteFile(filepath.Join(vault, name), []byte("# x\n"), 0o644); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+	notePath := filepath.Join(vault, "notes", "Current.md")
+
+	tests := []struct {
+		name   string
+		input  string
+		flavor config.Flavor
+		wantN  int
+	}{
+		{
+			name:   "existing page",
+			input:  "See [[Home]].\n",
+			flavor: config.FlavorObsidian,
+			wantN:  0,
+		},
+		{
+			name:   "case insensitive with alias and heading",
+			input:  "See [[ideas#Intro|my ideas]].\n",
+			flavor: config.FlavorObsidian,
+			wantN:  0,
+		},
+		{
+			name:   "path target",
+			input:  "See [[notes/sub/Deep]].\n",
+			flavor: config.FlavorObsidian,
+			wantN:  0,
+		},
+		{
+			name:   "embed existing file",
+			input:  "![[diagram.png]]\n",
+			flavor: config.FlavorObsidian,
+			wantN:  0,
+		},
+		{
+			name:   "same file heading",
+			input:  "Jump to [[#Heading]].\n",
+			flavor: config.FlavorObsidian,
+			wantN:  0,
+		},
+		{
+			name:   "missing page",
+			input:  "See [[Nowhere]] and ![[missing.png]].\n",
+			flavor: config.FlavorObsidian,
+			wantN:  2,
+		},
+		{
+			name:   "ignored in code",
+			input:  "`[[Nowhere]]`\n\n```\n[[Nowhere]]\n```\n",
+			flavor: config.FlavorObsidian,
+			wantN:  0,
+		},
+		{
+			name:   "inactive for other flavors",
+			input:  "See [[Nowhere]].\n",
+			flavor: config.FlavorGFM,
+			wantN:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := applyFlavorRule(t, NewWikilinkTargetRule(), tt.flavor, notePath, tt.input, nil)
+			if len(diags) != tt.wantN {
+				t.Errorf("got %d diagnostics, want %d", len(diags), tt.wantN)
+				for _, d := range diags {
+					t.Logf("  - %s", d.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestWikilinkTargetRule_VaultRootOption(t *testing.T) {
+	vault := t.TempDir()
+	if err := os.WriteFile(filepath.Join(vault, "Target.md"), []byte("# x\n"), 0o644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	sub := filepath.Join(vault, "deep", "er")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	// Without a .obsidian directory the vault defaults to the file's directory.
+	path := filepath.Join(sub, "note.md")
+	diags := applyFlavorRule(t, NewWikilinkTargetRule(), config.FlavorObsidian, path, "[[Target]]\n", nil)
+	if len(diags) != 1 {
+		t.Errorf("without vault_root: got %d diagnostics, want 1", len(diags))
+	}
+
+	ruleCfg := &config.RuleConfig{Options: map[string]any{"vault_root": vault}}
+	diags = applyFlavorRule(t, NewWikilinkTargetRule(), config.FlavorObsidian, path, "[[Target]]\n", ruleCfg)
+	if len(diags) != 0 {
+		t.Errorf("with vault_root: got %d diagnostics, want 0", len(diags))
+	}
+}
+
+func TestMDXJSXBalanceRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		flavor config.Flavor
+		wantN  int
+
+		// wantColumns are the start and exclusive end columns of the
+		// diagnostic when there is one.
+		wantColumns [2]int
+	}{
+		{
+			name:   "balanced block component",
+			input:  "<Tabs>\n\n<Tab label=\"a\">\n\nText\n\n</Tab>\n\n</Tabs>\n",
+			flavor: config.FlavorMDX,
+			wantN:  0,
+		},
+		{
+			name:   "self closing",
+			input:  "<Chart data={values} />\n",
+			flavor: config.FlavorMDX,
+			wantN:  0,
+		},
+		{
+			name:   "fragment",
+			input:  "<>\n\nText\n\n</>\n",
+			flavor: config.FlavorMDX,
+			wantN:  0,
+		},
+		{
+			name:        "unclosed",
+			input:       "<Callout>\n\nText\n",
+			flavor:      config.FlavorMDX,
+			wantN:       1,
+			wantColumns: [2]int{1, 10},
+		},
+		{
+			name:        "stray close",
+			input:       "Text\n\n</Callout>\n",
+			flavor:      config.FlavorMDX,
+			wantN:       1,
+			wantColumns: [2]int{1, 11},
+		},
+		{
+			name:        "mismatched nesting",
+			input:       "<Outer>\n\n<Inner>\n\n</Outer>\n",
+			flavor:      config.FlavorMDX,
+			wantN:       1,
+			wantColumns: [2]int{1, 8},
+		},
+		{
+			name:   "ignored in code",
+			input:  "`<Callout>`\n\n```jsx\n<Callout>\n```\n",
+			flavor: config.FlavorMDX,
+			wantN:  0,
+		},
+		{
+			name:   "inactive for other flavors",
+			input:  "<Callout>\n",
+			flavor: config.FlavorGFM,
+			wantN:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := applyFlavorRule(t, NewMDXJSXBalanceRule(), tt.flavor, "test.mdx", tt.input, nil)
+			if len(diags) != tt.wantN {
+				t.Errorf("got %d diagnostics, want %d", len(diags), tt.wantN)
+				for _, d := range diags {
+					t.Logf("  - %s", d.Message)
+				}
+			}
+			if len(diags) == 1 {
+				if got := [2]int{diags[0].StartColumn, diags[0].EndColumn}; got != tt.wantColumns {
+					t.Errorf("columns = %v, want %v", got, tt.wantColumns)
+				}
+			}
+		})
+	}
+}
+
+func TestPandocFencedDivRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		flavor config.Flavor
+		wantN  int
+
+		// wantColumns are the start and exclusive end columns of the
+		// diagnostic when there is one.
+		wantColumns [2]int
+	}{
+		{
+			name:   "balanced",
+			input:  "::: note\nText\n:::\n",
+			flavor: config.FlavorPandoc,
+			wantN:  0,
+		},
+		{
+			name:   "nested with attributes",
+			input:  "::::: {.outer}\n::: {#inner}\nText\n:::\n:::::\n",
+			flavor: config.FlavorPandoc,
+			wantN:  0,
+		},
+		{
+			name:        "never closed",
+			input:       "::: warning\nText\n",
+			flavor:      config.FlavorPandoc,
+			wantN:       1,
+			wantColumns: [2]int{1, 12},
+		},
+		{
+			name:        "stray close",
+			input:       "Text\n\n:::\n",
+			flavor:      config.FlavorPandoc,
+			wantN:       1,
+			wantColumns: [2]int{1, 4},
+		},
+		{
+			name:   "ignored in code block",
+			input:  "```\n::: note\n```\n",
+			flavor: config.FlavorPandoc,
+			wantN:  0,
+		},
+		{
+			name:   "inactive for other flavors",
+			input:  "::: note\nText\n",
+			flavor: config.FlavorCommonMark,
+			wantN:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := applyFlavorRule(t, NewPandocFencedDivRule(), tt.flavor, "test.md", tt.input, nil)
+			if len(diags) != tt.wantN {
+				t.Errorf("got %d diagnostics, want %d", len(diags), tt.wantN)
+				for _, d := range diags {
+					t.Logf("  - %s", d.Message)
+				}
+			}
+			if len(diags) == 1 {
+				if got := [2]int{diags[0].StartColumn, diags[0].EndColumn}; got != tt.wantColumns {
+					t.Errorf("columns = %v, want %v", got, tt.wantColumns)
+				}
+			}
+		})
+	}
+}
+
+func TestMkDocsAdmonitionRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		flavor config.Flavor
+		wantN  int
+
+		// wantColumns are the start and exclusive end columns of the
+		// diagnostic when there is one.
+		wantColumns [2]int
+	}{
+		{
+			name:   "indented admonition",
+			input:  "!!! note\n\n    Body text.\n",
+			flavor: config.FlavorMkDocs,
+			wantN:  0,
+		},
+		{
+			name:   "collapsible with title",
+			input:  "???+ tip \"Title\"\n    Body text.\n",
+			flavor: config.FlavorMkDocs,
+			wantN:  0,
+		},
+		{
+			name:   "content tab",
+			input:  "=== \"Tab 1\"\n\n    Tab body.\n",
+			flavor: config.FlavorMkDocs,
+			wantN:  0,
+		},
+		{
+			name:        "body not indented",
+			input:       "!!! warning\n\nBody text.\n",
+			flavor:      config.FlavorMkDocs,
+			wantN:       1,
+			wantColumns: [2]int{1, 12},
+		},
+		{
+			name:        "no body",
+			input:       "!!! note\n",
+			flavor:      config.FlavorMkDocs,
+			wantN:       1,
+			wantColumns: [2]int{1, 9},
+		},
+		{
+			name:   "setext underline not a tab",
+			input:  "Heading\n===\n\nText.\n",
+			flavor: config.FlavorMkDocs,
+			wantN:  0,
+		},
+		{
+			name:   "inactive for other flavors",
+			input:  "!!! note\n",
+			flavor: config.FlavorGFM,
+			wantN:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := applyFlavorRule(t, NewMkDocsAdmonitionRule(), tt.flavor, "test.md", tt.input, nil)
+			if len(diags) != tt.wantN {
+				t.Errorf("got %d diagnostics, want %d", len(diags), tt.wantN)
+				for _, d := range diags {
+					t.Logf("  - %s", d.Message)
+				}
+			}
+			if len(diags) == 1 {
+				if got := [2]int{diags[0].StartColumn, diags[0].EndColumn}; got != tt.wantColumns {
+					t.Errorf("columns = %v, want %v", got, tt.wantColumns)
+				}
+			}
+		})
+	}
+}
+
+func TestCodeBlockStyleRule_MkDocsAdmonitionBody(t *testing.T) {
+	input := "```go\nx := 1\n```\n\n!!! note\n\n    Body text.\n"
+	ruleCfg := &config.RuleConfig{Options: map[string]any{"style": "consistent"}}
+
+	diags := applyFlavorRule(t, NewCodeBlockStyleRule(), config.FlavorMkDocs, "test.md", input, ruleCfg)
+	if len(diags) != 0 {
+		t.Errorf("mkdocs: got %d diagnostics, want 0", len(diags))
+	}
+
+	diags = applyFlavorRule(t, NewCodeBlockStyleRule(), config.FlavorCommonMark, "test.md", input, ruleCfg)
+	if len(diags) != 1 {
+		t.Errorf("commonmark: got %d diagnostics, want 1", len(diags))
+	}
+}
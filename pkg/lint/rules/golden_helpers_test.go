@@ -58,7 +58,7 @@ func diagFromLint(diag lint.Diagnostic) DiagExpectation {
 		Column:   diag.StartColumn,
 		Message:  diag.Message,
 		Severity: string(diag.Severity),
-		Fixable:  diag.HasFix(),
+		Fixable:  diag.AutoFixable(),
 	}
 }
 
@@ -211,7 +211,7 @@ func writeDiagsTxt(t *testing.T, path string, diags []lint.Diagnostic, filename
 	var buf bytes.Buffer
 	for _, diag := range diags {
 		fixable := ""
-		if diag.HasFix() {
+		if diag.AutoFixable() {
 			fixable = " [fixable]"
 		}
 		// Format: file.input.md:2:1 warning Message (rule-name)%s
@@ -435,22 +435,24 @@ func getEnabledRules(t *testing.T) []lint.Rule {
 	return enabled
 }
 
-// filterFixableDiags returns only diagnostics that have fixes.
+// filterFixableDiags returns only diagnostics that have fixes fix mode
+// applies.
 func filterFixableDiags(diags []lint.Diagnostic) []lint.Diagnostic {
 	var fixable []lint.Diagnostic
 	for _, d := range diags {
-		if d.HasFix() {
+		if d.AutoFixable() {
 			fixable = append(fixable, d)
 		}
 	}
 	return fixable
 }
 
-// countFixableDiags returns the number of diagnostics with fixes.
+// countFixableDiags returns the number of diagnostics with fixes fix mode
+// applies.
 func countFixableDiags(diags []lint.Diagnostic) int {
 	count := 0
 	for _, diag := range diags {
-		if diag.HasFix() {
+		if diag.AutoFixable() {
 			count++
 		}
 	}
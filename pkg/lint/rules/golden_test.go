@@ -274,6 +274,9 @@ func runRoundTripTest(t *testing.T, tc GoldenTestCase) {
 func applyAllFixes(t *testing.T, input []byte, diags []lint.Diagnostic) []byte {
 	t.Helper()
 
+	// Only fixes that fix mode applies; suggestions are not.
+	diags = filterFixableDiags(diags)
+
 	// Count total edits for preallocation
 	totalEdits := 0
 	for _, diag := range diags {
@@ -301,8 +304,8 @@ func applyAllFixes(t *testing.T, input []byte, diags []lint.Diagnostic) []byte {
 	if len(skipped) > 0 {
 		t.Logf("Note: %d edits skipped due to conflicts (non-deletions that overlap)", len(skipped))
 	}
-	if merged > 0 {
-		t.Logf("Note: %d overlapping deletions were merged", merged)
+	if len(merged) > 0 {
+		t.Logf("Note: %d overlapping deletions were merged", len(merged))
 	}
 
 	return fix.ApplyEdits(input, accepted)
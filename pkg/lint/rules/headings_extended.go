@@ -31,6 +31,11 @@ func NewNoMissingSpaceATXRule() *NoMissingSpaceATXRule {
 	}
 }
 
+// FixChangesRendering returns true: paragraphs become headings.
+func (r *NoMissingSpaceATXRule) FixChangesRendering() bool {
+	return true
+}
+
 // atxHeadingNoSpacePattern matches ATX headings without space after hashes.
 // Matches: #Heading, ##Heading, etc. (no space after #).
 var atxHeadingNoSpacePattern = regexp.MustCompile(`^(#{1,6})([^#\s])`)
@@ -196,6 +201,11 @@ func NewNoMissingSpaceClosedATXRule() *NoMissingSpaceClosedATXRule {
 	}
 }
 
+// FixChangesRendering returns true: paragraphs become headings.
+func (r *NoMissingSpaceClosedATXRule) FixChangesRendering() bool {
+	return true
+}
+
 // closedATXPattern matches closed ATX headings.
 var closedATXPattern = regexp.MustCompile(`^(#{1,6})(.+?)(#{1,6})\s*$`)
 
@@ -643,6 +653,11 @@ func NewNoTrailingPunctuationRule() *NoTrailingPunctuationRule {
 	}
 }
 
+// FixChangesRendering returns true: the punctuation removed is part of the heading.
+func (r *NoTrailingPunctuationRule) FixChangesRendering() bool {
+	return true
+}
+
 // defaultPunctuation is the default set of trailing punctuation characters.
 const defaultPunctuation = ".,;:!"
 
@@ -94,6 +94,28 @@ func TestNoMissingSpaceATXRule(t *testing.T) {
 	}
 }
 
+func TestNoMissingSpaceATXRule_ByteOrderMark(t *testing.T) {
+	for _, bom := range []string{"", "\ufeff"} {
+		input := bom + "#Title\n"
+		parser := goldmark.New(string(config.FlavorCommonMark))
+		snapshot, err := parser.Parse(context.Background(), "test.md", []byte(input))
+		require.NoError(t, err)
+
+		rule := NewNoMissingSpaceATXRule()
+		ruleCtx := lint.NewRuleContext(context.Background(), snapshot, config.NewConfig(), nil)
+
+		diags, err := rule.Apply(ruleCtx)
+		require.NoError(t, err)
+		require.Len(t, diags, 1, "bom=%q", bom)
+		assert.Equal(t, 1, diags[0].StartLine)
+		assert.Equal(t, 1, diags[0].StartColumn)
+
+		prepared, err := fix.PrepareEdits(diags[0].FixEdits, len(input))
+		require.NoError(t, err)
+		assert.Equal(t, bom+"# Title\n", string(fix.ApplyEdits([]byte(input), prepared)))
+	}
+}
+
 func TestNoMultipleSpaceATXRule(t *testing.T) {
 	tests := []struct {
 		name      string
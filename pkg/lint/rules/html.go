@@ -57,6 +57,9 @@ func (r *InlineHTMLRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error)
 		allowedSet[strings.ToLower(el)] = true
 	}
 
+	// MDX renders capitalized tags and fragments as JSX components.
+	skipJSX := ctx.Config != nil && ctx.Config.Flavor == config.FlavorMDX
+
 	var diags []lint.Diagnostic
 
 	// Check HTML blocks.
@@ -66,7 +69,7 @@ func (r *InlineHTMLRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error)
 			return diags, ctx.Ctx.Err()
 		}
 
-		diag := r.checkHTMLNode(block, allowedSet, "HTML block")
+		diag := r.checkHTMLNode(block, allowedSet, "HTML block", skipJSX)
 		if diag != nil {
 			diags = append(diags, *diag)
 		}
@@ -79,7 +82,7 @@ func (r *InlineHTMLRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error)
 			return diags, ctx.Ctx.Err()
 		}
 
-		diag := r.checkHTMLNode(inline, allowedSet, "Inline HTML")
+		diag := r.checkHTMLNode(inline, allowedSet, "Inline HTML", skipJSX)
 		if diag != nil {
 			diags = append(diags, *diag)
 		}
@@ -103,7 +106,7 @@ func (r *InlineHTMLRule) getAllowedElements(ctx *lint.RuleContext) []string {
 	}
 
 	// Use flavor-based defaults.
-	if ctx.Config != nil && ctx.Config.Flavor == config.FlavorGFM {
+	if ctx.Config != nil && ctx.Config.Flavor.IsGFMBased() {
 		return gfmAllowedHTMLElements()
 	}
 
@@ -114,6 +117,7 @@ func (r *InlineHTMLRule) checkHTMLNode(
 	node *mdast.Node,
 	allowedSet map[string]bool,
 	nodeType string,
+	skipJSX bool,
 ) *lint.Diagnostic {
 	if node == nil || node.File == nil {
 		return nil
@@ -157,6 +161,10 @@ func (r *InlineHTMLRule) checkHTMLNode(
 		return nil
 	}
 
+	if skipJSX && lint.IsJSXTag(content) {
+		return nil
+	}
+
 	tagName := lint.ExtractHTMLTagName(content)
 	if tagName == "" {
 		// Could be a comment or other HTML construct.
@@ -132,6 +132,30 @@ func TestInlineHTMLRule_FlavorDefaults(t *testing.T) {
 			flavor: config.FlavorGFM,
 			wantN:  1,
 		},
+		{
+			name:   "mdx jsx component skipped",
+			input:  "<Callout type=\"info\">\n\nHello\n\n</Callout>",
+			flavor: config.FlavorMDX,
+			wantN:  0,
+		},
+		{
+			name:   "mdx inline jsx skipped",
+			input:  "Status: <Badge>beta</Badge>",
+			flavor: config.FlavorMDX,
+			wantN:  0,
+		},
+		{
+			name:   "mdx html still checked",
+			input:  "<div>content</div>",
+			flavor: config.FlavorMDX,
+			wantN:  1,
+		},
+		{
+			name:   "gfm jsx component reported",
+			input:  "Status: <Badge>beta</Badge>",
+			flavor: config.FlavorGFM,
+			wantN:  2,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,207 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+// LineEndingStyle represents the line ending required by MDL015.
+type LineEndingStyle string
+
+const (
+	// LineEndingConsistent requires the line ending most lines of the file use.
+	LineEndingConsistent LineEndingStyle = "consistent"
+	// LineEndingLF requires LF line endings.
+	LineEndingLF LineEndingStyle = "lf"
+	// LineEndingCRLF requires CRLF line endings.
+	LineEndingCRLF LineEndingStyle = "crlf"
+)
+
+// BOMPolicy represents whether MDL015 requires a UTF-8 byte order mark.
+type BOMPolicy string
+
+const (
+	// BOMForbid reports a byte order mark at the start of the file.
+	BOMForbid BOMPolicy = "forbid"
+	// BOMRequire reports a file that does not start with a byte order mark.
+	BOMRequire BOMPolicy = "require"
+)
+
+// utf8BOM is the UTF-8 encoding of the byte order mark.
+const utf8BOM = "\ufeff"
+
+// LineEndingsRule checks that line endings and the byte order mark follow
+// the configured style.
+type LineEndingsRule struct {
+	lint.BaseRule
+}
+
+// NewLineEndingsRule creates a new line endings rule.
+func NewLineEndingsRule() *LineEndingsRule {
+	return &LineEndingsRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL015",
+			"line-endings",
+			"Line endings and byte order mark should be consistent",
+			[]string{"whitespace", "line_endings"},
+			true,
+		),
+	}
+}
+
+// DefaultEnabled returns false - this rule is opt-in.
+func (r *LineEndingsRule) DefaultEnabled() bool {
+	return false
+}
+
+// FixChangesTextStyle returns true: the fixes set the line endings and
+// byte order mark of the file, which other fixes then keep.
+func (r *LineEndingsRule) FixChangesTextStyle() bool {
+	return true
+}
+
+// Apply checks the byte order mark, the line endings and stray carriage
+// returns.
+func (r *LineEndingsRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if ctx.File == nil || len(ctx.File.Content) == 0 {
+		return nil, nil
+	}
+
+	diags := r.checkBOM(ctx)
+
+	style := LineEndingStyle(ctx.OptionString("style", string(LineEndingConsistent)))
+	want := expectedLineEnding(ctx.File, style)
+	content := ctx.File.Content
+
+	for lineNum := 1; lineNum <= len(ctx.File.Lines); lineNum++ {
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+
+		line := ctx.File.Lines[lineNum-1]
+
+		// A carriage return not followed by a line feed.
+		for offset := line.StartOffset; offset < line.NewlineStart; offset++ {
+			if content[offset] != '\r' {
+				continue
+			}
+			diags = append(diags, r.lineEndingDiag(ctx, lineNum, offset, offset+1,
+				fmt.Sprintf("Stray carriage return, expected %s line ending", lineEndingName(want)), want))
+		}
+
+		// The last line may have no line ending.
+		if line.NewlineStart == line.EndOffset {
+			continue
+		}
+		if got := string(content[line.NewlineStart:line.EndOffset]); got != want {
+			diags = append(diags, r.lineEndingDiag(ctx, lineNum, line.NewlineStart, line.EndOffset,
+				fmt.Sprintf("Expected %s line ending, found %s", lineEndingName(want), lineEndingName(got)), want))
+		}
+	}
+
+	return diags, nil
+}
+
+// checkBOM reports a byte order mark that the bom option forbids, or a
+// missing one that it requires.
+func (r *LineEndingsRule) checkBOM(ctx *lint.RuleContext) []lint.Diagnostic {
+	hasBOM := bytes.HasPrefix(ctx.File.Content, []byte(utf8BOM))
+	pos := mdast.SourcePosition{StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 1}
+	builder := fix.NewEditBuilder()
+
+	var diag lint.Diagnostic
+	switch BOMPolicy(ctx.OptionString("bom", string(BOMForbid))) {
+	case BOMRequire:
+		if hasBOM {
+			return nil
+		}
+		builder.Insert(0, utf8BOM)
+		diag = lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos, "Missing byte order mark").
+			WithSeverity(config.SeverityWarning).
+			WithSuggestion("Start the file with a UTF-8 byte order mark").
+			WithFix(builder).
+			Build()
+	default:
+		if !hasBOM {
+			return nil
+		}
+		builder.Delete(0, len(utf8BOM))
+		pos.EndColumn = len(utf8BOM) + 1
+		diag = lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos, "Unexpected byte order mark").
+			WithSeverity(config.SeverityWarning).
+			WithSuggestion("Remove the byte order mark").
+			WithFix(builder).
+			Build()
+	}
+	return []lint.Diagnostic{diag}
+}
+
+// lineEndingDiag reports the line ending at [start, end) on lineNum, with
+// a fix that replaces it with want.
+func (r *LineEndingsRule) lineEndingDiag(ctx *lint.RuleContext, lineNum, start, end int, msg, want string) lint.Diagnostic {
+	line := ctx.File.Lines[lineNum-1]
+	pos := mdast.SourcePosition{
+		StartLine:   lineNum,
+		StartColumn: start - line.StartOffset + 1,
+		EndLine:     lineNum,
+		EndColumn:   end - line.StartOffset + 1,
+	}
+
+	builder := fix.NewEditBuilder()
+	builder.ReplaceRange(start, end, want)
+
+	return lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos, msg).
+		WithSeverity(config.SeverityWarning).
+		WithSuggestion(fmt.Sprintf("Use %s line endings", lineEndingName(want))).
+		WithFix(builder).
+		Build()
+}
+
+// expectedLineEnding returns the line ending that style requires in file.
+// For LineEndingConsistent it is the one most lines end with, or on a tie
+// the one of the first line; files without line endings expect LF.
+func expectedLineEnding(file *mdast.FileSnapshot, style LineEndingStyle) string {
+	switch style {
+	case LineEndingLF:
+		return "\n"
+	case LineEndingCRLF:
+		return "\r\n"
+	}
+
+	var lf, crlf int
+	first := ""
+	for _, line := range file.Lines {
+		ending := string(file.Content[line.NewlineStart:line.EndOffset])
+		switch ending {
+		case "\n":
+			lf++
+		case "\r\n":
+			crlf++
+		default:
+			continue
+		}
+		if first == "" {
+			first = ending
+		}
+	}
+
+	switch {
+	case crlf > lf:
+		return "\r\n"
+	case crlf == lf && first != "":
+		return first
+	default:
+		return "\n"
+	}
+}
+
+// lineEndingName returns the conventional name of a line ending.
+func lineEndingName(ending string) string {
+	return strings.NewReplacer("\r", "CR", "\n", "LF").Replace(ending)
+}
@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+)
+
+func TestLineEndingsRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		options     map[string]any
+		wantDiags   int
+		wantMessage string
+		wantLine    int
+		wantCol     int
+		wantFix     string
+	}{
+		{
+			name:      "consistent LF",
+			input:     "# Title\n\nText.\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "consistent CRLF",
+			input:     "# Title\r\n\r\nText.\r\n",
+			wantDiags: 0,
+		},
+		{
+			name:        "mostly CRLF",
+			input:       "# Title\r\n\nText.\r\n",
+			wantDiags:   1,
+			wantMessage: "Expected CRLF line ending, found LF",
+			wantLine:    2,
+			wantCol:     1,
+			wantFix:     "# Title\r\n\r\nText.\r\n",
+		},
+		{
+			name:        "tie goes to the first line",
+			input:       "# Title\n\r\n",
+			wantDiags:   1,
+			wantMessage: "Expected LF line ending, found CRLF",
+			wantLine:    2,
+			wantFix:     "# Title\n\n",
+		},
+		{
+			name:      "configured LF",
+			input:     "# Title\r\n\r\nText.\r\n",
+			options:   map[string]any{"style": "lf"},
+			wantDiags: 3,
+			wantLine:  1,
+			wantCol:   8,
+			wantFix:   "# Title\n\nText.\n",
+		},
+		{
+			name:      "configured CRLF",
+			input:     "# Title\n\nText.",
+			options:   map[string]any{"style": "crlf"},
+			wantDiags: 2,
+			wantFix:   "# Title\r\n\r\nText.",
+		},
+		{
+			name:        "stray carriage return",
+			input:       "# Title\n\nOne\rtwo\n",
+			wantDiags:   1,
+			wantMessage: "Stray carriage return, expected LF line ending",
+			wantLine:    3,
+			wantCol:     4,
+			wantFix:     "# Title\n\nOne\ntwo\n",
+		},
+		{
+			name:        "byte order mark forbidden",
+			input:       "\ufeff# Title\n",
+			wantDiags:   1,
+			wantMessage: "Unexpected byte order mark",
+			wantLine:    1,
+			wantCol:     1,
+			wantFix:     "# Title\n",
+		},
+		{
+			name:        "byte order mark required",
+			input:       "# Title\n",
+			options:     map[string]any{"bom": "require"},
+			wantDiags:   1,
+			wantMessage: "Missing byte order mark",
+			wantFix:     "\ufeff# Title\n",
+		},
+		{
+			name:      "byte order mark present",
+			input:     "\ufeff# Title\r\n",
+			options:   map[string]any{"bom": "require"},
+			wantDiags: 0,
+		},
+		{
+			name:      "empty file",
+			input:     "",
+			options:   map[string]any{"bom": "require"},
+			wantDiags: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ruleCfg *config.RuleConfig
+			if tt.options != nil {
+				ruleCfg = &config.RuleConfig{Options: tt.options}
+			}
+
+			diags := applyFlavorRule(t, NewLineEndingsRule(), config.FlavorCommonMark, "test.md", tt.input, ruleCfg)
+			require.Len(t, diags, tt.wantDiags)
+			if tt.wantDiags == 0 {
+				return
+			}
+
+			if tt.wantMessage != "" {
+				assert.Equal(t, tt.wantMessage, diags[0].Message)
+			}
+			if tt.wantLine != 0 {
+				assert.Equal(t, tt.wantLine, diags[0].StartLine)
+			}
+			if tt.wantCol != 0 {
+				assert.Equal(t, tt.wantCol, diags[0].StartColumn)
+			}
+
+			var allEdits []fix.TextEdit
+			for _, d := range diags {
+				allEdits = append(allEdits, d.FixEdits...)
+			}
+			prepared, err := fix.PrepareEdits(allEdits, len(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFix, string(fix.ApplyEdits([]byte(tt.input), prepared)))
+		})
+	}
+}
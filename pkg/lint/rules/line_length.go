@@ -28,6 +28,12 @@ func NewMaxLineLengthRule() *MaxLineLengthRule {
 	}
 }
 
+// FixApplicability returns lint.FixUnsafe: rewrapping prose can break
+// constructs the wrapper does not understand.
+func (r *MaxLineLengthRule) FixApplicability() lint.FixApplicability {
+	return lint.FixUnsafe
+}
+
 // defaultMaxLineLength is the default maximum line length.
 const defaultMaxLineLength = 120
 
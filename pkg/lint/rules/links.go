@@ -30,6 +30,11 @@ func NewReversedLinkRule() *ReversedLinkRule {
 	}
 }
 
+// FixChangesRendering returns true: literal text becomes a link.
+func (r *ReversedLinkRule) FixChangesRendering() bool {
+	return true
+}
+
 // reversedLinkPattern matches (text)[url] patterns.
 var reversedLinkPattern = regexp.MustCompile(`\(([^)]*)\)\[([^\]]*)\]`)
 
@@ -116,6 +121,11 @@ func NewLinkSpacesRule() *LinkSpacesRule {
 	}
 }
 
+// FixChangesRendering returns true: the spaces removed are part of the link text.
+func (r *LinkSpacesRule) FixChangesRendering() bool {
+	return true
+}
+
 // Apply checks for spaces inside link text.
 func (r *LinkSpacesRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
 	if ctx.Root == nil || ctx.File == nil {
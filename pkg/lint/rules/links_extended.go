@@ -27,6 +27,11 @@ func NewNoBareURLsRule() *NoBareURLsRule {
 	}
 }
 
+// FixChangesRendering returns true: bare URLs become links in every flavor.
+func (r *NoBareURLsRule) FixChangesRendering() bool {
+	return true
+}
+
 // bareURLPattern matches bare URLs and emails without consuming boundary characters.
 // Boundary validation (angle brackets, parens, brackets) is done in code after matching.
 var bareURLPattern = regexp.MustCompile(`https?://[^\s<>\[\]()]+|[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
@@ -49,6 +49,14 @@ func (r *FirstLineHeadingRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic,
 		return nil, nil
 	}
 
+	// MDX files may open with import/export statements before any content.
+	if ctx.Config != nil && ctx.Config.Flavor == config.FlavorMDX {
+		firstContentLine = r.skipMDXESM(ctx.File, firstContentLine)
+		if firstContentLine > len(ctx.File.Lines) {
+			return nil, nil
+		}
+	}
+
 	// Check for front matter title if configured.
 	if frontMatterTitlePattern != "" {
 		hasFrontMatterTitle, err := r.checkFrontMatterTitle(ctx.File, frontMatterTitlePattern)
@@ -149,6 +157,29 @@ func (r *FirstLineHeadingRule) findFirstContentLine(file *mdast.FileSnapshot) in
 	return 1
 }
 
+// skipMDXESM returns the first line at or after lineNum that is not blank and
+// not part of an MDX import/export block. An ESM block starts with "import "
+// or "export " and runs until the next blank line.
+func (r *FirstLineHeadingRule) skipMDXESM(file *mdast.FileSnapshot, lineNum int) int {
+	inESM := false
+	for ; lineNum <= len(file.Lines); lineNum++ {
+		if lint.IsBlankLine(file, lineNum) {
+			inESM = false
+			continue
+		}
+		if inESM {
+			continue
+		}
+		content := lint.LineContent(file, lineNum)
+		if bytes.HasPrefix(content, []byte("import ")) || bytes.HasPrefix(content, []byte("export ")) {
+			inESM = true
+			continue
+		}
+		return lineNum
+	}
+	return lineNum
+}
+
 func (r *FirstLineHeadingRule) checkFrontMatterTitle(
 	file *mdast.FileSnapshot,
 	pattern string,
@@ -579,6 +610,11 @@ func NewProperNamesRule() *ProperNamesRule {
 	}
 }
 
+// FixChangesRendering returns true: names are rewritten in the text.
+func (r *ProperNamesRule) FixChangesRendering() bool {
+	return true
+}
+
 // DefaultEnabled returns false - this rule requires configuration.
 func (r *ProperNamesRule) DefaultEnabled() bool {
 	return false
@@ -83,6 +83,44 @@ func TestFirstLineHeadingRule(t *testing.T) {
 	}
 }
 
+func TestFirstLineHeadingRule_ByteOrderMark(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		wantN int
+	}{
+		{name: "starts with h1", input: "# Title\n\nContent", wantN: 0},
+		{name: "starts with paragraph", input: "Some text\n\n# Title", wantN: 1},
+	}
+
+	for _, tt := range tests {
+		for _, bom := range []string{"", "\ufeff"} {
+			parser := goldmark.New(string(config.FlavorCommonMark))
+			snapshot, err := parser.Parse(context.Background(), "test.md", []byte(bom+tt.input))
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			ruleCfg := &config.RuleConfig{Options: map[string]any{"level": 1}}
+			ctx := lint.NewRuleContext(context.Background(), snapshot, config.NewConfig(), ruleCfg)
+			diags, err := NewFirstLineHeadingRule().Apply(ctx)
+			if err != nil {
+				t.Fatalf("Apply error: %v", err)
+			}
+
+			if len(diags) != tt.wantN {
+				t.Errorf("%s (bom=%q): got %d diagnostics, want %d", tt.name, bom, len(diags), tt.wantN)
+			}
+			for _, diag := range diags {
+				if diag.StartLine != 1 || diag.StartColumn != 1 {
+					t.Errorf("%s (bom=%q): diagnostic at %d:%d, want 1:1",
+						tt.name, bom, diag.StartLine, diag.StartColumn)
+				}
+			}
+		}
+	}
+}
+
 func TestFirstLineHeadingRule_FrontMatter(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -79,10 +79,11 @@ func StrictPack() Pack {
 			"MD045": enabled("error"), // no-alt-text
 
 			// Code blocks (errors).
-			"MD031": enabled("error"), // blanks-around-fences
-			"MD038": enabled("error"), // no-space-in-code
-			"MD040": enabled("error"), // fenced-code-language
-			"MD048": enabled("error"), // code-fence-style
+			"MD031":  enabled("error"), // blanks-around-fences
+			"MD038":  enabled("error"), // no-space-in-code
+			"MD040":  enabled("error"), // fenced-code-language
+			"MD048":  enabled("error"), // code-fence-style
+			"MDL009": enabled("error"), // code-block-syntax
 
 			// Emphasis (errors).
 			"MD037": enabled("error"), // no-space-in-emphasis
@@ -44,7 +44,7 @@ func TestPackByName(t *testing.T) {
 		rules int
 	}{
 		{"core", true, 10},
-		{"strict", true, 33},
+		{"strict", true, 34},
 		{"relaxed", true, 2},
 		{"gfm", true, 12},
 		{"nonexistent", false, 0},
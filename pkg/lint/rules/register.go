@@ -10,6 +10,8 @@ func RegisterAll(registry *lint.Registry) {
 	registry.Register(NewFinalNewlineRule())       // MD047
 	registry.Register(NewMultipleBlankLinesRule()) // MD012
 	registry.Register(NewHeadingBlankLinesRule())  // MD022
+	registry.Register(NewLineEndingsRule())        // MDL015
+	registry.Register(NewSuspiciousUnicodeRule())  // MDL016
 
 	// Heading rules
 	registry.Register(NewHeadingIncrementRule())         // MD001
@@ -62,6 +64,9 @@ func RegisterAll(registry *lint.Registry) {
 	registry.Register(NewCodeBlockLanguageRule())  // MD040
 	registry.Register(NewCodeBlockStyleRule())     // MD046
 	registry.Register(NewCodeFenceStyleRule())     // MD048
+	registry.Register(NewCodeBlockSyntaxRule())    // MDL009
+	registry.Register(NewCodeBlockFormatRule())    // MDL010
+	registry.Register(NewCodeFenceInfoRule())      // MDL011
 
 	// HTML rules
 	registry.Register(NewInlineHTMLRule()) // MD033
@@ -84,6 +89,12 @@ func RegisterAll(registry *lint.Registry) {
 	registry.Register(NewLinkImageRefDefsRule())    // MD053
 	registry.Register(NewLinkImageStyleRule())      // MD054
 	registry.Register(NewDescriptiveLinkTextRule()) // MD059
+
+	// Flavor-specific rules
+	registry.Register(NewWikilinkTargetRule())   // MDL005 (obsidian)
+	registry.Register(NewMDXJSXBalanceRule())    // MDL006 (mdx)
+	registry.Register(NewPandocFencedDivRule())  // MDL007 (pandoc)
+	registry.Register(NewMkDocsAdmonitionRule()) // MDL008 (mkdocs)
 }
 
 // RegisterLegacyAliases registers legacy markdownlint alias names that differ
@@ -51,8 +51,8 @@ func (r *TablePipeStyleRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, er
 		return nil, nil
 	}
 
-	// Skip if not GFM flavor.
-	if ctx.Config != nil && ctx.Config.Flavor != config.FlavorGFM {
+	// Skip if the flavor has no table extension.
+	if ctx.Config != nil && !ctx.Config.Flavor.SupportsTables() {
 		return nil, nil
 	}
 
@@ -174,8 +174,8 @@ func (r *TableColumnCountRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic,
 		return nil, nil
 	}
 
-	// Skip if not GFM flavor.
-	if ctx.Config != nil && ctx.Config.Flavor != config.FlavorGFM {
+	// Skip if the flavor has no table extension.
+	if ctx.Config != nil && !ctx.Config.Flavor.SupportsTables() {
 		return nil, nil
 	}
 
@@ -274,8 +274,8 @@ func (r *TableAlignmentRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, er
 		return nil, nil
 	}
 
-	// Skip if not GFM flavor.
-	if ctx.Config != nil && ctx.Config.Flavor != config.FlavorGFM {
+	// Skip if the flavor has no table extension.
+	if ctx.Config != nil && !ctx.Config.Flavor.SupportsTables() {
 		return nil, nil
 	}
 
@@ -420,8 +420,8 @@ func (r *TableBlankLinesRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, e
 		return nil, nil
 	}
 
-	// Skip if not GFM flavor.
-	if ctx.Config != nil && ctx.Config.Flavor != config.FlavorGFM {
+	// Skip if the flavor has no table extension.
+	if ctx.Config != nil && !ctx.Config.Flavor.SupportsTables() {
 		return nil, nil
 	}
 
@@ -611,8 +611,8 @@ func (r *TableColumnStyleRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic,
 		return nil, nil
 	}
 
-	// Skip if not GFM flavor.
-	if ctx.Config != nil && ctx.Config.Flavor != config.FlavorGFM {
+	// Skip if the flavor has no table extension.
+	if ctx.Config != nil && !ctx.Config.Flavor.SupportsTables() {
 		return nil, nil
 	}
 
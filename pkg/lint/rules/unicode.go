@@ -0,0 +1,357 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+// UnicodeCategory groups the characters reported by MDL016.
+type UnicodeCategory string
+
+const (
+	// UnicodeSpace is a space other than U+0020, such as a no-break space.
+	UnicodeSpace UnicodeCategory = "space"
+	// UnicodeZeroWidth is a zero-width space, joiner or non-joiner.
+	UnicodeZeroWidth UnicodeCategory = "zero-width"
+	// UnicodeSoftHyphen is the soft hyphen U+00AD.
+	UnicodeSoftHyphen UnicodeCategory = "soft-hyphen"
+	// UnicodeSmartQuote is a curly single or double quotation mark.
+	UnicodeSmartQuote UnicodeCategory = "smart-quote"
+	// UnicodeBidi is a bidirectional formatting character.
+	UnicodeBidi UnicodeCategory = "bidi"
+)
+
+// UnicodeContext is where in the document MDL016 found a character.
+type UnicodeContext string
+
+const (
+	// UnicodeInProse is text outside code and link destinations.
+	UnicodeInProse UnicodeContext = "prose"
+	// UnicodeInCodeSpan is the content of a code span.
+	UnicodeInCodeSpan UnicodeContext = "code_span"
+	// UnicodeInCodeBlock is the content of a code block.
+	UnicodeInCodeBlock UnicodeContext = "code_block"
+	// UnicodeInLinkDestination is the destination of an inline link, an
+	// image or a reference definition.
+	UnicodeInLinkDestination UnicodeContext = "link_destination"
+)
+
+// suspiciousRune describes a character reported by MDL016.
+type suspiciousRune struct {
+	category    UnicodeCategory
+	name        string
+	replacement string
+}
+
+// suspiciousRunes returns the characters reported by MDL016.
+func suspiciousRunes() map[rune]suspiciousRune {
+	return map[rune]suspiciousRune{
+		'\u00a0': {UnicodeSpace, "NO-BREAK SPACE", " "},
+		'\u2000': {UnicodeSpace, "EN QUAD", " "},
+		'\u2001': {UnicodeSpace, "EM QUAD", " "},
+		'\u2002': {UnicodeSpace, "EN SPACE", " "},
+		'\u2003': {UnicodeSpace, "EM SPACE", " "},
+		'\u2004': {UnicodeSpace, "THREE-PER-EM SPACE", " "},
+		'\u2005': {UnicodeSpace, "FOUR-PER-EM SPACE", " "},
+		'\u2006': {UnicodeSpace, "SIX-PER-EM SPACE", " "},
+		'\u2007': {UnicodeSpace, "FIGURE SPACE", " "},
+		'\u2008': {UnicodeSpace, "PUNCTUATION SPACE", " "},
+		'\u2009': {UnicodeSpace, "THIN SPACE", " "},
+		'\u200a': {UnicodeSpace, "HAIR SPACE", " "},
+		'\u202f': {UnicodeSpace, "NARROW NO-BREAK SPACE", " "},
+		'\u205f': {UnicodeSpace, "MEDIUM MATHEMATICAL SPACE", " "},
+		'\u3000': {UnicodeSpace, "IDEOGRAPHIC SPACE", " "},
+
+		'\u200b': {UnicodeZeroWidth, "ZERO WIDTH SPACE", ""},
+		'\u200c': {UnicodeZeroWidth, "ZERO WIDTH NON-JOINER", ""},
+		'\u200d': {UnicodeZeroWidth, "ZERO WIDTH JOINER", ""},
+		'\u2060': {UnicodeZeroWidth, "WORD JOINER", ""},
+		'\ufeff': {UnicodeZeroWidth, "ZERO WIDTH NO-BREAK SPACE", ""},
+
+		'\u00ad': {UnicodeSoftHyphen, "SOFT HYPHEN", ""},
+
+		'\u2018': {UnicodeSmartQuote, "LEFT SINGLE QUOTATION MARK", "'"},
+		'\u2019': {UnicodeSmartQuote, "RIGHT SINGLE QUOTATION MARK", "'"},
+		'\u201c': {UnicodeSmartQuote, "LEFT DOUBLE QUOTATION MARK", `"`},
+		'\u201d': {UnicodeSmartQuote, "RIGHT DOUBLE QUOTATION MARK", `"`},
+
+		'\u061c': {UnicodeBidi, "ARABIC LETTER MARK", ""},
+		'\u200e': {UnicodeBidi, "LEFT-TO-RIGHT MARK", ""},
+		'\u200f': {UnicodeBidi, "RIGHT-TO-LEFT MARK", ""},
+		'\u202a': {UnicodeBidi, "LEFT-TO-RIGHT EMBEDDING", ""},
+		'\u202b': {UnicodeBidi, "RIGHT-TO-LEFT EMBEDDING", ""},
+		'\u202c': {UnicodeBidi, "POP DIRECTIONAL FORMATTING", ""},
+		'\u202d': {UnicodeBidi, "LEFT-TO-RIGHT OVERRIDE", ""},
+		'\u202e': {UnicodeBidi, "RIGHT-TO-LEFT OVERRIDE", ""},
+		'\u2066': {UnicodeBidi, "LEFT-TO-RIGHT ISOLATE", ""},
+		'\u2067': {UnicodeBidi, "RIGHT-TO-LEFT ISOLATE", ""},
+		'\u2068': {UnicodeBidi, "FIRST STRONG ISOLATE", ""},
+		'\u2069': {UnicodeBidi, "POP DIRECTIONAL ISOLATE", ""},
+	}
+}
+
+// unicodeCategoryNames returns the names used in messages for each category.
+func unicodeCategoryNames() map[UnicodeCategory]string {
+	return map[UnicodeCategory]string{
+		UnicodeSpace:      "Non-ASCII space",
+		UnicodeZeroWidth:  "Zero-width character",
+		UnicodeSoftHyphen: "Soft hyphen",
+		UnicodeSmartQuote: "Smart quote",
+		UnicodeBidi:       "Bidirectional control character",
+	}
+}
+
+// unicodeContextNames returns the names used in messages for each context.
+func unicodeContextNames() map[UnicodeContext]string {
+	return map[UnicodeContext]string{
+		UnicodeInProse:           "prose",
+		UnicodeInCodeSpan:        "code span",
+		UnicodeInCodeBlock:       "code block",
+		UnicodeInLinkDestination: "link destination",
+	}
+}
+
+// unicodeSpan is a byte range of the file in a context other than prose.
+type unicodeSpan struct {
+	mdast.SourceRange
+	context UnicodeContext
+}
+
+// SuspiciousUnicodeRule reports invisible and look-alike Unicode characters
+// that break anchors, search and copied commands, and bidirectional control
+// characters that can make text read differently than it is.
+type SuspiciousUnicodeRule struct {
+	lint.BaseRule
+}
+
+// NewSuspiciousUnicodeRule creates a new suspicious Unicode rule.
+func NewSuspiciousUnicodeRule() *SuspiciousUnicodeRule {
+	return &SuspiciousUnicodeRule{
+		BaseRule: lint.NewBaseRule(
+			"MDL016",
+			"suspicious-unicode",
+			"Invisible and bidirectional Unicode characters should not be used",
+			[]string{"whitespace", "unicode"},
+			true,
+		),
+	}
+}
+
+// DefaultEnabled returns false - this rule is opt-in.
+func (r *SuspiciousUnicodeRule) DefaultEnabled() bool {
+	return false
+}
+
+// FixChangesRendering returns true: removing or replacing the characters
+// changes the text shown.
+func (r *SuspiciousUnicodeRule) FixChangesRendering() bool {
+	return true
+}
+
+// Apply reports the characters whose category is checked in the context
+// they appear in.
+func (r *SuspiciousUnicodeRule) Apply(ctx *lint.RuleContext) ([]lint.Diagnostic, error) {
+	if ctx.File == nil || len(ctx.File.Content) == 0 {
+		return nil, nil
+	}
+
+	// Curly quotes are normal in prose, so prose does not check them by default.
+	proseCategories := []string{
+		string(UnicodeSpace), string(UnicodeZeroWidth), string(UnicodeSoftHyphen), string(UnicodeBidi),
+	}
+	allCategories := append(slices.Clone(proseCategories), string(UnicodeSmartQuote))
+	checked := map[UnicodeContext][]string{
+		UnicodeInProse:           unicodeCategories(ctx, UnicodeInProse, proseCategories),
+		UnicodeInCodeSpan:        unicodeCategories(ctx, UnicodeInCodeSpan, allCategories),
+		UnicodeInCodeBlock:       unicodeCategories(ctx, UnicodeInCodeBlock, allCategories),
+		UnicodeInLinkDestination: unicodeCategories(ctx, UnicodeInLinkDestination, allCategories),
+	}
+
+	runes := suspiciousRunes()
+	spans := unicodeSpans(ctx)
+	content := ctx.File.Content
+	var diags []lint.Diagnostic
+	prev := rune(0)
+
+	for offset := 0; offset < len(content); {
+		char, size := utf8.DecodeRune(content[offset:])
+		start := offset
+		offset += size
+
+		info, ok := runes[char]
+		last := prev
+		prev = char
+		if !ok {
+			continue
+		}
+		if ctx.Cancelled() {
+			return diags, ctx.Ctx.Err()
+		}
+
+		// A byte order mark at the start of the file is checked by MDL015.
+		if char == '\ufeff' && start == 0 {
+			continue
+		}
+		// Joiners after non-ASCII characters build emoji sequences and
+		// join letters in scripts such as Persian and Devanagari.
+		if (char == '\u200c' || char == '\u200d') && last >= utf8.RuneSelf {
+			if _, suspicious := runes[last]; !suspicious {
+				continue
+			}
+		}
+
+		where := unicodeContextAt(ctx, spans, start)
+		if !slices.Contains(checked[where], string(info.category)) {
+			continue
+		}
+		diags = append(diags, r.diagnostic(ctx, start, start+size, char, info, where))
+	}
+
+	return diags, nil
+}
+
+// diagnostic reports the character at [start, end) with a fix that replaces
+// it with its ASCII equivalent or removes it.
+func (r *SuspiciousUnicodeRule) diagnostic(
+	ctx *lint.RuleContext, start, end int, char rune, info suspiciousRune, where UnicodeContext,
+) lint.Diagnostic {
+	startLine, startCol := ctx.File.LineAt(start)
+	endLine, endCol := ctx.File.LineAt(end)
+	pos := mdast.SourcePosition{StartLine: startLine, StartColumn: startCol, EndLine: endLine, EndColumn: endCol}
+
+	builder := fix.NewEditBuilder()
+	suggestion := "Remove the character"
+	if info.replacement != "" {
+		builder.ReplaceRange(start, end, info.replacement)
+		suggestion = fmt.Sprintf("Replace with %q", info.replacement)
+	} else {
+		builder.Delete(start, end)
+	}
+
+	msg := fmt.Sprintf("%s U+%04X %s in %s",
+		unicodeCategoryNames()[info.category], char, info.name, unicodeContextNames()[where])
+	diag := lint.NewDiagnosticAt(r.ID(), ctx.File.Path, pos, msg).
+		WithSeverity(config.SeverityWarning).
+		WithSuggestion(suggestion).
+		WithFix(builder)
+	if info.category == UnicodeBidi {
+		// Directional marks can be intentional in right-to-left text.
+		diag = diag.WithApplicability(lint.FixUnsafe)
+	}
+	return diag.Build()
+}
+
+// unicodeCategories returns the categories checked in context where, from
+// the option of that name. An empty list turns the checks off.
+func unicodeCategories(ctx *lint.RuleContext, where UnicodeContext, defaultValue []string) []string {
+	if list, ok := ctx.Option(string(where), nil).([]any); ok && len(list) == 0 {
+		return nil
+	}
+	return ctx.OptionStringSlice(string(where), defaultValue)
+}
+
+// unicodeContextAt returns the context of the byte at offset.
+func unicodeContextAt(ctx *lint.RuleContext, spans []unicodeSpan, offset int) UnicodeContext {
+	line, _ := ctx.File.LineAt(offset)
+	if ctx.IsLineInCodeBlock(line) {
+		return UnicodeInCodeBlock
+	}
+	for _, span := range spans {
+		if span.Contains(offset) {
+			return span.context
+		}
+	}
+	return UnicodeInProse
+}
+
+// unicodeSpans returns the code spans and link destinations of the file.
+func unicodeSpans(ctx *lint.RuleContext) []unicodeSpan {
+	var spans []unicodeSpan
+	for _, span := range ctx.CodeSpans() {
+		spans = append(spans, unicodeSpan{span.SourceRange(), UnicodeInCodeSpan})
+	}
+
+	// The range of a link or image is its text; an inline destination
+	// follows it in parentheses.
+	content := ctx.File.Content
+	for _, link := range append(slices.Clone(ctx.Links()), ctx.Images()...) {
+		if link.Inline == nil || link.Inline.Link == nil || link.Inline.Link.ReferenceStyle != mdast.RefStyleInline {
+			continue
+		}
+		text := link.SourceRange()
+		if text.IsEmpty() || !bytes.HasPrefix(content[text.EndOffset:], []byte("](")) {
+			continue
+		}
+		if dest, ok := scanLinkDestination(content, text.EndOffset+2, true); ok {
+			spans = append(spans, unicodeSpan{dest, UnicodeInLinkDestination})
+		}
+	}
+
+	if refCtx := ctx.RefContext(); refCtx != nil {
+		for _, def := range refCtx.AllDefinitions {
+			line := ctx.File.Lines[def.LineNumber-1]
+			colon := bytes.Index(content[line.StartOffset:line.NewlineStart], []byte("]:"))
+			if colon < 0 {
+				continue
+			}
+			if dest, ok := scanLinkDestination(content[:line.NewlineStart], line.StartOffset+colon+2, false); ok {
+				spans = append(spans, unicodeSpan{dest, UnicodeInLinkDestination})
+			}
+		}
+	}
+
+	return spans
+}
+
+// scanLinkDestination returns the range of the link destination that starts
+// at offset, after optional whitespace. In an inline link the destination
+// also ends at a closing parenthesis that does not match an opening one.
+func scanLinkDestination(content []byte, offset int, inline bool) (mdast.SourceRange, bool) {
+	for offset < len(content) && isASCIISpace(content[offset]) {
+		offset++
+	}
+	if offset >= len(content) {
+		return mdast.SourceRange{}, false
+	}
+
+	if content[offset] == '<' {
+		end := bytes.IndexAny(content[offset+1:], ">\n")
+		if end < 0 || content[offset+1+end] != '>' {
+			return mdast.SourceRange{}, false
+		}
+		return mdast.SourceRange{StartOffset: offset + 1, EndOffset: offset + 1 + end}, true
+	}
+
+	end := offset
+	depth := 0
+scan:
+	for ; end < len(content); end++ {
+		switch char := content[end]; {
+		case isASCIISpace(char):
+			break scan
+		case char == '\\':
+			end++
+		case inline && char == '(':
+			depth++
+		case inline && char == ')':
+			if depth == 0 {
+				break scan
+			}
+			depth--
+		}
+	}
+	end = min(end, len(content))
+	return mdast.SourceRange{StartOffset: offset, EndOffset: end}, end > offset
+}
+
+// isASCIISpace reports whether char is an ASCII space, tab or line ending.
+func isASCIISpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r'
+}
@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+)
+
+func TestSuspiciousUnicodeRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		options     map[string]any
+		wantDiags   int
+		wantMessage string
+		wantCol     int
+		wantFix     string
+	}{
+		{
+			name:      "plain ASCII",
+			input:     "# Title\n\nSome \"quoted\" text.\n",
+			wantDiags: 0,
+		},
+		{
+			name:        "no-break space in heading",
+			input:       "# Getting\u00a0started\n",
+			wantDiags:   1,
+			wantMessage: "Non-ASCII space U+00A0 NO-BREAK SPACE in prose",
+			wantCol:     10,
+			wantFix:     "# Getting started\n",
+		},
+		{
+			name:        "zero width space in code span",
+			input:       "Run `go\u200b test` now.\n",
+			wantDiags:   1,
+			wantMessage: "Zero-width character U+200B ZERO WIDTH SPACE in code span",
+			wantCol:     8,
+			wantFix:     "Run `go test` now.\n",
+		},
+		{
+			name:      "soft hyphen removed",
+			input:     "Docu\u00admentation\n",
+			wantDiags: 1,
+			wantFix:   "Documentation\n",
+		},
+		{
+			name:      "smart quotes allowed in prose",
+			input:     "It\u2019s \u201cfine\u201d here.\n",
+			wantDiags: 0,
+		},
+		{
+			name:        "smart quotes in code block",
+			input:       "```sh\necho \u201chello\u201d\n```\n",
+			wantDiags:   2,
+			wantMessage: "Smart quote U+201C LEFT DOUBLE QUOTATION MARK in code block",
+			wantCol:     6,
+			wantFix:     "```sh\necho \"hello\"\n```\n",
+		},
+		{
+			name:        "smart quote in link destination",
+			input:       "See [docs](https://example.com/it\u2019s).\n",
+			wantDiags:   1,
+			wantMessage: "Smart quote U+2019 RIGHT SINGLE QUOTATION MARK in link destination",
+			wantFix:     "See [docs](https://example.com/it's).\n",
+		},
+		{
+			name:        "zero width space in reference definition",
+			input:       "See [docs][d].\n\n[d]: https://example.com/a\u200bb\n",
+			wantDiags:   1,
+			wantMessage: "Zero-width character U+200B ZERO WIDTH SPACE in link destination",
+			wantCol:     27,
+		},
+		{
+			name:        "bidi override",
+			input:       "access = \"user\u202e \u2066// admin\u2069\u2066\"\n",
+			wantDiags:   4,
+			wantMessage: "Bidirectional control character U+202E RIGHT-TO-LEFT OVERRIDE in prose",
+			wantFix:     "access = \"user // admin\"\n",
+		},
+		{
+			name:      "emoji joiner allowed",
+			input:     "Team: \U0001f469\u200d\U0001f4bb\n",
+			wantDiags: 0,
+		},
+		{
+			name:      "joiner after ASCII reported",
+			input:     "ab\u200dcd\n",
+			wantDiags: 1,
+			wantFix:   "abcd\n",
+		},
+		{
+			name:      "leading byte order mark ignored",
+			input:     "\ufeff# Title\n",
+			wantDiags: 0,
+		},
+		{
+			name:        "byte order mark inside text",
+			input:       "# Title\n\nText\ufeff here\n",
+			wantDiags:   1,
+			wantMessage: "Zero-width character U+FEFF ZERO WIDTH NO-BREAK SPACE in prose",
+		},
+		{
+			name:      "smart quotes checked in prose by option",
+			input:     "It\u2019s fine.\n",
+			options:   map[string]any{"prose": []any{"smart-quote"}},
+			wantDiags: 1,
+			wantFix:   "It's fine.\n",
+		},
+		{
+			name:      "code block checks turned off",
+			input:     "```\na\u00a0b\n```\n",
+			options:   map[string]any{"code_block": []any{}},
+			wantDiags: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ruleCfg *config.RuleConfig
+			if tt.options != nil {
+				ruleCfg = &config.RuleConfig{Options: tt.options}
+			}
+
+			diags := applyFlavorRule(t, NewSuspiciousUnicodeRule(), config.FlavorCommonMark, "test.md", tt.input, ruleCfg)
+			require.Len(t, diags, tt.wantDiags)
+			if tt.wantDiags == 0 {
+				return
+			}
+
+			if tt.wantMessage != "" {
+				assert.Equal(t, tt.wantMessage, diags[0].Message)
+			}
+			if tt.wantCol != 0 {
+				assert.Equal(t, tt.wantCol, diags[0].StartColumn)
+			}
+			if tt.wantFix == "" {
+				return
+			}
+
+			var allEdits []fix.TextEdit
+			for _, d := range diags {
+				allEdits = append(allEdits, d.FixEdits...)
+			}
+			prepared, err := fix.PrepareEdits(allEdits, len(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFix, string(fix.ApplyEdits([]byte(tt.input), prepared)))
+		})
+	}
+}
+
+func TestSuspiciousUnicodeRule_BidiFixIsUnsafe(t *testing.T) {
+	diags := applyFlavorRule(t, NewSuspiciousUnicodeRule(), config.FlavorCommonMark, "test.md",
+		"a\u200fb and c\u00a0d\n", nil)
+	require.Len(t, diags, 2)
+	assert.Equal(t, lint.FixUnsafe, diags[0].Applicability)
+	assert.Empty(t, diags[1].Applicability)
+}
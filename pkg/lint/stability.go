@@ -0,0 +1,143 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
+)
+
+// FixInstability is a way in which fixes fail to settle.
+type FixInstability string
+
+const (
+	// FixCycles means the fixes of the rules undo each other, so the fix
+	// loop keeps returning to the same content.
+	FixCycles FixInstability = "cycle"
+
+	// FixDoesNotConverge means the rules still produce edits when the fix
+	// loop runs out of passes.
+	FixDoesNotConverge FixInstability = "not-converging"
+
+	// FixNotIdempotent means fixing the output of the rule's own fixes
+	// changes it again.
+	FixNotIdempotent FixInstability = "not-idempotent"
+)
+
+// UnstableFix reports rules whose fixes do not settle on a file.
+type UnstableFix struct {
+	Kind FixInstability
+
+	// Rules lists the IDs of the rules involved, sorted.
+	Rules []string
+
+	// Line is the 1-based line of the content where the instability shows.
+	Line int
+}
+
+// CheckFileFixStability reads the file at path and checks its fixes as
+// CheckFixStability does, on the text the pipeline would lint. A file the
+// encoding policy skips has nothing to check.
+func (p *Pipeline) CheckFileFixStability(ctx context.Context, path string, cfg *config.Config) ([]UnstableFix, error) {
+	content, info, err := fsutil.ReadFile(ctx, path)
+	if err != nil {
+		return nil, categorizeError(err)
+	}
+
+	text := decodeFile(path, content, info.Encoding, cfg)
+	if text.skipReason != "" {
+		return nil, nil
+	}
+	return p.CheckFixStability(ctx, path, text.content, cfg)
+}
+
+// CheckFixStability fixes content in memory and reports the fixes that do
+// not settle: rules whose fixes undo each other or keep producing edits in
+// the fix loop, and rules whose fixes are not idempotent, meaning that
+// linting the output of a rule's fixes yields new fixes from the same rule.
+// Nothing is written.
+func (p *Pipeline) CheckFixStability(ctx context.Context, path string, content []byte, cfg *config.Config) ([]UnstableFix, error) {
+	// Enable fixing on a shallow copy; the shared fields are only read.
+	fixCfg := config.NewConfig()
+	if cfg != nil {
+		copied := *cfg
+		fixCfg = &copied
+	}
+	fixCfg.Fix = true
+	opts := PipelineOptionsFromConfig(fixCfg)
+	opts.Fix = true
+	opts.DryRun = false
+
+	var unstable []UnstableFix
+
+	// The fix loop as a whole.
+	loop, err := p.ProcessContent(ctx, path, content, fixCfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case loop.Cycle != nil:
+		unstable = append(unstable, UnstableFix{Kind: FixCycles, Rules: loop.Cycle.Rules, Line: loop.Cycle.StartLine})
+	case loop.Exhausted:
+		unstable = append(unstable, UnstableFix{Kind: FixDoesNotConverge, Rules: loop.RemainingRules, Line: editLine(loop.FileResult, loop.Edits)})
+	}
+
+	// Each rule's fixes on their own: fix once, then lint the output again.
+	first, err := p.Engine.LintFile(ctx, path, content, fixCfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParseFailure, err)
+	}
+	unsafe := unsafeFixesEnabled(fixCfg)
+	style := fix.DetectTextStyle(content)
+	for _, ruleID := range editRules(first.Edits) {
+		edits, complete := ruleEdits(first, ruleID, unsafe, len(content))
+		if !complete {
+			// The rule's own fixes overlap, so a second round is expected.
+			continue
+		}
+
+		// Apply the edits as the fix loop does, keeping the line endings
+		// and byte order mark of the file.
+		ruleStyle := style
+		fixed, _ := p.applyEdits(content, edits, &ruleStyle)
+		second, err := p.Engine.LintFile(ctx, path, fixed, fixCfg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParseFailure, err)
+		}
+		if again, _ := ruleEdits(second, ruleID, unsafe, len(fixed)); len(again) > 0 {
+			unstable = append(unstable, UnstableFix{Kind: FixNotIdempotent, Rules: []string{ruleID}, Line: editLine(second, again)})
+		}
+	}
+
+	return unstable, nil
+}
+
+// ruleEdits returns the enabled fix edits of the diagnostics of ruleID in
+// fileResult, without the ones that overlap. complete is false if some
+// overlapped.
+func ruleEdits(fileResult *FileResult, ruleID string, unsafe bool, contentLen int) (edits []fix.TextEdit, complete bool) {
+	var proposed []fix.TextEdit
+	for _, diag := range fileResult.Diagnostics {
+		if diag.RuleID == ruleID && diag.Applicability.Enabled(unsafe) {
+			proposed = append(proposed, diag.FixEdits...)
+		}
+	}
+	accepted, skipped, _, err := fix.PrepareEditsFiltered(slices.Clone(proposed), contentLen)
+	if err != nil {
+		return nil, false
+	}
+	return accepted, len(skipped) == 0
+}
+
+// editLine returns the line of the first of edits in the content of
+// fileResult, or 0.
+func editLine(fileResult *FileResult, edits []fix.TextEdit) int {
+	if len(edits) == 0 || fileResult == nil || fileResult.Snapshot == nil {
+		return 0
+	}
+	line, _ := fileResult.Snapshot.LineAt(edits[0].StartOffset)
+	return line
+}
@@ -0,0 +1,62 @@
+package lint_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+// checkFileStability writes content to a file and checks the stability of
+// the fixes of rules on it.
+func checkFileStability(t *testing.T, content []byte, cfg *config.Config, rules ...lint.Rule) []lint.UnstableFix {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.md")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	registry := lint.NewRegistry()
+	for _, rule := range rules {
+		registry.Register(rule)
+	}
+	pipeline := lint.NewPipeline(lint.NewEngine(goldmark.New(goldmark.FlavorCommonMark), registry))
+
+	issues, err := pipeline.CheckFileFixStability(context.Background(), path, cfg)
+	if err != nil {
+		t.Fatalf("CheckFileFixStability() error = %v", err)
+	}
+	return issues
+}
+
+func TestCheckFileFixStability_DecodesFile(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.NewConfig()
+	cfg.Encoding = config.EncodingTranscode
+	issues := checkFileStability(t, utf16LE("# Title\r\n\r\nSome foo.\r\n"), cfg,
+		newReplaceRule("TEST001", "foo", "bar"), newReplaceRule("TEST002", "bar", "foo"))
+
+	if len(issues) == 0 || issues[0].Kind != lint.FixCycles {
+		t.Fatalf("issues = %+v, want a cycle first", issues)
+	}
+	if got := issues[0].Rules; len(got) != 2 || got[0] != "TEST001" || got[1] != "TEST002" {
+		t.Errorf("cycle rules = %v, want TEST001 and TEST002", got)
+	}
+}
+
+func TestCheckFileFixStability_SkippedFile(t *testing.T) {
+	t.Parallel()
+
+	issues := checkFileStability(t, utf16LE("Some foo.\r\n"), config.NewConfig(),
+		newReplaceRule("TEST001", "foo", "bar"), newReplaceRule("TEST002", "bar", "foo"))
+
+	if len(issues) != 0 {
+		t.Errorf("issues = %+v, want none for a file the encoding policy skips", issues)
+	}
+}
@@ -72,8 +72,12 @@ func (f *FileSnapshot) LineAt(offset int) (int, int) {
 
 	lineInfo := f.Lines[lineIdx]
 
-	// Verify offset is within this line.
+	// Verify offset is within this line. Offsets inside a byte order mark
+	// before the first line belong to its first column.
 	if offset < lineInfo.StartOffset {
+		if lineIdx == 0 {
+			return 1, 1
+		}
 		return 0, 0
 	}
 
@@ -1,5 +1,10 @@
 package mdast
 
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
 // SourceRange represents a byte range in the source content.
 type SourceRange struct {
 	// StartOffset is the byte index where the range begins (inclusive).
@@ -122,3 +127,115 @@ func (n *Node) Text() []byte {
 
 	return n.File.Content[r.StartOffset:r.EndOffset]
 }
+
+// PositionEncoding is the unit in which columns and character offsets count
+// text. Positions in this package count UTF-8 bytes; consumers such as
+// editors and CI systems may expect another encoding, and converting needs
+// the text of the line.
+type PositionEncoding string
+
+// Position encodings, named as in the Language Server Protocol.
+const (
+	// PositionEncodingUTF8 counts UTF-8 bytes.
+	PositionEncodingUTF8 PositionEncoding = "utf-8"
+
+	// PositionEncodingUTF16 counts UTF-16 code units, as LSP (by default),
+	// SARIF and JavaScript do.
+	PositionEncodingUTF16 PositionEncoding = "utf-16"
+
+	// PositionEncodingUTF32 counts Unicode code points.
+	PositionEncodingUTF32 PositionEncoding = "utf-32"
+)
+
+// ParsePositionEncoding parses an encoding name. The empty string is UTF-8.
+func ParsePositionEncoding(s string) (PositionEncoding, error) {
+	if s == "" {
+		return PositionEncodingUTF8, nil
+	}
+	encoding := PositionEncoding(s)
+	if !encoding.IsValid() {
+		return "", fmt.Errorf("unknown position encoding %q (valid: utf-8, utf-16, utf-32)", s)
+	}
+	return encoding, nil
+}
+
+// IsValid returns true if e is a known encoding.
+func (e PositionEncoding) IsValid() bool {
+	switch e {
+	case PositionEncodingUTF8, PositionEncodingUTF16, PositionEncodingUTF32:
+		return true
+	default:
+		return false
+	}
+}
+
+// Len returns the length of b in units of e. Invalid UTF-8 bytes count as
+// one unit each, like U+FFFD.
+func (e PositionEncoding) Len(b []byte) int {
+	switch e {
+	case PositionEncodingUTF16:
+		n := 0
+		for len(b) > 0 {
+			r, size := utf8.DecodeRune(b)
+			if r >= 0x10000 {
+				n += 2
+			} else {
+				n++
+			}
+			b = b[size:]
+		}
+		return n
+	case PositionEncodingUTF32:
+		return utf8.RuneCount(b)
+	default:
+		return len(b)
+	}
+}
+
+// Column converts a 1-based byte column on line to a 1-based column in
+// units of e. Columns past the end of the line, such as the position after
+// its line ending, stay the same distance past it. Columns below 1 are
+// returned unchanged.
+func (e PositionEncoding) Column(line []byte, byteColumn int) int {
+	if byteColumn < 1 {
+		return byteColumn
+	}
+	end := min(byteColumn-1, len(line))
+	return e.Len(line[:end]) + 1 + byteColumn - 1 - end
+}
+
+// ByteColumn converts a 1-based column in units of e on line back to a
+// 1-based byte column. It is the inverse of Column; a column inside a
+// character, such as the second half of a surrogate pair, maps to the start
+// of the character.
+func (e PositionEncoding) ByteColumn(line []byte, column int) int {
+	if column < 1 {
+		return column
+	}
+
+	units, offset := 0, 0
+	for offset < len(line) && units < column-1 {
+		_, size := utf8.DecodeRune(line[offset:])
+		next := units + e.Len(line[offset:offset+size])
+		if next > column-1 {
+			break
+		}
+		units = next
+		offset += size
+	}
+	if offset == len(line) {
+		offset += column - 1 - units
+	}
+	return offset + 1
+}
+
+// PositionIn converts a byte offset to a 1-based line and a 1-based column
+// in units of e. It returns an invalid position if the offset is out of
+// range.
+func (f *FileSnapshot) PositionIn(offset int, e PositionEncoding) Position {
+	line, col := f.LineAt(offset)
+	if line == 0 {
+		return Position{}
+	}
+	return Position{Line: line, Column: e.Column(f.LineContent(line), col)}
+}
@@ -0,0 +1,121 @@
+package mdast_test
+
+import (
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+func TestParsePositionEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"", "utf-8", "utf-16", "utf-32"} {
+		encoding, err := mdast.ParsePositionEncoding(name)
+		if err != nil {
+			t.Errorf("ParsePositionEncoding(%q) error = %v", name, err)
+		}
+		if !encoding.IsValid() {
+			t.Errorf("ParsePositionEncoding(%q) = %q, not valid", name, encoding)
+		}
+	}
+
+	if _, err := mdast.ParsePositionEncoding("latin1"); err == nil {
+		t.Error("ParsePositionEncoding(latin1) succeeded, want error")
+	}
+}
+
+func TestPositionEncoding_Column(t *testing.T) {
+	t.Parallel()
+
+	// "é" is 2 bytes and 1 unit; "😀" is 4 bytes, 2 UTF-16 units and
+	// 1 code point.
+	line := []byte("é😀x")
+
+	tests := []struct {
+		name       string
+		encoding   mdast.PositionEncoding
+		byteColumn int
+		want       int
+	}{
+		{"utf-8 unchanged", mdast.PositionEncodingUTF8, 7, 7},
+		{"utf-16 start", mdast.PositionEncodingUTF16, 1, 1},
+		{"utf-16 after é", mdast.PositionEncodingUTF16, 3, 2},
+		{"utf-16 after emoji", mdast.PositionEncodingUTF16, 7, 4},
+		{"utf-16 end of line", mdast.PositionEncodingUTF16, 8, 5},
+		{"utf-16 past end", mdast.PositionEncodingUTF16, 10, 7},
+		{"utf-32 after emoji", mdast.PositionEncodingUTF32, 7, 3},
+		{"utf-32 end of line", mdast.PositionEncodingUTF32, 8, 4},
+		{"zero stays zero", mdast.PositionEncodingUTF16, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.encoding.Column(line, tt.byteColumn)
+			if got != tt.want {
+				t.Errorf("Column(%d) = %d, want %d", tt.byteColumn, got, tt.want)
+			}
+			if back := tt.encoding.ByteColumn(line, got); back != tt.byteColumn {
+				t.Errorf("ByteColumn(%d) = %d, want %d", got, back, tt.byteColumn)
+			}
+		})
+	}
+}
+
+func TestPositionEncoding_ByteColumnInsideCharacter(t *testing.T) {
+	t.Parallel()
+
+	// Column 3 is the second UTF-16 unit of the emoji.
+	line := []byte("a😀b")
+	if got := mdast.PositionEncodingUTF16.ByteColumn(line, 3); got != 2 {
+		t.Errorf("ByteColumn(3) = %d, want 2", got)
+	}
+}
+
+func TestPositionEncoding_Len(t *testing.T) {
+	t.Parallel()
+
+	text := []byte("é😀x\xff")
+	tests := []struct {
+		encoding mdast.PositionEncoding
+		want     int
+	}{
+		{mdast.PositionEncodingUTF8, 8},
+		{mdast.PositionEncodingUTF16, 5},
+		{mdast.PositionEncodingUTF32, 4},
+	}
+
+	for _, tt := range tests {
+		if got := tt.encoding.Len(text); got != tt.want {
+			t.Errorf("%s Len = %d, want %d", tt.encoding, got, tt.want)
+		}
+	}
+}
+
+func TestFileSnapshot_PositionIn(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("# T\n\n😀 x\n")
+	snapshot := mdast.NewFileSnapshot("test.md", content)
+	offset := len("# T\n\n😀 ")
+
+	tests := []struct {
+		encoding mdast.PositionEncoding
+		want     mdast.Position
+	}{
+		{mdast.PositionEncodingUTF8, mdast.Position{Line: 3, Column: 6}},
+		{mdast.PositionEncodingUTF16, mdast.Position{Line: 3, Column: 4}},
+		{mdast.PositionEncodingUTF32, mdast.Position{Line: 3, Column: 3}},
+	}
+
+	for _, tt := range tests {
+		if got := snapshot.PositionIn(offset, tt.encoding); got != tt.want {
+			t.Errorf("%s PositionIn = %+v, want %+v", tt.encoding, got, tt.want)
+		}
+	}
+
+	if got := snapshot.PositionIn(-1, mdast.PositionEncodingUTF16); got.IsValid() {
+		t.Errorf("PositionIn(-1) = %+v, want invalid", got)
+	}
+}
@@ -26,7 +26,8 @@ type FileSnapshot struct {
 
 // LineInfo holds metadata for a single line in a file.
 type LineInfo struct {
-	// StartOffset is the byte index of the line start.
+	// StartOffset is the byte index of the line start. The first line
+	// starts after any byte order mark.
 	StartOffset int
 
 	// NewlineStart is the byte index where newline characters begin.
@@ -0,0 +1,80 @@
+package orphans
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mkdocsDefaultDocsDir is the MkDocs default for docs_dir.
+const mkdocsDefaultDocsDir = "docs"
+
+// mkdocsConfig is the subset of mkdocs.yml needed to find entry points.
+type mkdocsConfig struct {
+	DocsDir string `yaml:"docs_dir"`
+	Nav     any    `yaml:"nav"`
+}
+
+// mkdocsEntryPoints returns the pages listed in the nav of an mkdocs.yml
+// file, plus the site home page (index.md or README.md in docs_dir).
+func mkdocsEntryPoints(configPath string) ([]string, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("read mkdocs config: %w", err)
+	}
+
+	var cfg mkdocsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse mkdocs config %s: %w", configPath, err)
+	}
+
+	docsDir := cfg.DocsDir
+	if docsDir == "" {
+		docsDir = mkdocsDefaultDocsDir
+	}
+	if !filepath.IsAbs(docsDir) {
+		docsDir = filepath.Join(filepath.Dir(configPath), docsDir)
+	}
+
+	var pages []string
+	for _, home := range []string{"index.md", "README.md"} {
+		page := filepath.Join(docsDir, home)
+		if _, err := os.Stat(page); err == nil {
+			pages = append(pages, page)
+			break
+		}
+	}
+
+	for _, entry := range navEntries(cfg.Nav) {
+		if hasScheme(entry) {
+			continue
+		}
+		page := filepath.Join(docsDir, filepath.FromSlash(entry))
+		if info, err := os.Stat(page); err == nil && !info.IsDir() {
+			pages = append(pages, page)
+		}
+	}
+
+	return pages, nil
+}
+
+// navEntries flattens an MkDocs nav tree into page paths. Nav items are
+// either paths or single-key maps from a title to a path or nested nav.
+func navEntries(nav any) []string {
+	var entries []string
+	switch v := nav.(type) {
+	case string:
+		entries = append(entries, v)
+	case []any:
+		for _, item := range v {
+			entries = append(entries, navEntries(item)...)
+		}
+	case map[string]any:
+		for _, item := range v {
+			entries = append(entries, navEntries(item)...)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,445 @@
+// Package orphans finds Markdown pages that cannot be reached by following
+// links from a set of entry points, and asset files that no page references.
+package orphans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/lint/refs"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// ErrNoEntryPoints is returned when none of the entry points exist.
+var ErrNoEntryPoints = errors.New("no entry points found")
+
+// defaultEntryPoints are used when Options.EntryPoints is empty.
+//
+//nolint:gochecknoglobals // Read-only default.
+var defaultEntryPoints = []string{"README.md", "index.md", "mkdocs.yml"}
+
+// defaultAssetExtensions are used when Options.AssetExtensions is empty.
+//
+//nolint:gochecknoglobals // Read-only default.
+var defaultAssetExtensions = []string{
+	".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".avif", ".bmp", ".ico",
+}
+
+// DefaultAssetExtensions returns the file extensions treated as assets by default.
+func DefaultAssetExtensions() []string {
+	return slices.Clone(defaultAssetExtensions)
+}
+
+// Options controls an orphans analysis.
+type Options struct {
+	// Paths are the files or directories scanned for pages.
+	// If empty, defaults to the working directory.
+	Paths []string
+
+	// WorkingDir is the base directory for relative paths, entry points,
+	// and site-absolute links ("/docs/page.md").
+	// If empty, the current process working directory is used.
+	WorkingDir string
+
+	// Extensions are the Markdown file extensions.
+	Extensions []string
+
+	// ExcludeGlobs are glob patterns for files and directories to skip.
+	ExcludeGlobs []string
+
+	// EntryPoints are pages, globs, or mkdocs.yml files (whose nav pages
+	// become entry points). Empty means README.md, index.md and mkdocs.yml,
+	// skipping those that do not exist.
+	EntryPoints []string
+
+	// AssetPaths are the directories searched for assets.
+	// Empty means Paths.
+	AssetPaths []string
+
+	// AssetExtensions are the file extensions treated as assets.
+	// Empty means DefaultAssetExtensions.
+	AssetExtensions []string
+
+	// Parser parses Markdown pages.
+	Parser lint.Parser
+
+	// Flavor enables flavor-specific links (Obsidian wikilinks and embeds).
+	Flavor config.Flavor
+}
+
+// Report is the outcome of an orphans analysis. Paths are relative to the
+// working directory and use forward slashes.
+type Report struct {
+	// EntryPoints are the pages reachability started from.
+	EntryPoints []string `json:"entry_points"`
+
+	// Pages is the number of pages scanned.
+	Pages int `json:"pages"`
+
+	// Assets is the number of asset files scanned.
+	Assets int `json:"assets"`
+
+	// OrphanedPages are pages not reachable from any entry point.
+	OrphanedPages []string `json:"orphaned_pages"`
+
+	// UnusedAssets are assets not referenced by any page.
+	UnusedAssets []string `json:"unused_assets"`
+}
+
+// Analyze scans pages and assets and reports orphaned pages and unused assets.
+func Analyze(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Parser == nil {
+		return nil, errors.New("orphans: parser is required")
+	}
+
+	workDir := opts.WorkingDir
+	if workDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("get working directory: %w", err)
+		}
+		workDir = wd
+	}
+	workDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve working directory: %w", err)
+	}
+
+	pages, err := runner.Discover(ctx, runner.Options{
+		Paths:        opts.Paths,
+		WorkingDir:   workDir,
+		Extensions:   opts.Extensions,
+		ExcludeGlobs: opts.ExcludeGlobs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discover pages: %w", err)
+	}
+
+	entries, err := resolveEntryPoints(workDir, opts.EntryPoints, pages)
+	if err != nil {
+		return nil, err
+	}
+
+	assetPaths := opts.AssetPaths
+	if len(assetPaths) == 0 {
+		assetPaths = opts.Paths
+	}
+	assetExtensions := opts.AssetExtensions
+	if len(assetExtensions) == 0 {
+		assetExtensions = defaultAssetExtensions
+	}
+	assets, err := runner.Discover(ctx, runner.Options{
+		Paths:        assetPaths,
+		WorkingDir:   workDir,
+		Extensions:   assetExtensions,
+		ExcludeGlobs: opts.ExcludeGlobs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discover assets: %w", err)
+	}
+
+	graph := &linkGraph{
+		workDir:    workDir,
+		extensions: opts.Extensions,
+		flavor:     opts.Flavor,
+		links:      make(map[string][]string),
+	}
+	graph.indexNames(pages, assets)
+
+	// Entry points may live outside the scanned paths (a root README linking
+	// into docs/), so they are parsed too.
+	toParse := slices.Clone(pages)
+	for _, entry := range entries {
+		if !slices.Contains(toParse, entry) {
+			toParse = append(toParse, entry)
+		}
+	}
+	for _, page := range toParse {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("orphans cancelled: %w", err)
+		}
+		if err := graph.addPage(ctx, opts.Parser, page); err != nil {
+			return nil, err
+		}
+	}
+
+	reached := graph.reachable(entries)
+	referenced := make(map[string]bool)
+	for _, targets := range graph.links {
+		for _, target := range targets {
+			referenced[target] = true
+		}
+	}
+
+	report := &Report{
+		Pages:         len(pages),
+		Assets:        len(assets),
+		EntryPoints:   make([]string, 0, len(entries)),
+		OrphanedPages: []string{},
+		UnusedAssets:  []string{},
+	}
+	for _, entry := range entries {
+		report.EntryPoints = append(report.EntryPoints, relPath(workDir, entry))
+	}
+	for _, page := range pages {
+		if !reached[page] {
+			report.OrphanedPages = append(report.OrphanedPages, relPath(workDir, page))
+		}
+	}
+	for _, asset := range assets {
+		if !referenced[asset] {
+			report.UnusedAssets = append(report.UnusedAssets, relPath(workDir, asset))
+		}
+	}
+
+	return report, nil
+}
+
+// resolveEntryPoints expands entry points to absolute page paths.
+func resolveEntryPoints(workDir string, entryPoints, pages []string) ([]string, error) {
+	explicit := len(entryPoints) > 0
+	if !explicit {
+		entryPoints = defaultEntryPoints
+	}
+
+	var entries []string
+	add := func(page string) {
+		if !slices.Contains(entries, page) {
+			entries = append(entries, page)
+		}
+	}
+
+	for _, entry := range entryPoints {
+		var matched []string
+
+		switch ext := strings.ToLower(filepath.Ext(entry)); {
+		case ext == ".yml" || ext == ".yaml":
+			navPages, err := mkdocsEntryPoints(absPath(workDir, entry))
+			if err != nil && !(errors.Is(err, os.ErrNotExist) && !explicit) {
+				return nil, err
+			}
+			matched = navPages
+		case strings.ContainsAny(entry, "*?["):
+			for _, page := range pages {
+				if ok, _ := path.Match(entry, relPath(workDir, page)); ok {
+					matched = append(matched, page)
+				}
+			}
+		default:
+			page := absPath(workDir, entry)
+			if info, err := os.Stat(page); err == nil && !info.IsDir() {
+				matched = append(matched, page)
+			}
+		}
+
+		if len(matched) == 0 && explicit {
+			return nil, fmt.Errorf("entry point %q matches no page", entry)
+		}
+		for _, page := range matched {
+			add(page)
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, ErrNoEntryPoints
+	}
+	return entries, nil
+}
+
+// absPath resolves p against workDir unless it is already absolute.
+func absPath(workDir, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(workDir, p)
+}
+
+// htmlRefPattern matches src and href attributes in raw HTML.
+var htmlRefPattern = regexp.MustCompile(`(?i)\b(?:src|href)\s*=\s*["']([^"']+)["']`)
+
+// linkGraph records the local link targets of each page.
+type linkGraph struct {
+	workDir    string
+	extensions []string
+	flavor     config.Flavor
+
+	// links maps a page to the absolute paths of the files it links to.
+	links map[string][]string
+
+	// names maps lowercase base names (with and without the Markdown
+	// extension) to files, for wikilink resolution.
+	names map[string]string
+}
+
+// indexNames records page and asset base names for wikilink resolution.
+func (g *linkGraph) indexNames(pages, assets []string) {
+	g.names = make(map[string]string, len(pages)+len(assets))
+	for _, file := range slices.Concat(pages, assets) {
+		name := strings.ToLower(filepath.Base(file))
+		g.names[name] = file
+		g.names[strings.TrimSuffix(name, filepath.Ext(name))] = file
+	}
+}
+
+// addPage parses a page and records its link targets.
+func (g *linkGraph) addPage(ctx context.Context, parser lint.Parser, page string) error {
+	content, err := os.ReadFile(page)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", page, err)
+	}
+
+	snapshot, err := parser.Parse(ctx, page, content)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", page, err)
+	}
+
+	var destinations []string
+	refCtx := refs.Collect(snapshot.Root, snapshot)
+	for _, usage := range refCtx.Usages {
+		destinations = append(destinations, usage.Destination)
+	}
+	for _, def := range refCtx.AllDefinitions {
+		destinations = append(destinations, def.Destination)
+	}
+	destinations = append(destinations, htmlDestinations(snapshot)...)
+
+	var targets []string
+	for _, dest := range destinations {
+		if target := g.resolve(page, dest); target != "" {
+			targets = append(targets, target)
+		}
+	}
+
+	if g.flavor == config.FlavorObsidian {
+		for _, link := range refs.CollectWikilinks(snapshot.Root, snapshot) {
+			if target, ok := g.names[strings.ToLower(path.Base(link.Target))]; ok {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	g.links[page] = targets
+	return nil
+}
+
+// htmlDestinations returns src and href values from raw HTML in a page.
+func htmlDestinations(snapshot *mdast.FileSnapshot) []string {
+	var dests []string
+	_ = mdast.Walk(snapshot.Root, func(n *mdast.Node) error { //nolint:errcheck // visitor never returns error
+		if n.Kind != mdast.NodeHTMLBlock && n.Kind != mdast.NodeHTMLInline {
+			return nil
+		}
+		pos := n.SourcePosition()
+		if !pos.IsValid() {
+			return nil
+		}
+		for line := pos.StartLine; line <= pos.EndLine; line++ {
+			for _, match := range htmlRefPattern.FindAllSubmatch(lint.LineContent(snapshot, line), -1) {
+				dests = append(dests, string(match[1]))
+			}
+		}
+		return nil
+	})
+	return dests
+}
+
+// resolve maps a link destination in page to an existing local file, or "".
+func (g *linkGraph) resolve(page, dest string) string {
+	dest = strings.TrimSpace(dest)
+	if dest == "" || strings.HasPrefix(dest, "#") || strings.HasPrefix(dest, "//") || hasScheme(dest) {
+		return ""
+	}
+	if idx := strings.IndexAny(dest, "?#"); idx >= 0 {
+		dest = dest[:idx]
+	}
+	dest = unescapePath(dest)
+
+	var target string
+	if strings.HasPrefix(dest, "/") {
+		target = filepath.Join(g.workDir, filepath.FromSlash(dest))
+	} else {
+		target = filepath.Join(filepath.Dir(page), filepath.FromSlash(dest))
+	}
+
+	for _, candidate := range g.candidates(target) {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// candidates lists the files a link target may refer to: the file itself,
+// the Markdown source of an extensionless or .html link, and the index page
+// of a directory.
+func (g *linkGraph) candidates(target string) []string {
+	candidates := []string{target}
+
+	base := strings.TrimSuffix(target, ".html")
+	if filepath.Ext(target) == "" || base != target {
+		for _, ext := range g.extensions {
+			candidates = append(candidates, base+ext)
+		}
+	}
+	for _, index := range []string{"index", "README"} {
+		for _, ext := range g.extensions {
+			candidates = append(candidates, filepath.Join(target, index+ext))
+		}
+	}
+
+	return candidates
+}
+
+// reachable returns the set of pages reachable from entries.
+func (g *linkGraph) reachable(entries []string) map[string]bool {
+	reached := make(map[string]bool, len(g.links))
+	queue := slices.Clone(entries)
+	for len(queue) > 0 {
+		page := queue[0]
+		queue = queue[1:]
+		if reached[page] {
+			continue
+		}
+		reached[page] = true
+		queue = append(queue, g.links[page]...)
+	}
+	return reached
+}
+
+// schemePattern matches a URL scheme such as "https:" or "mailto:".
+var schemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// hasScheme reports whether dest is an absolute URL.
+func hasScheme(dest string) bool {
+	// A single letter followed by ':' is a Windows drive, not a scheme.
+	return schemePattern.MatchString(dest) && !(len(dest) > 1 && dest[1] == ':')
+}
+
+// unescapePath decodes percent-encoded characters, keeping the input on error.
+func unescapePath(dest string) string {
+	if unescaped, err := url.PathUnescape(dest); err == nil {
+		return unescaped
+	}
+	return dest
+}
+
+// relPath returns path relative to workDir with forward slashes, or the
+// absolute path if it lies outside workDir.
+func relPath(workDir, file string) string {
+	rel, err := filepath.Rel(workDir, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
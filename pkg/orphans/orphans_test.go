@@ -0,0 +1,180 @@
+package orphans
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/parser/goldmark"
+)
+
+// writeTree creates files under dir from a map of relative path to content.
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func analyze(t *testing.T, dir string, opts Options) *Report {
+	t.Helper()
+	opts.WorkingDir = dir
+	if opts.Extensions == nil {
+		opts.Extensions = config.FlavorCommonMark.FileExtensions()
+	}
+	if opts.Parser == nil {
+		flavor := opts.Flavor
+		if flavor == "" {
+			flavor = config.FlavorCommonMark
+		}
+		opts.Parser = goldmark.New(string(flavor))
+	}
+	report, err := Analyze(context.Background(), opts)
+	require.NoError(t, err)
+	return report
+}
+
+func TestAnalyze(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"README.md": "# Project\n\nSee the [docs](docs/).\n",
+		"docs/index.md": "# Docs\n\n" +
+			"- [Guide](guide)\n" +
+			"- [API][api]\n" +
+			"- [Site link](/docs/faq.html#top)\n" +
+			"- [External](https://example.com/missing.md)\n\n" +
+			"![Logo](../assets/logo.png)\n\n" +
+			"<img src=\"../assets/html%20image.svg\" alt=\"x\">\n\n" +
+			"[api]: reference/api.md\n",
+		"docs/guide.md":         "# Guide\n\nBack to [index](index.md#docs).\n",
+		"docs/reference/api.md": "# API\n",
+		"docs/faq.md":           "# FAQ\n",
+		"docs/old.md":           "# Old\n\n![Diagram](../assets/diagram.png)\n",
+		"assets/logo.png":       "png",
+		"assets/html image.svg": "svg",
+		"assets/diagram.png":    "png",
+		"assets/unused.gif":     "gif",
+		"assets/notes.txt":      "not an asset",
+	})
+
+	report := analyze(t, dir, Options{})
+
+	assert.Equal(t, []string{"README.md"}, report.EntryPoints)
+	assert.Equal(t, 6, report.Pages)
+	assert.Equal(t, 4, report.Assets)
+	assert.Equal(t, []string{"docs/old.md"}, report.OrphanedPages)
+	// An asset referenced only from an orphaned page is still referenced.
+	assert.Equal(t, []string{"assets/unused.gif"}, report.UnusedAssets)
+}
+
+func TestAnalyze_EntryPoints(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"README.md":      "# Project\n",
+		"docs/start.md":  "# Start\n\n[Next](next.md)\n",
+		"docs/next.md":   "# Next\n",
+		"docs/other.md":  "# Other\n",
+		"docs/a/home.md": "# Home\n",
+	})
+
+	t.Run("explicit page", func(t *testing.T) {
+		report := analyze(t, dir, Options{EntryPoints: []string{"docs/start.md"}})
+		assert.Equal(t, []string{"README.md", "docs/a/home.md", "docs/other.md"}, report.OrphanedPages)
+	})
+
+	t.Run("glob", func(t *testing.T) {
+		report := analyze(t, dir, Options{EntryPoints: []string{"docs/*.md"}})
+		assert.Equal(t, []string{"README.md", "docs/a/home.md"}, report.OrphanedPages)
+	})
+
+	t.Run("missing explicit entry point", func(t *testing.T) {
+		_, err := Analyze(context.Background(), Options{
+			WorkingDir:  dir,
+			Extensions:  []string{".md"},
+			EntryPoints: []string{"nope.md"},
+			Parser:      goldmark.New(string(config.FlavorCommonMark)),
+		})
+		require.ErrorContains(t, err, `entry point "nope.md" matches no page`)
+	})
+
+	t.Run("no default entry points", func(t *testing.T) {
+		_, err := Analyze(context.Background(), Options{
+			WorkingDir: dir,
+			Paths:      []string{"docs"},
+			Extensions: []string{".md"},
+			Parser:     goldmark.New(string(config.FlavorCommonMark)),
+		})
+		require.NoError(t, err) // README.md in the working directory
+	})
+}
+
+func TestAnalyze_MkDocsNav(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"mkdocs.yml": "site_name: Test\n" +
+			"docs_dir: site\n" +
+			"markdown_extensions:\n" +
+			"  - pymdownx.emoji:\n" +
+			"      emoji_index: !!python/name:material.extensions.emoji.twemoji\n" +
+			"nav:\n" +
+			"  - Home: index.md\n" +
+			"  - Guides:\n" +
+			"      - Install: guides/install.md\n" +
+			"      - guides/usage.md\n" +
+			"  - GitHub: https://github.com/example/project\n",
+		"site/index.md":          "# Home\n",
+		"site/guides/install.md": "# Install\n",
+		"site/guides/usage.md":   "# Usage\n",
+		"site/hidden.md":         "# Hidden\n",
+	})
+
+	report := analyze(t, dir, Options{Paths: []string{"site"}})
+
+	assert.Equal(t, []string{"site/index.md", "site/guides/install.md", "site/guides/usage.md"}, report.EntryPoints)
+	assert.Equal(t, []string{"site/hidden.md"}, report.OrphanedPages)
+}
+
+func TestAnalyze_ObsidianWikilinks(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"index.md":            "# Vault\n\n[[Daily Note]] and ![[chart.png]]\n",
+		"notes/Daily Note.md": "# Daily\n",
+		"notes/Stray.md":      "# Stray\n",
+		"files/chart.png":     "png",
+		"files/unused.png":    "png",
+	})
+
+	report := analyze(t, dir, Options{Flavor: config.FlavorObsidian})
+
+	assert.Equal(t, []string{"notes/Stray.md"}, report.OrphanedPages)
+	assert.Equal(t, []string{"files/unused.png"}, report.UnusedAssets)
+}
+
+func TestReport_Result(t *testing.T) {
+	report := &Report{
+		OrphanedPages: []string{"docs/old.md"},
+		UnusedAssets:  []string{"assets/unused.png"},
+	}
+
+	result := report.Result("/work")
+
+	require.Len(t, result.Files, 2)
+	assert.Equal(t, filepath.Join("/work", "docs", "old.md"), result.Files[0].Path)
+
+	diag := result.Files[0].Result.Diagnostics[0]
+	assert.Equal(t, RuleOrphanedPage, diag.RuleID)
+	assert.Equal(t, NameOrphanedPage, diag.RuleName)
+	assert.Equal(t, config.SeverityWarning, diag.Severity)
+	assert.Equal(t, RuleUnusedAsset, result.Files[1].Result.Diagnostics[0].RuleID)
+
+	assert.Equal(t, 2, result.Stats.DiagnosticsTotal)
+	assert.Equal(t, 2, result.Stats.FilesWithIssues)
+	assert.Equal(t, 2, result.Stats.DiagnosticsBySeverity["warning"])
+}
@@ -0,0 +1,62 @@
+package orphans
+
+import (
+	"path/filepath"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// Identifiers for orphans diagnostics. These are project-level checks run by
+// "gomdlint orphans" rather than rules in the registry.
+const (
+	RuleOrphanedPage = "MDL012"
+	NameOrphanedPage = "orphaned-page"
+	RuleUnusedAsset  = "MDL013"
+	NameUnusedAsset  = "unused-asset"
+)
+
+// Result converts the report into a runner result with one warning per
+// orphaned page and unused asset, so it can be rendered by any reporter.
+// workDir must be the working directory the report was produced with.
+func (r *Report) Result(workDir string) *runner.Result {
+	result := runner.NewResult()
+
+	for _, page := range r.OrphanedPages {
+		result.Add(outcome(workDir, page, RuleOrphanedPage, NameOrphanedPage,
+			"Page is not reachable from any entry point",
+			"Link to the page from a reachable page, or remove it"))
+	}
+	for _, asset := range r.UnusedAssets {
+		result.Add(outcome(workDir, asset, RuleUnusedAsset, NameUnusedAsset,
+			"Asset is not referenced by any page",
+			"Reference the asset from a page, or remove it"))
+	}
+
+	return result
+}
+
+// outcome builds a file outcome holding a single file-level diagnostic.
+func outcome(workDir, rel, ruleID, ruleName, message, suggestion string) runner.FileOutcome {
+	path := filepath.Join(workDir, filepath.FromSlash(rel))
+	if filepath.IsAbs(filepath.FromSlash(rel)) {
+		path = filepath.FromSlash(rel)
+	}
+
+	diag := lint.NewDiagnosticAt(ruleID, path,
+		mdast.SourcePosition{StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 1}, message).
+		WithSeverity(config.SeverityWarning).
+		WithSuggestion(suggestion).
+		Build()
+	diag.RuleName = ruleName
+
+	return runner.FileOutcome{
+		Path: path,
+		Result: &lint.PipelineResult{
+			Path:       path,
+			FileResult: &lint.FileResult{Diagnostics: []lint.Diagnostic{diag}},
+		},
+	}
+}
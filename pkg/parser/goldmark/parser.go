@@ -2,6 +2,7 @@
 package goldmark
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
 	"github.com/yuin/goldmark/text"
 )
 
@@ -17,8 +19,15 @@ import (
 const (
 	FlavorCommonMark = "commonmark"
 	FlavorGFM        = "gfm"
+	FlavorMDX        = "mdx"
+	FlavorObsidian   = "obsidian"
+	FlavorMkDocs     = "mkdocs"
+	FlavorPandoc     = "pandoc"
 )
 
+// utf8BOM is the UTF-8 encoding of the byte order mark.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
 // Parser implements lint.Parser using goldmark.
 type Parser struct {
 	flavor string
@@ -26,8 +35,8 @@ type Parser struct {
 }
 
 // New creates a new goldmark-based parser for the given flavor.
-// Supported flavors are "commonmark" and "gfm".
-// Invalid flavors default to "commonmark".
+// Supported flavors are "commonmark", "gfm", "mdx", "obsidian", "mkdocs"
+// and "pandoc". Invalid flavors default to "commonmark".
 func New(flavor string) *Parser {
 	f := flavorOrDefault(flavor)
 	return &Parser{
@@ -67,8 +76,14 @@ func (p *Parser) Parse(ctx context.Context, path string, content []byte) (*FileS
 		Lines:   mdast.BuildLines(content),
 	}
 
-	// Parse with goldmark.
+	// Parse with goldmark. A byte order mark is not content; skipping it
+	// keeps the node offsets relative to the whole file, and starting the
+	// first line after it keeps line-based rules from seeing it.
 	reader := text.NewReader(snapshot.Content)
+	if bytes.HasPrefix(snapshot.Content, utf8BOM) {
+		reader.Advance(len(utf8BOM))
+		snapshot.Lines[0].StartOffset = len(utf8BOM)
+	}
 	gmDoc := p.md.Parser().Parse(reader, parser.WithContext(parser.NewContext()))
 
 	// Check for cancellation after parsing.
@@ -98,13 +113,27 @@ func (p *Parser) Parse(ctx context.Context, path string, content []byte) (*FileS
 	return snapshot, nil
 }
 
+// Render converts Markdown content to HTML with the configuration Parse
+// uses. Raw HTML is kept.
+func (p *Parser) Render(ctx context.Context, content []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("render cancelled: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := p.md.Convert(bytes.TrimPrefix(content, utf8BOM), &out); err != nil {
+		return nil, fmt.Errorf("render: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
 // FileSnapshot is a type alias for mdast.FileSnapshot for convenience.
 type FileSnapshot = mdast.FileSnapshot
 
 // flavorOrDefault returns the flavor if valid, otherwise defaults to CommonMark.
 func flavorOrDefault(flavor string) string {
 	switch flavor {
-	case FlavorCommonMark, FlavorGFM:
+	case FlavorCommonMark, FlavorGFM, FlavorMDX, FlavorObsidian, FlavorMkDocs, FlavorPandoc:
 		return flavor
 	default:
 		return FlavorCommonMark
@@ -117,16 +146,51 @@ func newGoldmarkInstance(flavor string) goldmark.Markdown {
 
 	// Configure extensions based on flavor.
 	switch flavor {
-	case FlavorGFM:
+	case FlavorGFM, FlavorMDX:
+		// MDX has no goldmark extension; JSX tags already parse as raw HTML
+		// and flavor-aware rules treat them as components.
+		opts = append(opts,
+			goldmark.WithExtensions(
+				extension.GFM,
+			),
+		)
+	case FlavorObsidian:
+		// Wikilinks and embeds stay plain text; rules scan them from source.
 		opts = append(opts,
 			goldmark.WithExtensions(
 				extension.GFM,
+				extension.Footnote,
+			),
+		)
+	case FlavorMkDocs:
+		// Python-Markdown extensions commonly enabled by MkDocs themes:
+		// tables, footnotes, def_list and attr_list.
+		opts = append(opts,
+			goldmark.WithExtensions(
+				extension.Table,
+				extension.Footnote,
+				extension.DefinitionList,
 			),
+			goldmark.WithParserOptions(parser.WithAttribute()),
+		)
+	case FlavorPandoc:
+		// Pandoc's default Markdown extensions that goldmark can express.
+		opts = append(opts,
+			goldmark.WithExtensions(
+				extension.Table,
+				extension.Strikethrough,
+				extension.Footnote,
+				extension.DefinitionList,
+			),
+			goldmark.WithParserOptions(parser.WithAttribute()),
 		)
 	case FlavorCommonMark:
 		// No extensions for pure CommonMark.
 	}
 
+	// Keep raw HTML when rendering, so that changes to it are visible.
+	opts = append(opts, goldmark.WithRendererOptions(html.WithUnsafe()))
+
 	return goldmark.New(opts...)
 }
 
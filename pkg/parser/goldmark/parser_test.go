@@ -17,6 +17,10 @@ func TestParser_New(t *testing.T) {
 	}{
 		{"commonmark", FlavorCommonMark, FlavorCommonMark},
 		{"gfm", FlavorGFM, FlavorGFM},
+		{"mdx", FlavorMDX, FlavorMDX},
+		{"obsidian", FlavorObsidian, FlavorObsidian},
+		{"mkdocs", FlavorMkDocs, FlavorMkDocs},
+		{"pandoc", FlavorPandoc, FlavorPandoc},
 		{"invalid defaults to commonmark", "invalid", FlavorCommonMark},
 		{"empty defaults to commonmark", "", FlavorCommonMark},
 	}
@@ -241,6 +245,40 @@ https://example.com
 	}
 }
 
+func TestParser_Parse_Flavors(t *testing.T) {
+	tests := []struct {
+		flavor    string
+		content   string
+		wantTable bool
+	}{
+		{FlavorCommonMark, "| a | b |\n|---|---|\n| 1 | 2 |\n", false},
+		{FlavorMDX, "import X from './x'\n\n<X prop={1} />\n\n| a | b |\n|---|---|\n| 1 | 2 |\n", true},
+		{FlavorObsidian, "See [[Page]] and ![[img.png]].[^1]\n\n[^1]: Note.\n\n| a |\n|---|\n| 1 |\n", true},
+		{FlavorMkDocs, "!!! note\n\n    Body.\n\n## Title {#custom}\n\n| a |\n|---|\n| 1 |\n", true},
+		{FlavorPandoc, "::: note\nText\n:::\n\nTerm\n:   Definition\n\n| a |\n|---|\n| 1 |\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flavor, func(t *testing.T) {
+			snapshot, err := New(tt.flavor).Parse(context.Background(), "test.md", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if !mdast.ValidateTokens(snapshot.Tokens, len(snapshot.Content)) {
+				t.Error("tokens are not valid")
+			}
+
+			hasTable := mdast.FindFirst(snapshot.Root, func(n *mdast.Node) bool {
+				return n.Ext["table"] == true
+			}) != nil
+			if hasTable != tt.wantTable {
+				t.Errorf("table parsed = %v, want %v", hasTable, tt.wantTable)
+			}
+		})
+	}
+}
+
 func TestParser_Parse_PositionMapping(t *testing.T) {
 	parser := New(FlavorCommonMark)
 	ctx := context.Background()
@@ -279,6 +317,29 @@ func TestParser_Parse_PositionMapping(t *testing.T) {
 	}
 }
 
+func TestParser_Parse_ByteOrderMark(t *testing.T) {
+	parser := New(FlavorCommonMark)
+
+	snapshot, err := parser.Parse(context.Background(), "test.md", []byte("\ufeff# Heading\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	headings := mdast.FindByKind(snapshot.Root, mdast.NodeHeading)
+	if len(headings) != 1 {
+		t.Fatalf("got %d headings, want 1", len(headings))
+	}
+	if pos := headings[0].SourcePosition(); pos.StartLine != 1 {
+		t.Errorf("heading StartLine = %d, want 1", pos.StartLine)
+	}
+	if got := string(snapshot.LineContent(1)); got != "# Heading" {
+		t.Errorf("LineContent(1) = %q, want %q", got, "# Heading")
+	}
+	if line, col := snapshot.LineAt(0); line != 1 || col != 1 {
+		t.Errorf("LineAt(0) = %d:%d, want 1:1", line, col)
+	}
+}
+
 func TestParser_Parse_TokenRanges(t *testing.T) {
 	parser := New(FlavorCommonMark)
 	ctx := context.Background()
@@ -504,3 +565,41 @@ func countNodes(root *mdast.Node) int {
 	}
 	return count
 }
+
+func TestParser_Render(t *testing.T) {
+	var _ lint.Renderer = New(FlavorGFM)
+
+	tests := []struct {
+		name    string
+		flavor  string
+		content string
+		want    string
+	}{
+		{"heading", FlavorCommonMark, "# Title\n", "<h1>Title</h1>\n"},
+		{"raw html kept", FlavorCommonMark, "<div>x</div>\n", "<div>x</div>\n"},
+		{"gfm strikethrough", FlavorGFM, "~~old~~\n", "<p><del>old</del></p>\n"},
+		{"commonmark no strikethrough", FlavorCommonMark, "~~old~~\n", "<p>~~old~~</p>\n"},
+		{"byte order mark skipped", FlavorCommonMark, "\ufeff# Title\n", "<h1>Title</h1>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.flavor).Render(context.Background(), []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_Render_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := New(FlavorCommonMark).Render(ctx, []byte("# Hello")); err == nil {
+		t.Error("expected error for cancelled context")
+	}
+}
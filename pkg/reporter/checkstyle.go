@@ -0,0 +1,137 @@
+package reporter
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// checkstyleVersion is the Checkstyle report format version emitted.
+const checkstyleVersion = "8.0"
+
+// CheckstyleOutput is the root element of a Checkstyle XML report.
+type CheckstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []CheckstyleFile `xml:"file"`
+}
+
+// CheckstyleFile holds the errors of one file.
+type CheckstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []CheckstyleError `xml:"error"`
+}
+
+// CheckstyleError is a single finding.
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// CheckstyleReporter formats results as Checkstyle XML, understood by
+// reviewdog and many older CI plugins.
+type CheckstyleReporter struct {
+	opts Options
+	out  io.Writer
+}
+
+// NewCheckstyleReporter creates a new Checkstyle XML reporter.
+func NewCheckstyleReporter(opts Options) *CheckstyleReporter {
+	return &CheckstyleReporter{
+		opts: opts,
+		out:  opts.Writer,
+	}
+}
+
+// Report implements Reporter.
+func (r *CheckstyleReporter) Report(_ context.Context, result *runner.Result) (int, error) {
+	output := CheckstyleOutput{Version: checkstyleVersion}
+	count := 0
+
+	if result != nil {
+		for _, file := range result.Files {
+			csFile := CheckstyleFile{Name: displayPath(file.Path, r.opts.WorkingDir)}
+
+			if file.Error != nil {
+				csFile.Errors = append(csFile.Errors, CheckstyleError{
+					Severity: "error",
+					Message:  file.Error.Error(),
+					Source:   junitSuiteName,
+				})
+			}
+
+			if file.Result != nil && file.Result.FileResult != nil {
+				for _, diag := range file.Result.Diagnostics {
+					csFile.Errors = append(csFile.Errors, CheckstyleError{
+						Line:     diag.StartLine,
+						Column:   diag.StartColumn,
+						Severity: checkstyleSeverity(diag.Severity),
+						Message:  diag.Message,
+						Source:   "gomdlint." + config.FormatRuleID(r.opts.RuleFormat, diag.RuleID, diag.RuleName),
+					})
+					count++
+				}
+			}
+
+			output.Files = append(output.Files, csFile)
+		}
+	}
+
+	if err := writeXML(r.out, output, r.opts.Compact); err != nil {
+		return 0, fmt.Errorf("encode Checkstyle: %w", err)
+	}
+
+	return count, nil
+}
+
+// checkstyleSeverity maps a severity to a Checkstyle severity.
+func checkstyleSeverity(severity config.Severity) string {
+	switch severity {
+	case config.SeverityError:
+		return "error"
+	case config.SeverityInfo:
+		return "info"
+	default:
+		return "warning"
+	}
+}
+
+// displayPath returns path relative to workDir when possible.
+func displayPath(path, workDir string) string {
+	if workDir == "" {
+		return path
+	}
+	rel, err := filepath.Rel(workDir, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+// writeXML writes v as an XML document with a declaration.
+func writeXML(w io.Writer, v any, compact bool) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write XML header: %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	if !compact {
+		encoder.Indent("", "  ")
+	}
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("encode XML: %w", err)
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write XML: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package reporter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// createCIResult returns a result with a clean file, a file with two
+// findings, and a file that failed to process, under workDir.
+func createCIResult(workDir string) *runner.Result {
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome(filepath.Join(workDir, "clean.md"), "# Clean\n"))
+	result.Add(sarifFileOutcome(filepath.Join(workDir, "docs", "a,b.md"), "# A\ntext  \n#Bad\n",
+		lint.Diagnostic{
+			RuleID: "MD009", RuleName: "no-trailing-spaces", Message: "Trailing whitespace",
+			Severity: config.SeverityWarning, StartLine: 2, StartColumn: 5, EndLine: 2, EndColumn: 7,
+			Suggestion: "Remove trailing whitespace",
+		},
+		lint.Diagnostic{
+			RuleID: "MD018", RuleName: "no-missing-space-atx", Message: "No space after hash: 100%",
+			Severity: config.SeverityError, StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 2,
+		},
+	))
+	result.Add(runner.FileOutcome{Path: filepath.Join(workDir, "gone.md"), Error: errors.New("read failed")})
+	return result
+}
+
+func renderCI(t *testing.T, format reporter.Format, opts reporter.Options, result *runner.Result) (string, int) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	opts.Writer = &buf
+	opts.Format = format
+	rep, err := reporter.New(opts)
+	require.NoError(t, err)
+
+	count, err := rep.Report(context.Background(), result)
+	require.NoError(t, err)
+	return buf.String(), count
+}
+
+// ciWorkDir returns a temporary repository root.
+func ciWorkDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+	return dir
+}
+
+func TestGitHubReporter(t *testing.T) {
+	t.Parallel()
+
+	workDir := ciWorkDir(t)
+	opts := reporter.Options{WorkingDir: workDir, RuleFormat: config.RuleFormatCombined}
+	output, count := renderCI(t, reporter.FormatGitHub, opts, createCIResult(workDir))
+
+	assert.Equal(t, 2, count)
+	assert.Equal(t,
+		"::warning file=docs/a%2Cb.md,line=2,endLine=2,col=5,endColumn=7,title=MD009/no-trailing-spaces"+
+			"::Trailing whitespace%0ARemove trailing whitespace\n"+
+			"::error file=docs/a%2Cb.md,line=3,endLine=3,col=1,endColumn=2,title=MD018/no-missing-space-atx"+
+			"::No space after hash: 100%25\n"+
+			"::error file=gone.md::read failed\n",
+		output)
+}
+
+func TestGitLabReporter(t *testing.T) {
+	t.Parallel()
+
+	workDir := ciWorkDir(t)
+	output, count := renderCI(t, reporter.FormatGitLab, reporter.Options{WorkingDir: workDir}, createCIResult(workDir))
+	assert.Equal(t, 2, count)
+
+	var issues []reporter.GitLabIssue
+	require.NoError(t, json.Unmarshal([]byte(output), &issues))
+	require.Len(t, issues, 2)
+
+	issue := issues[0]
+	assert.Equal(t, "issue", issue.Type)
+	assert.Equal(t, "no-trailing-spaces", issue.CheckName)
+	assert.Equal(t, "Trailing whitespace", issue.Description)
+	assert.Equal(t, "minor", issue.Severity)
+	assert.Equal(t, "docs/a,b.md", issue.Location.Path)
+	require.NotNil(t, issue.Location.Positions)
+	assert.Equal(t, reporter.GitLabPosition{Line: 2, Column: 5}, issue.Location.Positions.Begin)
+	assert.Regexp(t, `^[0-9a-f]{32}$`, issue.Fingerprint)
+
+	assert.Equal(t, "major", issues[1].Severity)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+
+	// Fingerprints are stable when unrelated lines are added above.
+	shifted := runner.NewResult()
+	shifted.Add(sarifFileOutcome(filepath.Join(workDir, "docs", "a,b.md"), "# A\n\nIntro.\ntext  \n",
+		lint.Diagnostic{RuleID: "MD009", RuleName: "no-trailing-spaces", StartLine: 4, StartColumn: 5}))
+	output, _ = renderCI(t, reporter.FormatGitLab, reporter.Options{WorkingDir: workDir}, shifted)
+
+	var shiftedIssues []reporter.GitLabIssue
+	require.NoError(t, json.Unmarshal([]byte(output), &shiftedIssues))
+	require.Len(t, shiftedIssues, 1)
+	assert.Equal(t, issue.Fingerprint, shiftedIssues[0].Fingerprint)
+}
+
+func TestGitLabReporter_Empty(t *testing.T) {
+	t.Parallel()
+
+	output, count := renderCI(t, reporter.FormatGitLab, reporter.Options{}, nil)
+	assert.Equal(t, 0, count)
+	assert.JSONEq(t, "[]", output)
+}
+
+func TestJUnitReporter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("by file", func(t *testing.T) {
+		t.Parallel()
+
+		workDir := ciWorkDir(t)
+		output, count := renderCI(t, reporter.FormatJUnit, reporter.Options{WorkingDir: workDir}, createCIResult(workDir))
+		assert.Equal(t, 2, count)
+
+		var doc reporter.JUnitTestSuites
+		require.NoError(t, xml.Unmarshal([]byte(output), &doc))
+		assert.Equal(t, 3, doc.Tests)
+		assert.Equal(t, 1, doc.Failures)
+		assert.Equal(t, 1, doc.Errors)
+		require.Len(t, doc.Suites, 1)
+
+		cases := doc.Suites[0].Cases
+		require.Len(t, cases, 3)
+		assert.Equal(t, "clean.md", cases[0].Name)
+		assert.Nil(t, cases[0].Failure)
+
+		assert.Equal(t, filepath.Join("docs", "a,b.md"), cases[1].Name)
+		require.NotNil(t, cases[1].Failure)
+		assert.Equal(t, "2 issues", cases[1].Failure.Message)
+		assert.Equal(t, "error", cases[1].Failure.Type)
+		assert.Equal(t, "2:5 no-trailing-spaces Trailing whitespace\n"+
+			"3:1 no-missing-space-atx No space after hash: 100%", cases[1].Failure.Text)
+
+		require.NotNil(t, cases[2].Error)
+		assert.Equal(t, "read failed", cases[2].Error.Message)
+	})
+
+	t.Run("by rule", func(t *testing.T) {
+		t.Parallel()
+
+		workDir := ciWorkDir(t)
+		opts := reporter.Options{WorkingDir: workDir, JUnitGroupBy: reporter.JUnitByRule, RuleFormat: config.RuleFormatID}
+		output, _ := renderCI(t, reporter.FormatJUnit, opts, createCIResult(workDir))
+
+		var doc reporter.JUnitTestSuites
+		require.NoError(t, xml.Unmarshal([]byte(output), &doc))
+
+		cases := doc.Suites[0].Cases
+		require.Len(t, cases, 3)
+		assert.Equal(t, "MD009", cases[0].Name)
+		assert.Equal(t, "1 issue", cases[0].Failure.Message)
+		assert.Equal(t, "warning", cases[0].Failure.Type)
+		assert.Equal(t, filepath.Join("docs", "a,b.md")+":2:5 Trailing whitespace", cases[0].Failure.Text)
+		assert.Equal(t, "MD018", cases[1].Name)
+		assert.Equal(t, "gone.md", cases[2].Name)
+		require.NotNil(t, cases[2].Error)
+	})
+
+	t.Run("invalid grouping", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := reporter.New(reporter.Options{Format: reporter.FormatJUnit, JUnitGroupBy: "suite"})
+		require.Error(t, err)
+	})
+}
+
+func TestCheckstyleReporter(t *testing.T) {
+	t.Parallel()
+
+	workDir := ciWorkDir(t)
+	output, count := renderCI(t, reporter.FormatCheckstyle, reporter.Options{WorkingDir: workDir}, createCIResult(workDir))
+	assert.Equal(t, 2, count)
+	assert.Contains(t, output, `<?xml version="1.0" encoding="UTF-8"?>`)
+
+	var doc reporter.CheckstyleOutput
+	require.NoError(t, xml.Unmarshal([]byte(output), &doc))
+	require.Len(t, doc.Files, 3)
+
+	assert.Equal(t, "clean.md", doc.Files[0].Name)
+	assert.Empty(t, doc.Files[0].Errors)
+
+	errs := doc.Files[1].Errors
+	require.Len(t, errs, 2)
+	assert.Equal(t, reporter.CheckstyleError{
+		Line: 2, Column: 5, Severity: "warning", Message: "Trailing whitespace", Source: "gomdlint.no-trailing-spaces",
+	}, errs[0])
+	assert.Equal(t, "error", errs[1].Severity)
+
+	require.Len(t, doc.Files[2].Errors, 1)
+	assert.Equal(t, "read failed", doc.Files[2].Errors[0].Message)
+}
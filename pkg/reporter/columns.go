@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"context"
+
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// columnReporter converts diagnostic columns from bytes to another
+// encoding before passing results to a reporter that writes columns as
+// given. Columns of files without source text cannot be converted and are
+// passed through.
+type columnReporter struct {
+	rep      Reporter
+	stream   FileReporter
+	encoding mdast.PositionEncoding
+}
+
+// withColumnEncoding wraps rep to write columns in encoding. Reporters
+// already write bytes, so UTF-8 needs no wrapper.
+func withColumnEncoding(rep Reporter, encoding mdast.PositionEncoding) Reporter {
+	if encoding == "" || encoding == mdast.PositionEncodingUTF8 {
+		return rep
+	}
+	return &columnReporter{rep: rep, encoding: encoding}
+}
+
+// Report implements Reporter.
+func (c *columnReporter) Report(ctx context.Context, result *runner.Result) (int, error) {
+	if result == nil {
+		return c.rep.Report(ctx, result)
+	}
+
+	encoded := *result
+	encoded.Files = make([]runner.FileOutcome, 0, len(result.Files))
+	for _, file := range result.Files {
+		encoded.Files = append(encoded.Files, encodeColumns(file, c.encoding))
+	}
+	return c.rep.Report(ctx, &encoded)
+}
+
+// ReportFile implements FileReporter.
+func (c *columnReporter) ReportFile(ctx context.Context, outcome runner.FileOutcome) (int, error) {
+	return c.streamer().ReportFile(ctx, encodeColumns(outcome, c.encoding))
+}
+
+// Finish implements FileReporter.
+func (c *columnReporter) Finish(ctx context.Context, result *runner.Result) (int, error) {
+	return c.streamer().Finish(ctx, result)
+}
+
+// streamer returns the wrapped reporter as a FileReporter, creating the
+// adapter on first use.
+func (c *columnReporter) streamer() FileReporter {
+	if c.stream == nil {
+		c.stream = NewStream(c.rep)
+	}
+	return c.stream
+}
+
+// encodeColumns returns a copy of outcome whose diagnostic columns count
+// units of encoding instead of bytes.
+func encodeColumns(outcome runner.FileOutcome, encoding mdast.PositionEncoding) runner.FileOutcome {
+	if outcome.Result == nil || outcome.Result.FileResult == nil || outcome.Result.Snapshot == nil ||
+		len(outcome.Result.Diagnostics) == 0 {
+		return outcome
+	}
+
+	text := newSourceText(outcome.Result.Snapshot.Content, encoding)
+	diagnostics := make([]lint.Diagnostic, len(outcome.Result.Diagnostics))
+	for i, diag := range outcome.Result.Diagnostics {
+		diag.StartColumn = text.column(diag.StartLine, diag.StartColumn)
+		diag.EndColumn = text.column(diag.EndLine, diag.EndColumn)
+		diagnostics[i] = diag
+	}
+
+	pipelineResult := *outcome.Result
+	fileResult := *pipelineResult.FileResult
+	fileResult.Diagnostics = diagnostics
+	pipelineResult.FileResult = &fileResult
+	outcome.Result = &pipelineResult
+	return outcome
+}
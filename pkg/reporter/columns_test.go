@@ -0,0 +1,172 @@
+package reporter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// columnsContent has trailing spaces after "Café 😀 x" on line 3, at byte
+// columns 13-15, UTF-16 columns 10-12 and code point columns 9-11.
+const columnsContent = "# T\n\nCafé 😀 x  \n"
+
+func columnsResult() *runner.Result {
+	start := len("# T\n\nCafé 😀 x")
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome("test.md", columnsContent, lint.Diagnostic{
+		RuleID: "MD009", RuleName: "no-trailing-spaces", Message: "Trailing spaces",
+		Severity: config.SeverityWarning, StartLine: 3, StartColumn: 13, EndLine: 3, EndColumn: 15,
+		FixEdits: []fix.TextEdit{{StartOffset: start, EndOffset: start + 2}},
+	}))
+	return result
+}
+
+func renderEncodedJSON(t *testing.T, encoding mdast.PositionEncoding, result *runner.Result) *reporter.JSONOutput {
+	t.Helper()
+
+	var buf bytes.Buffer
+	opts := reporter.DefaultOptions()
+	opts.Writer = &buf
+	opts.Format = reporter.FormatJSON
+	opts.ColumnEncoding = encoding
+	rep, err := reporter.New(opts)
+	require.NoError(t, err)
+	_, err = rep.Report(context.Background(), result)
+	require.NoError(t, err)
+
+	output, err := reporter.ReadJSON(&buf)
+	require.NoError(t, err)
+	return output
+}
+
+func TestColumnEncoding_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		encoding  mdast.PositionEncoding
+		wantName  string
+		wantStart int
+		wantEnd   int
+	}{
+		{"", "utf-8", 13, 15},
+		{mdast.PositionEncodingUTF8, "utf-8", 13, 15},
+		{mdast.PositionEncodingUTF16, "utf-16", 10, 12},
+		{mdast.PositionEncodingUTF32, "utf-32", 9, 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantName, func(t *testing.T) {
+			t.Parallel()
+
+			output := renderEncodedJSON(t, tt.encoding, columnsResult())
+			assert.Equal(t, tt.wantName, output.ColumnEncoding)
+
+			diag := output.Files[0].Diagnostics[0]
+			assert.Equal(t, tt.wantStart, diag.StartColumn)
+			assert.Equal(t, tt.wantEnd, diag.EndColumn)
+			// Fix edits stay byte offsets.
+			assert.Equal(t, len("# T\n\nCafé 😀 x"), diag.Fixes[0].StartOffset)
+		})
+	}
+}
+
+func TestColumnEncoding_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	output := renderEncodedJSON(t, mdast.PositionEncodingUTF16, columnsResult())
+
+	readSource := func(string) ([]byte, error) { return []byte(columnsContent), nil }
+	diag := output.Result(readSource).Files[0].Result.Diagnostics[0]
+	assert.Equal(t, 13, diag.StartColumn)
+	assert.Equal(t, 15, diag.EndColumn)
+
+	// Without the source the columns cannot be converted.
+	diag = output.Result(nil).Files[0].Result.Diagnostics[0]
+	assert.Equal(t, 10, diag.StartColumn)
+}
+
+func TestColumnEncoding_Text(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	opts := reporter.DefaultOptions()
+	opts.Writer = &buf
+	opts.Color = "never"
+	opts.ShowContext = false
+	opts.ColumnEncoding = mdast.PositionEncodingUTF32
+	rep, err := reporter.New(opts)
+	require.NoError(t, err)
+
+	streamReport(t, rep, columnsResult())
+	assert.Contains(t, buf.String(), "3:9")
+}
+
+func TestColumnEncoding_SARIF(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		encoding       mdast.PositionEncoding
+		wantKind       string
+		wantColumn     int
+		wantCharOffset bool
+	}{
+		{"", "utf16CodeUnits", 10, true},
+		{mdast.PositionEncodingUTF32, "unicodeCodePoints", 9, true},
+		{mdast.PositionEncodingUTF8, "", 13, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.encoding), func(t *testing.T) {
+			t.Parallel()
+
+			opts := reporter.DefaultOptions()
+			opts.ColumnEncoding = tt.encoding
+			output, raw := renderSARIF(t, opts, columnsResult())
+			validateSARIF(t, raw)
+
+			run := output.Runs[0]
+			assert.Equal(t, tt.wantKind, run.ColumnKind)
+			if tt.wantKind == "" {
+				assert.Equal(t, "utf-8", run.Properties["columnEncoding"])
+			}
+
+			result := run.Results[0]
+			assert.Equal(t, tt.wantColumn, result.Locations[0].PhysicalLocation.Region.StartColumn)
+			deleted := result.Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion
+			assert.Equal(t, tt.wantCharOffset, deleted.CharOffset != nil)
+		})
+	}
+}
+
+func TestCheckColumnEncodings(t *testing.T) {
+	t.Parallel()
+
+	utf8Report := &reporter.JSONOutput{}
+	utf16Report := &reporter.JSONOutput{ColumnEncoding: "utf-16"}
+
+	require.NoError(t, reporter.CheckColumnEncodings(utf8Report, &reporter.JSONOutput{ColumnEncoding: "utf-8"}))
+	require.NoError(t, reporter.CheckColumnEncodings(utf16Report, utf16Report))
+	require.ErrorContains(t, reporter.CheckColumnEncodings(utf8Report, utf16Report), "utf-8 and utf-16")
+	require.ErrorContains(t, reporter.CheckColumnEncodings(&reporter.JSONOutput{ColumnEncoding: "ebcdic"}), "unknown")
+
+	assert.Equal(t, "utf-16", reporter.MergeJSON(utf16Report, utf16Report).ColumnEncoding)
+}
+
+func TestNew_InvalidColumnEncoding(t *testing.T) {
+	t.Parallel()
+
+	opts := reporter.DefaultOptions()
+	opts.ColumnEncoding = "utf-7"
+	_, err := reporter.New(opts)
+	require.ErrorContains(t, err, "column encoding")
+}
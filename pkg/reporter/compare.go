@@ -0,0 +1,144 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/analysis"
+	"github.com/yaklabco/gomdlint/pkg/config"
+)
+
+// ComparisonFormat is an output format for report comparisons.
+type ComparisonFormat string
+
+// Comparison output formats.
+const (
+	// ComparisonText is a plain-text listing for terminals and logs.
+	ComparisonText ComparisonFormat = "text"
+	// ComparisonMarkdown is a summary suitable for pull request comments.
+	ComparisonMarkdown ComparisonFormat = "markdown"
+	// ComparisonJSON is the analysis.Comparison as JSON, for trend tracking.
+	ComparisonJSON ComparisonFormat = "json"
+)
+
+// IsValid returns true if the format is a known comparison format.
+func (f ComparisonFormat) IsValid() bool {
+	switch f {
+	case ComparisonText, ComparisonMarkdown, ComparisonJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// WriteComparison writes a comparison of two reports in the given format.
+func WriteComparison(w io.Writer, comparison *analysis.Comparison, format ComparisonFormat, ruleFormat config.RuleFormat) error {
+	var err error
+	switch format {
+	case ComparisonJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(comparison)
+	case ComparisonMarkdown:
+		_, err = io.WriteString(w, comparisonMarkdown(comparison, ruleFormat))
+	case ComparisonText, "":
+		_, err = io.WriteString(w, comparisonText(comparison, ruleFormat))
+	default:
+		return fmt.Errorf("unsupported comparison format: %s", format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("write comparison: %w", err)
+	}
+	return nil
+}
+
+// comparisonTotals is the one-line summary of a comparison.
+func comparisonTotals(c *analysis.Comparison) string {
+	return fmt.Sprintf("%d introduced, %d resolved, %d unchanged", len(c.Introduced), len(c.Resolved), c.Unchanged)
+}
+
+func comparisonText(c *analysis.Comparison, ruleFormat config.RuleFormat) string {
+	var b strings.Builder
+
+	writeEntries := func(title string, entries []analysis.DiagnosticEntry) {
+		if len(entries) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "%s (%d)\n", title, len(entries))
+		for _, entry := range entries {
+			fmt.Fprintf(&b, "  %s:%d:%d  %s  %s  (%s)\n", entry.FilePath, entry.StartLine, entry.StartColumn,
+				entry.Severity, entry.Message, config.FormatRuleID(ruleFormat, entry.RuleID, entry.RuleName))
+		}
+		b.WriteString("\n")
+	}
+	writeEntries("Introduced", c.Introduced)
+	writeEntries("Resolved", c.Resolved)
+
+	if len(c.ByRule) > 0 {
+		b.WriteString("By rule\n")
+		for _, delta := range c.ByRule {
+			fmt.Fprintf(&b, "  %-40s %+5d %+5d\n",
+				config.FormatRuleID(ruleFormat, delta.RuleID, delta.RuleName), delta.Introduced, -delta.Resolved)
+		}
+		b.WriteString("\nBy file\n")
+		for _, delta := range c.ByFile {
+			fmt.Fprintf(&b, "  %-40s %+5d %+5d\n", delta.Path, delta.Introduced, -delta.Resolved)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(comparisonTotals(c))
+	b.WriteString("\n")
+	return b.String()
+}
+
+func comparisonMarkdown(c *analysis.Comparison, ruleFormat config.RuleFormat) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "### gomdlint: %s\n", comparisonTotals(c))
+
+	if len(c.ByRule) > 0 {
+		b.WriteString("\n| Rule | Introduced | Resolved |\n| --- | ---: | ---: |\n")
+		for _, delta := range c.ByRule {
+			fmt.Fprintf(&b, "| `%s` | %d | %d |\n",
+				markdownCell(config.FormatRuleID(ruleFormat, delta.RuleID, delta.RuleName)), delta.Introduced, delta.Resolved)
+		}
+
+		b.WriteString("\n| File | Introduced | Resolved |\n| --- | ---: | ---: |\n")
+		for _, delta := range c.ByFile {
+			fmt.Fprintf(&b, "| `%s` | %d | %d |\n", markdownCell(delta.Path), delta.Introduced, delta.Resolved)
+		}
+	}
+
+	writeEntries := func(title string, entries []analysis.DiagnosticEntry) {
+		if len(entries) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n<details><summary>%s (%d)</summary>\n\n", title, len(entries))
+		for _, entry := range entries {
+			fmt.Fprintf(&b, "- `%s:%d:%d` **%s** %s (`%s`)\n", entry.FilePath, entry.StartLine, entry.StartColumn,
+				entry.Severity, markdownText(entry.Message), config.FormatRuleID(ruleFormat, entry.RuleID, entry.RuleName))
+		}
+		b.WriteString("\n</details>\n")
+	}
+	writeEntries("Introduced", c.Introduced)
+	writeEntries("Resolved", c.Resolved)
+
+	return b.String()
+}
+
+// markdownCell escapes text for a Markdown table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// markdownText escapes text that should not be interpreted as HTML or
+// Markdown markup in a comment.
+func markdownText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;",
+	).Replace(s)
+}
@@ -0,0 +1,101 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/analysis"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+)
+
+func testComparison() *analysis.Comparison {
+	introduced := analysis.DiagnosticEntry{
+		FilePath: "docs/a.md", RuleID: "MD009", RuleName: "no-trailing-spaces",
+		Severity: "warning", Message: "Trailing spaces <br>", StartLine: 4, StartColumn: 7,
+	}
+	resolved := analysis.DiagnosticEntry{
+		FilePath: "docs/a|b.md", RuleID: "MD013", RuleName: "line-length",
+		Severity: "warning", Message: "Line too long", StartLine: 2, StartColumn: 81,
+	}
+	return &analysis.Comparison{
+		Introduced: []analysis.DiagnosticEntry{introduced},
+		Resolved:   []analysis.DiagnosticEntry{resolved},
+		Unchanged:  3,
+		ByRule: []analysis.RuleDelta{
+			{RuleID: "MD009", RuleName: "no-trailing-spaces", Introduced: 1},
+			{RuleID: "MD013", RuleName: "line-length", Resolved: 1},
+		},
+		ByFile: []analysis.FileDelta{
+			{Path: "docs/a.md", Introduced: 1},
+			{Path: "docs/a|b.md", Resolved: 1},
+		},
+	}
+}
+
+func TestWriteComparison(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format reporter.ComparisonFormat
+		want   []string
+	}{
+		{
+			name:   "text",
+			format: reporter.ComparisonText,
+			want: []string{
+				"Introduced (1)",
+				"docs/a.md:4:7  warning  Trailing spaces <br>  (no-trailing-spaces)",
+				"Resolved (1)",
+				"By rule",
+				"1 introduced, 1 resolved, 3 unchanged",
+			},
+		},
+		{
+			name:   "markdown",
+			format: reporter.ComparisonMarkdown,
+			want: []string{
+				"### gomdlint: 1 introduced, 1 resolved, 3 unchanged",
+				"| `no-trailing-spaces` | 1 | 0 |",
+				"| `docs/a\\|b.md` | 0 | 1 |",
+				"<details><summary>Introduced (1)</summary>",
+				"- `docs/a.md:4:7` **warning** Trailing spaces &lt;br&gt; (`no-trailing-spaces`)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, reporter.WriteComparison(&buf, testComparison(), tt.format, config.RuleFormatName))
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, reporter.WriteComparison(&buf, testComparison(), reporter.ComparisonJSON, config.RuleFormatName))
+
+		var decoded analysis.Comparison
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, *testComparison(), decoded)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, reporter.ComparisonFormat("xml").IsValid())
+		require.Error(t, reporter.WriteComparison(&bytes.Buffer{}, testComparison(), "xml", config.RuleFormatName))
+	})
+}
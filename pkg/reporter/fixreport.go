@@ -0,0 +1,100 @@
+package reporter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// writeFixReport explains the fix passes of a file: the edits each rule
+// applied and had skipped, the conflicts behind the skipped edits, and
+// rules whose edits undo each other.
+func (r *TextReporter) writeFixReport(file runner.FileOutcome) {
+	if !r.opts.FixReport || file.Result == nil || len(file.Result.Passes) == 0 {
+		return
+	}
+
+	passes := file.Result.Passes
+	passWord := "passes"
+	if len(passes) == 1 {
+		passWord = "pass"
+	}
+	fmt.Fprintf(r.out, "%s: %s\n", r.styles.FilePath.Render(file.Path),
+		r.styles.SummaryTitle.Render(fmt.Sprintf("fix report, %d %s", len(passes), passWord)))
+
+	type rulePair struct{ first, second string }
+	var pairs []rulePair
+	for i, pass := range passes {
+		fmt.Fprintf(r.out, "  pass %d: %s\n", i+1, r.formatPassCounts(pass))
+
+		for _, conflict := range pass.Conflicts {
+			fmt.Fprintf(r.out, "    %s\n", r.styles.Warning.Render(fmt.Sprintf(
+				"%s skipped: overlaps %s edit at %d:%d",
+				r.describeEdit(conflict.Skipped), r.ruleLabel(conflict.Accepted.Origin.RuleID),
+				conflict.Line, conflict.Column)))
+		}
+
+		for _, reversal := range pass.Reversals {
+			fmt.Fprintf(r.out, "    %s\n", r.styles.Warning.Render(fmt.Sprintf(
+				"%s undoes the %s of pass %d",
+				r.describeEdit(reversal.Undoing), r.describeEdit(reversal.Undone), i)))
+
+			pair := rulePair{reversal.Undone.Origin.RuleID, reversal.Undoing.Origin.RuleID}
+			if pair.second < pair.first {
+				pair.first, pair.second = pair.second, pair.first
+			}
+			if !slices.Contains(pairs, pair) {
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+
+	for _, pair := range pairs {
+		message := r.ruleLabel(pair.first) + " undoes its own edits"
+		if pair.first != pair.second {
+			message = fmt.Sprintf("%s and %s undo each other's edits", r.ruleLabel(pair.first), r.ruleLabel(pair.second))
+		}
+		fmt.Fprintf(r.out, "  %s\n", r.styles.Warning.Render("warning: "+message))
+	}
+}
+
+// formatPassCounts lists the edits applied and skipped by rule, sorted by
+// rule label.
+func (r *TextReporter) formatPassCounts(pass lint.FixPass) string {
+	parts := make([]string, 0, len(pass.Rules))
+	for id, counts := range pass.Rules {
+		var counted []string
+		if counts.Applied > 0 {
+			counted = append(counted, fmt.Sprintf("%d applied", counts.Applied))
+		}
+		if counts.Skipped > 0 {
+			counted = append(counted, fmt.Sprintf("%d skipped", counts.Skipped))
+		}
+		parts = append(parts, r.ruleLabel(id)+" "+strings.Join(counted, ", "))
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, "; ")
+}
+
+// describeEdit names an edit by its rule and the position of its diagnostic.
+func (r *TextReporter) describeEdit(edit fix.TextEdit) string {
+	return fmt.Sprintf("%s edit for %d:%d", r.ruleLabel(edit.Origin.RuleID), edit.Origin.Line, edit.Origin.Column)
+}
+
+// ruleLabel formats a rule ID in the configured rule format.
+func (r *TextReporter) ruleLabel(id string) string {
+	registry := r.opts.Registry
+	if registry == nil {
+		registry = lint.DefaultRegistry
+	}
+	name := ""
+	if rule, ok := registry.GetByID(id); ok {
+		name = rule.Name()
+	}
+	return config.FormatRuleID(r.opts.RuleFormat, id, name)
+}
@@ -10,9 +10,18 @@ const (
 	FormatText    Format = "text"
 	FormatTable   Format = "table"
 	FormatJSON    Format = "json"
+	FormatJSONL   Format = "jsonl"
 	FormatSARIF   Format = "sarif"
 	FormatDiff    Format = "diff"
 	FormatSummary Format = "summary"
+	FormatHTML    Format = "html"
+
+	// CI formats.
+	FormatGitHub     Format = "github"
+	FormatGitLab     Format = "gitlab"
+	FormatRDJSONL    Format = "rdjsonl"
+	FormatJUnit      Format = "junit"
+	FormatCheckstyle Format = "checkstyle"
 )
 
 // ParseFormat parses a format string, returning an error for unknown formats.
@@ -24,14 +33,29 @@ func ParseFormat(formatStr string) (Format, error) {
 		return FormatTable, nil
 	case "json":
 		return FormatJSON, nil
+	case "jsonl":
+		return FormatJSONL, nil
 	case "sarif":
 		return FormatSARIF, nil
 	case "diff":
 		return FormatDiff, nil
 	case "summary":
 		return FormatSummary, nil
+	case "html":
+		return FormatHTML, nil
+	case "github":
+		return FormatGitHub, nil
+	case "gitlab":
+		return FormatGitLab, nil
+	case "rdjsonl":
+		return FormatRDJSONL, nil
+	case "junit":
+		return FormatJUnit, nil
+	case "checkstyle":
+		return FormatCheckstyle, nil
 	default:
-		return "", fmt.Errorf("unknown format %q; valid formats: text, table, json, sarif, diff, summary", formatStr)
+		return "", fmt.Errorf("unknown format %q; valid formats: "+
+			"text, table, json, jsonl, sarif, diff, summary, html, github, gitlab, rdjsonl, junit, checkstyle", formatStr)
 	}
 }
 
@@ -43,7 +67,8 @@ func (f Format) String() string {
 // IsValid returns true if the format is a known valid format.
 func (f Format) IsValid() bool {
 	switch f {
-	case FormatText, FormatTable, FormatJSON, FormatSARIF, FormatDiff, FormatSummary:
+	case FormatText, FormatTable, FormatJSON, FormatJSONL, FormatSARIF, FormatDiff, FormatSummary, FormatHTML,
+		FormatGitHub, FormatGitLab, FormatRDJSONL, FormatJUnit, FormatCheckstyle:
 		return true
 	default:
 		return false
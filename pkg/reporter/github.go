@@ -0,0 +1,123 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// GitHubReporter formats results as GitHub Actions workflow commands
+// (::error file=...::message), which the runner turns into inline
+// annotations on pull requests without a SARIF upload.
+type GitHubReporter struct {
+	opts Options
+	out  io.Writer
+}
+
+// NewGitHubReporter creates a new GitHub Actions reporter.
+func NewGitHubReporter(opts Options) *GitHubReporter {
+	return &GitHubReporter{
+		opts: opts,
+		out:  opts.Writer,
+	}
+}
+
+// Report implements Reporter.
+func (r *GitHubReporter) Report(_ context.Context, result *runner.Result) (int, error) {
+	if result == nil {
+		return 0, nil
+	}
+
+	paths := newRepoPaths(r.opts.WorkingDir)
+	var buf strings.Builder
+	count := 0
+
+	for _, file := range result.Files {
+		path := paths.rel(file.Path)
+
+		if file.Error != nil {
+			writeGitHubCommand(&buf, "error", [][2]string{{"file", path}}, file.Error.Error())
+		}
+
+		if file.Result == nil || file.Result.FileResult == nil {
+			continue
+		}
+
+		for _, diag := range file.Result.Diagnostics {
+			props := [][2]string{{"file", path}}
+			if diag.StartLine > 0 {
+				props = append(props, [2]string{"line", strconv.Itoa(diag.StartLine)})
+				if diag.EndLine >= diag.StartLine {
+					props = append(props, [2]string{"endLine", strconv.Itoa(diag.EndLine)})
+				}
+			}
+			if diag.StartColumn > 0 {
+				props = append(props, [2]string{"col", strconv.Itoa(diag.StartColumn)})
+				if diag.EndColumn > 0 {
+					props = append(props, [2]string{"endColumn", strconv.Itoa(diag.EndColumn)})
+				}
+			}
+			props = append(props, [2]string{"title", config.FormatRuleID(r.opts.RuleFormat, diag.RuleID, diag.RuleName)})
+
+			message := diag.Message
+			if diag.Suggestion != "" {
+				message += "\n" + diag.Suggestion
+			}
+
+			writeGitHubCommand(&buf, githubLevel(diag.Severity), props, message)
+			count++
+		}
+	}
+
+	if _, err := io.WriteString(r.out, buf.String()); err != nil {
+		return 0, fmt.Errorf("write GitHub annotations: %w", err)
+	}
+
+	return count, nil
+}
+
+// writeGitHubCommand writes one workflow command line.
+func writeGitHubCommand(buf *strings.Builder, command string, props [][2]string, message string) {
+	buf.WriteString("::")
+	buf.WriteString(command)
+	for i, prop := range props {
+		if i == 0 {
+			buf.WriteByte(' ')
+		} else {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(prop[0])
+		buf.WriteByte('=')
+		buf.WriteString(escapeGitHubProperty(prop[1]))
+	}
+	buf.WriteString("::")
+	buf.WriteString(escapeGitHubData(message))
+	buf.WriteByte('\n')
+}
+
+// githubLevel maps a severity to a workflow command.
+func githubLevel(severity config.Severity) string {
+	switch severity {
+	case config.SeverityError:
+		return "error"
+	case config.SeverityInfo:
+		return "notice"
+	default:
+		return "warning"
+	}
+}
+
+// escapeGitHubData escapes a workflow command message.
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubProperty escapes a workflow command property value.
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,157 @@
+package reporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// GitLabIssue is a GitLab Code Quality report entry.
+type GitLabIssue struct {
+	Type        string         `json:"type"`
+	CheckName   string         `json:"check_name"`
+	Description string         `json:"description"`
+	Categories  []string       `json:"categories"`
+	Severity    string         `json:"severity"`
+	Fingerprint string         `json:"fingerprint"`
+	Location    GitLabLocation `json:"location"`
+}
+
+// GitLabLocation locates a Code Quality issue.
+type GitLabLocation struct {
+	Path      string           `json:"path"`
+	Lines     *GitLabLines     `json:"lines,omitempty"`
+	Positions *GitLabPositions `json:"positions,omitempty"`
+}
+
+// GitLabLines is a line range.
+type GitLabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+// GitLabPositions is a line and column range.
+type GitLabPositions struct {
+	Begin GitLabPosition `json:"begin"`
+	End   GitLabPosition `json:"end"`
+}
+
+// GitLabPosition is a line and column.
+type GitLabPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GitLabReporter formats results as a GitLab Code Quality report, shown in
+// the merge request widget.
+type GitLabReporter struct {
+	opts Options
+	out  io.Writer
+}
+
+// NewGitLabReporter creates a new GitLab Code Quality reporter.
+func NewGitLabReporter(opts Options) *GitLabReporter {
+	return &GitLabReporter{
+		opts: opts,
+		out:  opts.Writer,
+	}
+}
+
+// Report implements Reporter.
+func (r *GitLabReporter) Report(_ context.Context, result *runner.Result) (int, error) {
+	issues := r.buildIssues(result)
+
+	encoder := json.NewEncoder(r.out)
+	if !r.opts.Compact {
+		encoder.SetIndent("", "  ")
+	}
+
+	if err := encoder.Encode(issues); err != nil {
+		return 0, fmt.Errorf("encode GitLab Code Quality: %w", err)
+	}
+
+	return len(issues), nil
+}
+
+// readsSource implements sourceReporter: the source is read for the fingerprints of the issues.
+func (r *GitLabReporter) readsSource() bool {
+	return true
+}
+
+func (r *GitLabReporter) buildIssues(result *runner.Result) []GitLabIssue {
+	issues := make([]GitLabIssue, 0)
+	if result == nil {
+		return issues
+	}
+
+	paths := newRepoPaths(r.opts.WorkingDir)
+
+	for _, file := range result.Files {
+		if file.Result == nil || file.Result.FileResult == nil {
+			continue
+		}
+
+		path := paths.rel(file.Path)
+		var content []byte
+		if file.Result.Snapshot != nil {
+			content = file.Result.Snapshot.Content
+		}
+		text := newSourceText(content, mdast.PositionEncodingUTF8)
+		fingerprints := newLineFingerprinter()
+
+		for _, diag := range file.Result.Diagnostics {
+			// The line fingerprint is unique within a file; the path makes
+			// it unique within the report, as GitLab requires.
+			sum := sha256.Sum256([]byte(path + "\x00" + fingerprints.fingerprint(diag, text)))
+
+			issue := GitLabIssue{
+				Type:        "issue",
+				CheckName:   config.FormatRuleID(r.opts.RuleFormat, diag.RuleID, diag.RuleName),
+				Description: diag.Message,
+				Categories:  []string{"Style"},
+				Severity:    gitlabSeverity(diag.Severity),
+				Fingerprint: hex.EncodeToString(sum[:16]),
+				Location: GitLabLocation{
+					Path:  path,
+					Lines: &GitLabLines{Begin: max(diag.StartLine, 1)},
+				},
+			}
+			if diag.EndLine > diag.StartLine {
+				issue.Location.Lines.End = diag.EndLine
+			}
+			if diag.StartLine > 0 && diag.StartColumn > 0 {
+				issue.Location.Lines = nil
+				issue.Location.Positions = &GitLabPositions{
+					Begin: GitLabPosition{Line: diag.StartLine, Column: diag.StartColumn},
+					End: GitLabPosition{
+						Line:   max(diag.EndLine, diag.StartLine),
+						Column: max(diag.EndColumn, diag.StartColumn),
+					},
+				}
+			}
+
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// gitlabSeverity maps a severity to a Code Quality severity.
+func gitlabSeverity(severity config.Severity) string {
+	switch severity {
+	case config.SeverityError:
+		return "major"
+	case config.SeverityInfo:
+		return "info"
+	default:
+		return "minor"
+	}
+}
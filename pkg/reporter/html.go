@@ -0,0 +1,302 @@
+package reporter
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/yaklabco/gomdlint/pkg/analysis"
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+//go:embed templates/report.html.tmpl
+var htmlTemplateText string
+
+// htmlTemplate renders the HTML report.
+//
+//nolint:gochecknoglobals // Parsed once; read-only.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"diffClass":  diffLineClass,
+	"diffPrefix": diffLinePrefix,
+}).Parse(htmlTemplateText))
+
+// HTMLReporter formats results as a single self-contained HTML page with a
+// summary dashboard, highlighted sources, inline diagnostics and proposed
+// fixes. The page uses no external assets, so it can be archived as a CI
+// artifact.
+type HTMLReporter struct {
+	opts Options
+	out  io.Writer
+}
+
+// NewHTMLReporter creates a new HTML reporter.
+func NewHTMLReporter(opts Options) *HTMLReporter {
+	return &HTMLReporter{
+		opts: opts,
+		out:  opts.Writer,
+	}
+}
+
+// htmlPage is the data passed to the HTML template.
+type htmlPage struct {
+	Title       string
+	ToolVersion string
+	Generated   string
+	Totals      analysis.Totals
+	Rules       []htmlRule
+	Files       []htmlFile
+}
+
+// htmlRule is a row of the rules table and a rule filter option.
+type htmlRule struct {
+	ID       string
+	Label    string
+	Issues   int
+	Errors   int
+	Warnings int
+	Infos    int
+	Fixable  bool
+	Files    int
+}
+
+// htmlFile is a row of the files table and, when it has findings, a
+// source view.
+type htmlFile struct {
+	Anchor   string
+	Path     string
+	Issues   int
+	Errors   int
+	Warnings int
+	Infos    int
+	Error    string
+	Lines    []htmlLine
+}
+
+// htmlLine is a highlighted source line with the diagnostics that start on it.
+type htmlLine struct {
+	Number   int
+	Source   template.HTML
+	Severity string
+	Diags    []htmlDiag
+}
+
+// htmlDiag is a diagnostic shown below its line.
+type htmlDiag struct {
+	RuleID     string
+	Rule       string
+	Severity   string
+	Position   string
+	Message    string
+	Suggestion string
+	Fix        []fix.DiffHunk
+}
+
+// Report implements Reporter.
+func (r *HTMLReporter) Report(_ context.Context, result *runner.Result) (int, error) {
+	report := analysis.Analyze(result, analysis.Options{
+		IncludeByFile: true,
+		IncludeByRule: true,
+		SortBy:        analysis.SortByCount,
+		SortDesc:      true,
+		RuleFormat:    r.opts.RuleFormat,
+		WorkingDir:    r.opts.WorkingDir,
+	})
+
+	page := htmlPage{
+		Title:       "gomdlint report",
+		ToolVersion: r.opts.ToolVersion,
+		Generated:   report.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC"),
+		Totals:      report.Totals,
+		Rules:       r.buildRules(report.ByRule),
+		Files:       r.buildFiles(result),
+	}
+
+	if err := htmlTemplate.Execute(r.out, page); err != nil {
+		return 0, fmt.Errorf("render HTML: %w", err)
+	}
+
+	return report.Totals.Issues, nil
+}
+
+// readsSource implements sourceReporter: the source is read for the source listings.
+func (r *HTMLReporter) readsSource() bool {
+	return true
+}
+
+func (r *HTMLReporter) buildRules(rules []analysis.RuleAnalysis) []htmlRule {
+	out := make([]htmlRule, 0, len(rules))
+	for _, rule := range rules {
+		out = append(out, htmlRule{
+			ID:       rule.RuleID,
+			Label:    config.FormatRuleID(r.opts.RuleFormat, rule.RuleID, rule.RuleName),
+			Issues:   rule.Issues,
+			Errors:   rule.Errors,
+			Warnings: rule.Warnings,
+			Infos:    rule.Infos,
+			Fixable:  rule.Fixable,
+			Files:    len(rule.Files),
+		})
+	}
+	return out
+}
+
+// buildFiles returns the files with findings or errors, most issues first.
+func (r *HTMLReporter) buildFiles(result *runner.Result) []htmlFile {
+	if result == nil {
+		return nil
+	}
+
+	var files []htmlFile
+	for _, outcome := range result.Files {
+		file := htmlFile{Path: displayPath(outcome.Path, r.opts.WorkingDir)}
+
+		if outcome.Error != nil {
+			file.Error = outcome.Error.Error()
+		}
+
+		if outcome.Result != nil && outcome.Result.FileResult != nil && len(outcome.Result.Diagnostics) > 0 {
+			var content []byte
+			if outcome.Result.Snapshot != nil {
+				content = outcome.Result.Snapshot.Content
+			}
+			file.Lines = r.buildLines(content, outcome.Result.Diagnostics)
+
+			for _, diag := range outcome.Result.Diagnostics {
+				file.Issues++
+				switch htmlSeverity(diag.Severity) {
+				case string(config.SeverityError):
+					file.Errors++
+				case string(config.SeverityInfo):
+					file.Infos++
+				default:
+					file.Warnings++
+				}
+			}
+		}
+
+		if file.Issues > 0 || file.Error != "" {
+			files = append(files, file)
+		}
+	}
+
+	slices.SortStableFunc(files, func(a, b htmlFile) int {
+		return b.Issues - a.Issues
+	})
+	for i := range files {
+		files[i].Anchor = "file-" + strconv.Itoa(i+1)
+	}
+
+	return files
+}
+
+// buildLines highlights content and attaches each diagnostic to its start
+// line. Diagnostics outside the content are attached to the last line.
+func (r *HTMLReporter) buildLines(content []byte, diags []lint.Diagnostic) []htmlLine {
+	source := highlightMarkdown(content)
+	lines := make([]htmlLine, max(len(source), 1))
+	for i := range lines {
+		lines[i].Number = i + 1
+		if i < len(source) {
+			lines[i].Source = source[i]
+		}
+	}
+
+	for _, diag := range diags {
+		idx := min(max(diag.StartLine, 1), len(lines)) - 1
+		severity := htmlSeverity(diag.Severity)
+
+		entry := htmlDiag{
+			RuleID:     diag.RuleID,
+			Rule:       config.FormatRuleID(r.opts.RuleFormat, diag.RuleID, diag.RuleName),
+			Severity:   severity,
+			Position:   junitPosition(diag),
+			Message:    diag.Message,
+			Suggestion: diag.Suggestion,
+			Fix:        proposedFix(content, diag),
+		}
+		lines[idx].Diags = append(lines[idx].Diags, entry)
+		lines[idx].Severity = moreSevere(lines[idx].Severity, severity)
+	}
+
+	return lines
+}
+
+// proposedFix returns the diff hunks of applying diag's fix on its own,
+// or nil if it has no applicable fix.
+func proposedFix(content []byte, diag lint.Diagnostic) []fix.DiffHunk {
+	if !diag.HasFix() {
+		return nil
+	}
+
+	edits, err := fix.PrepareEdits(diag.FixEdits, len(content))
+	if err != nil {
+		return nil
+	}
+
+	diff := fix.GenerateDiff("", content, fix.ApplyEdits(content, edits))
+	if !diff.HasChanges() {
+		return nil
+	}
+	return diff.Hunks
+}
+
+// diffLineClass returns the CSS class of a proposed-fix diff line.
+func diffLineClass(kind fix.DiffLineKind) string {
+	switch kind {
+	case fix.DiffLineAdd:
+		return "add"
+	case fix.DiffLineRemove:
+		return "remove"
+	default:
+		return "context"
+	}
+}
+
+// diffLinePrefix returns the unified diff prefix of a diff line.
+func diffLinePrefix(kind fix.DiffLineKind) string {
+	switch kind {
+	case fix.DiffLineAdd:
+		return "+"
+	case fix.DiffLineRemove:
+		return "-"
+	default:
+		return " "
+	}
+}
+
+// htmlSeverity normalizes a severity for display and filtering.
+func htmlSeverity(severity config.Severity) string {
+	if severity == "" {
+		return string(config.SeverityWarning)
+	}
+	return string(severity)
+}
+
+// moreSevere returns the more severe of two severity names.
+func moreSevere(a, b string) string {
+	if severityRank(b) > severityRank(a) {
+		return b
+	}
+	return a
+}
+
+// severityRank orders severities from least (0, none) to most severe.
+func severityRank(severity string) int {
+	switch config.Severity(severity) {
+	case config.SeverityError:
+		return 3
+	case config.SeverityWarning:
+		return 2
+	case config.SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,119 @@
+package reporter
+
+import (
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// Markdown block patterns used by highlightMarkdown.
+var (
+	mdFencePattern   = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	mdHeadingPattern = regexp.MustCompile(`^ {0,3}#{1,6}(?:[ \t]|$)`)
+	mdQuotePattern   = regexp.MustCompile(`^ {0,3}(?:> ?)+`)
+	mdListPattern    = regexp.MustCompile(`^[ \t]*(?:[-*+]|\d{1,9}[.)])(?:[ \t]|$)`)
+
+	// mdInlinePattern matches, in order of precedence: code spans, images
+	// and links, strong emphasis, emphasis, and autolinks or HTML tags.
+	mdInlinePattern = regexp.MustCompile(
+		"`+[^`]+`+" +
+			`|!?\[[^\]]*\]\([^)]*\)` +
+			`|\*\*[^*]+\*\*|__[^_]+__` +
+			`|\*[^*\s][^*]*\*|_[^_\s][^_]*_` +
+			`|<[^>\s]+>`)
+)
+
+// highlightMarkdown returns each line of content as HTML with Markdown
+// syntax wrapped in classed spans. It is a line-oriented approximation,
+// good enough to make a report readable, not a Markdown parser.
+func highlightMarkdown(content []byte) []template.HTML {
+	if len(content) == 0 {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	out := make([]template.HTML, len(lines))
+
+	var fence string
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+
+		if fence != "" {
+			if m := mdFencePattern.FindStringSubmatch(line); m != nil &&
+				m[1][0] == fence[0] && len(m[1]) >= len(fence) && strings.TrimSpace(line[len(m[0]):]) == "" {
+				fence = ""
+				out[i] = mdSpan("md-fence", line)
+			} else {
+				out[i] = mdSpan("md-code", line)
+			}
+			continue
+		}
+
+		switch {
+		case mdFencePattern.MatchString(line):
+			fence = mdFencePattern.FindStringSubmatch(line)[1]
+			out[i] = mdSpan("md-fence", line)
+		case mdHeadingPattern.MatchString(line):
+			out[i] = mdSpan("md-heading", line)
+		default:
+			out[i] = highlightBlockLine(line)
+		}
+	}
+
+	return out
+}
+
+// highlightBlockLine highlights quote and list markers followed by inline
+// syntax.
+func highlightBlockLine(line string) template.HTML {
+	var b strings.Builder
+
+	if m := mdQuotePattern.FindString(line); m != "" {
+		b.WriteString(string(mdSpan("md-quote", m)))
+		line = line[len(m):]
+	}
+	if m := mdListPattern.FindString(line); m != "" {
+		b.WriteString(string(mdSpan("md-list", m)))
+		line = line[len(m):]
+	}
+
+	b.WriteString(string(highlightInline(line)))
+	return template.HTML(b.String()) //nolint:gosec // Built from escaped text.
+}
+
+// highlightInline escapes text and wraps inline Markdown syntax in spans.
+func highlightInline(text string) template.HTML {
+	var b strings.Builder
+	last := 0
+
+	for _, loc := range mdInlinePattern.FindAllStringIndex(text, -1) {
+		b.WriteString(template.HTMLEscapeString(text[last:loc[0]]))
+		b.WriteString(string(mdSpan(inlineClass(text[loc[0]:loc[1]]), text[loc[0]:loc[1]])))
+		last = loc[1]
+	}
+	b.WriteString(template.HTMLEscapeString(text[last:]))
+
+	return template.HTML(b.String()) //nolint:gosec // Built from escaped text.
+}
+
+// inlineClass returns the span class for an inline match.
+func inlineClass(match string) string {
+	switch {
+	case strings.HasPrefix(match, "`"):
+		return "md-code"
+	case strings.HasPrefix(match, "[") || strings.HasPrefix(match, "!["):
+		return "md-link"
+	case strings.HasPrefix(match, "**") || strings.HasPrefix(match, "__"):
+		return "md-strong"
+	case strings.HasPrefix(match, "<"):
+		return "md-link"
+	default:
+		return "md-em"
+	}
+}
+
+// mdSpan wraps escaped text in a span with the given class.
+func mdSpan(class, text string) template.HTML {
+	//nolint:gosec // Class is a constant and text is escaped.
+	return template.HTML(`<span class="` + class + `">` + template.HTMLEscapeString(text) + `</span>`)
+}
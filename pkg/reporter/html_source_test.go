@@ -0,0 +1,44 @@
+package reporter
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightMarkdown(t *testing.T) {
+	t.Parallel()
+
+	content := "# Title\n" +
+		"> - item with `code` & [link](x.md)\n" +
+		"```md\n" +
+		"# not a heading\n" +
+		"```\n" +
+		"**bold** and _em_ <br>\r\n"
+
+	want := []template.HTML{
+		`<span class="md-heading"># Title</span>`,
+		`<span class="md-quote">&gt; </span><span class="md-list">- </span>item with ` +
+			"<span class=\"md-code\">`code`</span> &amp; <span class=\"md-link\">[link](x.md)</span>",
+		"<span class=\"md-fence\">```md</span>",
+		`<span class="md-code"># not a heading</span>`,
+		"<span class=\"md-fence\">```</span>",
+		`<span class="md-strong">**bold**</span> and <span class="md-em">_em_</span> <span class="md-link">&lt;br&gt;</span>`,
+	}
+
+	assert.Equal(t, want, highlightMarkdown([]byte(content)))
+	assert.Nil(t, highlightMarkdown(nil))
+}
+
+func TestHighlightMarkdown_UnclosedFence(t *testing.T) {
+	t.Parallel()
+
+	got := highlightMarkdown([]byte("~~~~\n```\n~~~\n# still code\n"))
+	assert.Equal(t, []template.HTML{
+		`<span class="md-fence">~~~~</span>`,
+		"<span class=\"md-code\">```</span>",
+		`<span class="md-code">~~~</span>`,
+		`<span class="md-code"># still code</span>`,
+	}, got)
+}
@@ -0,0 +1,82 @@
+package reporter_test
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+func TestHTMLReporter(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	result := createCIResult(workDir)
+	result.Add(sarifFileOutcome(filepath.Join(workDir, "fix.md"), "#Title <b>\n",
+		lint.Diagnostic{
+			RuleID: "MD018", RuleName: "no-missing-space-atx", Message: "No space after hash",
+			Severity: config.SeverityWarning, StartLine: 1, StartColumn: 1,
+			FixEdits: []fix.TextEdit{{StartOffset: 1, EndOffset: 1, NewText: " "}},
+		},
+	))
+
+	opts := reporter.Options{WorkingDir: workDir, ToolVersion: "1.2.3"}
+	output, count := renderCI(t, reporter.FormatHTML, opts, result)
+	assert.Equal(t, 3, count)
+
+	t.Run("dashboard", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Contains(t, output, "<!DOCTYPE html>")
+		assert.Contains(t, output, "gomdlint 1.2.3")
+		assert.Contains(t, output, `<div class="value">4</div><div class="label">Files checked</div>`)
+		assert.Contains(t, output, `<div class="value">3</div><div class="label">Issues</div>`)
+		assert.Contains(t, output, `<tr class="filterable" data-rule="MD018"`)
+		assert.Contains(t, output, `<option value="MD009">no-trailing-spaces</option>`)
+	})
+
+	t.Run("files", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Contains(t, output, `data-path="docs/a,b.md"`)
+		assert.Contains(t, output, `data-path="fix.md"`)
+		assert.Contains(t, output, "Error: read failed")
+		assert.NotContains(t, output, `data-path="clean.md"`, "files without findings have no source view")
+	})
+
+	t.Run("diagnostics and fixes", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Contains(t, output, `data-severity="error" data-rule="MD018"`)
+		assert.Contains(t, output, "No space after hash: 100%")
+		assert.Contains(t, output, "Suggestion: Remove trailing whitespace")
+		assert.Contains(t, output, `<div class="remove">-#Title &lt;b&gt;</div>`)
+		assert.Contains(t, output, `<div class="add">&#43;# Title &lt;b&gt;</div>`)
+	})
+
+	t.Run("self-contained", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NotContains(t, output, "<b>", "source is escaped")
+		external := regexp.MustCompile(`(?i)(src|href)="(https?:)?//|<link |@import`)
+		assert.False(t, external.MatchString(output), "report must not load external assets")
+	})
+}
+
+func TestHTMLReporter_NoIssues(t *testing.T) {
+	t.Parallel()
+
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome("clean.md", "# Clean\n"))
+
+	output, count := renderCI(t, reporter.FormatHTML, reporter.Options{}, result)
+	assert.Equal(t, 0, count)
+	assert.Contains(t, output, "No issues found.")
+}
@@ -1,11 +1,14 @@
 package reporter
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
 	"github.com/yaklabco/gomdlint/pkg/runner"
 )
 
@@ -16,11 +19,25 @@ const (
 
 // JSONOutput is the top-level JSON structure.
 type JSONOutput struct {
-	Version string           `json:"version"`
+	Version string     `json:"version"`
+	Root    string     `json:"root,omitempty"`
+	Shard   *JSONShard `json:"shard,omitempty"`
+
+	// ColumnEncoding is the unit of diagnostic columns (see
+	// mdast.PositionEncoding). Reports before 1.3.0 count UTF-8 bytes.
+	ColumnEncoding string `json:"columnEncoding,omitempty"`
+
 	Files   []JSONFileResult `json:"files"`
 	Summary JSONSummary      `json:"summary"`
 }
 
+// JSONShard identifies the partition of files a sharded run covered.
+type JSONShard struct {
+	Index      int `json:"index"`
+	Count      int `json:"count"`
+	TotalFiles int `json:"totalFiles"`
+}
+
 // JSONFileResult represents a single file's results.
 type JSONFileResult struct {
 	Path        string           `json:"path"`
@@ -42,6 +59,10 @@ type JSONDiagnostic struct {
 	Suggestion  string    `json:"suggestion,omitempty"`
 	Fixable     bool      `json:"fixable"`
 	Fixes       []JSONFix `json:"fixes,omitempty"`
+
+	// FixApplicability classifies the fixes: safe, unsafe or suggestion.
+	// Absent without fixes, and in reports before 1.4.0.
+	FixApplicability string `json:"fixApplicability,omitempty"`
 }
 
 // JSONFix represents a proposed fix.
@@ -93,8 +114,10 @@ func (r *JSONReporter) Report(_ context.Context, result *runner.Result) (int, er
 
 func (r *JSONReporter) buildOutput(result *runner.Result) *JSONOutput {
 	output := &JSONOutput{
-		Version: "1.0.0",
-		Files:   make([]JSONFileResult, 0),
+		Version:        JSONVersion,
+		Root:           r.opts.WorkingDir,
+		ColumnEncoding: string(cmp.Or(r.opts.ColumnEncoding, mdast.PositionEncodingUTF8)),
+		Files:          make([]JSONFileResult, 0),
 		Summary: JSONSummary{
 			BySeverity: make(map[string]int),
 		},
@@ -104,6 +127,14 @@ func (r *JSONReporter) buildOutput(result *runner.Result) *JSONOutput {
 		return output
 	}
 
+	if result.Shard != nil {
+		output.Shard = &JSONShard{
+			Index:      result.Shard.Index,
+			Count:      result.Shard.Count,
+			TotalFiles: result.Shard.TotalFiles,
+		}
+	}
+
 	// Pre-allocate if we have files
 	if len(result.Files) > 0 {
 		output.Files = make([]JSONFileResult, 0, len(result.Files))
@@ -125,28 +156,7 @@ func (r *JSONReporter) buildOutput(result *runner.Result) *JSONOutput {
 
 			if file.Result.FileResult != nil {
 				for _, diag := range file.Result.Diagnostics {
-					jsonDiag := JSONDiagnostic{
-						RuleID:      diag.RuleID,
-						RuleName:    diag.RuleName,
-						Severity:    string(diag.Severity),
-						Message:     diag.Message,
-						StartLine:   diag.StartLine,
-						StartColumn: diag.StartColumn,
-						EndLine:     diag.EndLine,
-						EndColumn:   diag.EndColumn,
-						Suggestion:  diag.Suggestion,
-						Fixable:     len(diag.FixEdits) > 0,
-					}
-
-					for _, edit := range diag.FixEdits {
-						jsonDiag.Fixes = append(jsonDiag.Fixes, JSONFix{
-							StartOffset: edit.StartOffset,
-							EndOffset:   edit.EndOffset,
-							NewText:     edit.NewText,
-						})
-					}
-
-					fileResult.Diagnostics = append(fileResult.Diagnostics, jsonDiag)
+					fileResult.Diagnostics = append(fileResult.Diagnostics, newJSONDiagnostic(diag))
 					output.Summary.TotalIssues++
 
 					severity := string(diag.Severity)
@@ -171,3 +181,32 @@ func (r *JSONReporter) buildOutput(result *runner.Result) *JSONOutput {
 
 	return output
 }
+
+// newJSONDiagnostic converts a diagnostic to its JSON form.
+func newJSONDiagnostic(diag lint.Diagnostic) JSONDiagnostic {
+	jsonDiag := JSONDiagnostic{
+		RuleID:      diag.RuleID,
+		RuleName:    diag.RuleName,
+		Severity:    string(diag.Severity),
+		Message:     diag.Message,
+		StartLine:   diag.StartLine,
+		StartColumn: diag.StartColumn,
+		EndLine:     diag.EndLine,
+		EndColumn:   diag.EndColumn,
+		Suggestion:  diag.Suggestion,
+		Fixable:     len(diag.FixEdits) > 0,
+	}
+
+	if len(diag.FixEdits) > 0 {
+		jsonDiag.FixApplicability = string(diag.Applicability)
+	}
+	for _, edit := range diag.FixEdits {
+		jsonDiag.Fixes = append(jsonDiag.Fixes, JSONFix{
+			StartOffset: edit.StartOffset,
+			EndOffset:   edit.EndOffset,
+			NewText:     edit.NewText,
+		})
+	}
+
+	return jsonDiag
+}
@@ -0,0 +1,327 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// JSONVersion is the version of the JSON report schema written by
+// JSONReporter. It follows semantic versioning: readers accept any report
+// with the same major version, and new optional fields bump the minor
+// version. The schema is published in schema/report-v1.schema.json.
+//
+// 1.1.0 added the optional root field; 1.2.0 added shard; 1.3.0 added
+// columnEncoding; 1.4.0 added fixApplicability.
+const JSONVersion = "1.4.0"
+
+// ReadJSON decodes a report written by JSONReporter. It rejects reports
+// with a different major schema version.
+func ReadJSON(r io.Reader) (*JSONOutput, error) {
+	var output JSONOutput
+
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&output); err != nil {
+		return nil, fmt.Errorf("decode JSON report: %w", err)
+	}
+
+	if output.Version == "" {
+		return nil, errors.New("decode JSON report: missing version")
+	}
+	major, _, _ := strings.Cut(output.Version, ".")
+	wantMajor, _, _ := strings.Cut(JSONVersion, ".")
+	if major != wantMajor {
+		return nil, fmt.Errorf("unsupported JSON report version %s (supported: %s.x)", output.Version, wantMajor)
+	}
+
+	return &output, nil
+}
+
+// Rebase moves file paths under the report's root to workDir, so reports
+// written in other checkouts (such as other CI jobs) refer to local files.
+// Reports without a root are left unchanged.
+func (o *JSONOutput) Rebase(workDir string) {
+	if o == nil || o.Root == "" || workDir == "" || o.Root == workDir {
+		return
+	}
+
+	for i, file := range o.Files {
+		if !filepath.IsAbs(file.Path) {
+			continue
+		}
+		rel, err := filepath.Rel(o.Root, file.Path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		o.Files[i].Path = filepath.Join(workDir, rel)
+	}
+	o.Root = workDir
+}
+
+// CheckShards verifies that sharded reports fit together: they must all
+// be shards of the same run, no file may appear in two of them and, unless
+// allowPartial is set, every shard must be present. Reports without shard
+// metadata are not checked, but cannot be mixed with sharded ones.
+func CheckShards(allowPartial bool, outputs ...*JSONOutput) error {
+	var sharded []*JSONOutput
+	for _, output := range outputs {
+		if output != nil && output.Shard != nil {
+			sharded = append(sharded, output)
+		}
+	}
+	if len(sharded) == 0 {
+		return nil
+	}
+	if len(sharded) != len(outputs) {
+		return errors.New("cannot merge sharded and unsharded reports")
+	}
+
+	first := sharded[0].Shard
+	seenShards := make(map[int]bool, first.Count)
+	seenFiles := make(map[string]int)
+	files := 0
+	for _, output := range sharded {
+		shard := output.Shard
+		if shard.Count != first.Count || shard.TotalFiles != first.TotalFiles {
+			return fmt.Errorf("shard %d/%d of %d files does not belong to the same run as shard %d/%d of %d files",
+				shard.Index, shard.Count, shard.TotalFiles, first.Index, first.Count, first.TotalFiles)
+		}
+		if seenShards[shard.Index] {
+			return fmt.Errorf("shard %d/%d appears more than once", shard.Index, shard.Count)
+		}
+		seenShards[shard.Index] = true
+
+		for _, file := range output.Files {
+			if other, ok := seenFiles[file.Path]; ok {
+				return fmt.Errorf("%s appears in shards %d and %d", file.Path, other, shard.Index)
+			}
+			seenFiles[file.Path] = shard.Index
+		}
+		files += len(output.Files)
+	}
+
+	if allowPartial {
+		return nil
+	}
+	var missing []string
+	for index := 1; index <= first.Count; index++ {
+		if !seenShards[index] {
+			missing = append(missing, fmt.Sprintf("%d/%d", index, first.Count))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing shards: %s", strings.Join(missing, ", "))
+	}
+	if files != first.TotalFiles {
+		return fmt.Errorf("shards contain %d files, but the run discovered %d", files, first.TotalFiles)
+	}
+	return nil
+}
+
+// Encoding returns the unit of the report's columns. Reports that do not
+// record it count UTF-8 bytes.
+func (o *JSONOutput) Encoding() mdast.PositionEncoding {
+	if o == nil || o.ColumnEncoding == "" {
+		return mdast.PositionEncodingUTF8
+	}
+	return mdast.PositionEncoding(o.ColumnEncoding)
+}
+
+// CheckColumnEncodings verifies that reports count columns in the same,
+// known encoding, so they can be merged.
+func CheckColumnEncodings(outputs ...*JSONOutput) error {
+	var first mdast.PositionEncoding
+	for i, output := range outputs {
+		encoding := output.Encoding()
+		if !encoding.IsValid() {
+			return fmt.Errorf("unknown column encoding %q", encoding)
+		}
+		if i == 0 {
+			first = encoding
+		} else if encoding != first {
+			return fmt.Errorf("cannot merge reports with %s and %s columns", first, encoding)
+		}
+	}
+	return nil
+}
+
+// MergeJSON combines reports, such as those from sharded runs, into one.
+// Files are ordered by path; when several reports contain the same file,
+// the last one wins. The summary is recomputed and shard metadata is
+// dropped; use CheckShards first to verify shards. The merged report keeps a
+// root only if all reports share it; Rebase them first to merge reports
+// from different checkouts. Columns are not converted: use
+// CheckColumnEncodings first.
+func MergeJSON(outputs ...*JSONOutput) *JSONOutput {
+	byPath := make(map[string]JSONFileResult)
+	root, sameRoot := "", true
+	for i, output := range outputs {
+		if output == nil {
+			continue
+		}
+		if i == 0 {
+			root = output.Root
+		} else if output.Root != root {
+			sameRoot = false
+		}
+		for _, file := range output.Files {
+			byPath[file.Path] = file
+		}
+	}
+
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	merged := &JSONOutput{
+		Version:        JSONVersion,
+		ColumnEncoding: string(mdast.PositionEncodingUTF8),
+		Files:          make([]JSONFileResult, 0, len(paths)),
+		Summary:        JSONSummary{BySeverity: make(map[string]int)},
+	}
+	if len(outputs) > 0 {
+		merged.ColumnEncoding = string(outputs[0].Encoding())
+	}
+	if sameRoot {
+		merged.Root = root
+	}
+	for _, path := range paths {
+		file := byPath[path]
+		merged.Files = append(merged.Files, file)
+
+		merged.Summary.FilesChecked++
+		if file.Error != "" {
+			merged.Summary.FilesErrored++
+		}
+		if file.Modified {
+			merged.Summary.FilesModified++
+		}
+		if len(file.Diagnostics) > 0 {
+			merged.Summary.FilesWithIssues++
+		}
+		for _, diag := range file.Diagnostics {
+			merged.Summary.TotalIssues++
+			merged.Summary.BySeverity[jsonSeverity(diag.Severity)]++
+		}
+	}
+
+	return merged
+}
+
+// Result converts the report back into a runner.Result so it can be
+// rendered by any reporter. If readSource is non-nil it is called for each
+// file without an error to recover the source text, which some formats use
+// for context, fingerprints and fixes; files it cannot read have no source.
+// Columns are converted back to bytes using the source; without it they
+// keep the report's encoding.
+func (o *JSONOutput) Result(readSource func(path string) ([]byte, error)) *runner.Result {
+	result := runner.NewResult()
+	if o == nil {
+		return result
+	}
+	if o.Shard != nil {
+		result.Shard = &runner.ShardInfo{Index: o.Shard.Index, Count: o.Shard.Count, TotalFiles: o.Shard.TotalFiles}
+	}
+
+	for _, file := range o.Files {
+		outcome := runner.FileOutcome{Path: file.Path}
+
+		if file.Error != "" {
+			outcome.Error = errors.New(file.Error)
+			result.Add(outcome)
+			continue
+		}
+
+		fileResult := &lint.FileResult{
+			Diagnostics: make([]lint.Diagnostic, 0, len(file.Diagnostics)),
+		}
+		for _, diag := range file.Diagnostics {
+			fileResult.Diagnostics = append(fileResult.Diagnostics, diag.diagnostic(file.Path))
+		}
+
+		if readSource != nil {
+			if content, err := readSource(file.Path); err == nil {
+				fileResult.Snapshot = &mdast.FileSnapshot{
+					Path:    file.Path,
+					Content: content,
+					Lines:   mdast.BuildLines(content),
+				}
+				decodeColumns(fileResult.Diagnostics, content, o.Encoding())
+			}
+		}
+
+		outcome.Result = &lint.PipelineResult{
+			FileResult: fileResult,
+			Path:       file.Path,
+			Written:    file.Modified,
+			Modified:   file.Modified,
+		}
+		result.Add(outcome)
+	}
+
+	return result
+}
+
+// decodeColumns converts diagnostic columns counted in encoding back to
+// bytes.
+func decodeColumns(diagnostics []lint.Diagnostic, content []byte, encoding mdast.PositionEncoding) {
+	if encoding == mdast.PositionEncodingUTF8 || !encoding.IsValid() {
+		return
+	}
+
+	text := newSourceText(content, encoding)
+	for i := range diagnostics {
+		diag := &diagnostics[i]
+		diag.StartColumn = encoding.ByteColumn(text.line(diag.StartLine), diag.StartColumn)
+		diag.EndColumn = encoding.ByteColumn(text.line(diag.EndLine), diag.EndColumn)
+	}
+}
+
+// diagnostic converts a JSON diagnostic back into a lint.Diagnostic.
+func (d JSONDiagnostic) diagnostic(path string) lint.Diagnostic {
+	diag := lint.Diagnostic{
+		RuleID:      d.RuleID,
+		RuleName:    d.RuleName,
+		Message:     d.Message,
+		Severity:    config.Severity(d.Severity),
+		FilePath:    path,
+		StartLine:   d.StartLine,
+		StartColumn: d.StartColumn,
+		EndLine:     d.EndLine,
+		EndColumn:   d.EndColumn,
+		Suggestion:  d.Suggestion,
+	}
+	if len(d.Fixes) > 0 {
+		diag.Applicability = lint.FixApplicability(d.FixApplicability)
+	}
+
+	for _, f := range d.Fixes {
+		diag.FixEdits = append(diag.FixEdits, fix.TextEdit{
+			StartOffset: f.StartOffset,
+			EndOffset:   f.EndOffset,
+			NewText:     f.NewText,
+		})
+	}
+
+	return diag
+}
+
+// jsonSeverity returns a severity name, defaulting to warning.
+func jsonSeverity(severity string) string {
+	if severity == "" {
+		return severityWarning
+	}
+	return severity
+}
@@ -0,0 +1,253 @@
+package reporter_test
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+//go:embed schema/report-v1.schema.json
+var reportSchemaJSON []byte
+
+// renderJSON writes result as a JSON report rooted at workDir.
+func renderJSON(t *testing.T, workDir string, result *runner.Result) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	rep := reporter.NewJSONReporter(reporter.Options{Writer: &buf, WorkingDir: workDir})
+	_, err := rep.Report(context.Background(), result)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func readJSONResult(workDir string) *runner.Result {
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome(filepath.Join(workDir, "docs", "a.md"), "# Title  \n",
+		lint.Diagnostic{
+			RuleID: "MD009", RuleName: "no-trailing-spaces", Message: "Trailing spaces",
+			Severity: config.SeverityWarning, StartLine: 1, StartColumn: 8, EndLine: 1, EndColumn: 10,
+			FixEdits:      []fix.TextEdit{{StartOffset: 7, EndOffset: 9, NewText: ""}},
+			Applicability: lint.FixSafe,
+		}))
+	result.Add(runner.FileOutcome{Path: filepath.Join(workDir, "b.md"), Error: errors.New("permission denied")})
+	return result
+}
+
+func TestJSONReport_MatchesSchema(t *testing.T) {
+	t.Parallel()
+
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(reportSchemaJSON))
+	require.NoError(t, err)
+	compiler := jsonschema.NewCompiler()
+	require.NoError(t, compiler.AddResource("report-v1.schema.json", schemaDoc))
+	schema, err := compiler.Compile("report-v1.schema.json")
+	require.NoError(t, err)
+
+	output := renderJSON(t, "/repo", readJSONResult("/repo"))
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(output))
+	require.NoError(t, err)
+	require.NoError(t, schema.Validate(doc))
+}
+
+func TestReadJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	source := filepath.Join(workDir, "docs", "a.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(source), 0o750))
+	require.NoError(t, os.WriteFile(source, []byte("# Title  \n"), 0o600))
+
+	original := renderJSON(t, workDir, readJSONResult(workDir))
+
+	report, err := reporter.ReadJSON(bytes.NewReader(original))
+	require.NoError(t, err)
+	assert.Equal(t, reporter.JSONVersion, report.Version)
+	assert.Equal(t, workDir, report.Root)
+
+	result := report.Result(os.ReadFile)
+	assert.Equal(t, 2, result.Stats.FilesDiscovered)
+	assert.Equal(t, 1, result.Stats.DiagnosticsTotal)
+	assert.Equal(t, 1, result.Stats.FilesErrored)
+
+	outcome := result.Files[0]
+	require.NotNil(t, outcome.Result)
+	require.NotNil(t, outcome.Result.Snapshot)
+	assert.Equal(t, "# Title  \n", string(outcome.Result.Snapshot.Content))
+	assert.EqualError(t, result.Files[1].Error, "permission denied")
+
+	assert.JSONEq(t, string(original), string(renderJSON(t, workDir, result)))
+}
+
+func TestReadJSON_Version(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "current", input: `{"version":"1.1.0","files":[],"summary":{}}`},
+		{name: "older minor", input: `{"version":"1.0.0","files":[],"summary":{}}`},
+		{name: "newer minor", input: `{"version":"1.9.0","files":[],"summary":{}}`},
+		{name: "major", input: `{"version":"2.0.0","files":[],"summary":{}}`, wantErr: "unsupported JSON report version 2.0.0"},
+		{name: "missing", input: `{"files":[]}`, wantErr: "missing version"},
+		{name: "invalid", input: `not json`, wantErr: "decode JSON report"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := reporter.ReadJSON(strings.NewReader(tt.input))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJSONOutput_Rebase(t *testing.T) {
+	t.Parallel()
+
+	report := &reporter.JSONOutput{
+		Root: "/ci/job1",
+		Files: []reporter.JSONFileResult{
+			{Path: "/ci/job1/docs/a.md"},
+			{Path: "/elsewhere/b.md"},
+			{Path: "relative.md"},
+		},
+	}
+	report.Rebase("/home/me/repo")
+
+	assert.Equal(t, "/home/me/repo", report.Root)
+	assert.Equal(t, filepath.Join("/home/me/repo", "docs", "a.md"), report.Files[0].Path)
+	assert.Equal(t, "/elsewhere/b.md", report.Files[1].Path)
+	assert.Equal(t, "relative.md", report.Files[2].Path)
+
+	noRoot := &reporter.JSONOutput{Files: []reporter.JSONFileResult{{Path: "/ci/job1/a.md"}}}
+	noRoot.Rebase("/home/me/repo")
+	assert.Equal(t, "/ci/job1/a.md", noRoot.Files[0].Path)
+}
+
+func TestMergeJSON(t *testing.T) {
+	t.Parallel()
+
+	warning := reporter.JSONDiagnostic{RuleID: "MD009", Severity: "warning"}
+	errorDiag := reporter.JSONDiagnostic{RuleID: "MD001", Severity: "error"}
+
+	first := &reporter.JSONOutput{
+		Root: "/repo",
+		Files: []reporter.JSONFileResult{
+			{Path: "/repo/b.md", Diagnostics: []reporter.JSONDiagnostic{warning}},
+			{Path: "/repo/c.md", Diagnostics: []reporter.JSONDiagnostic{warning, warning}},
+		},
+	}
+	second := &reporter.JSONOutput{
+		Root: "/repo",
+		Files: []reporter.JSONFileResult{
+			{Path: "/repo/a.md", Diagnostics: []reporter.JSONDiagnostic{errorDiag}, Modified: true},
+			{Path: "/repo/c.md", Diagnostics: []reporter.JSONDiagnostic{}},
+			{Path: "/repo/d.md", Diagnostics: []reporter.JSONDiagnostic{}, Error: "boom"},
+		},
+	}
+
+	merged := reporter.MergeJSON(first, second)
+
+	assert.Equal(t, reporter.JSONVersion, merged.Version)
+	assert.Equal(t, "/repo", merged.Root)
+	paths := make([]string, 0, len(merged.Files))
+	for _, file := range merged.Files {
+		paths = append(paths, file.Path)
+	}
+	assert.Equal(t, []string{"/repo/a.md", "/repo/b.md", "/repo/c.md", "/repo/d.md"}, paths)
+	// The later report's c.md wins.
+	assert.Empty(t, merged.Files[2].Diagnostics)
+
+	assert.Equal(t, reporter.JSONSummary{
+		FilesChecked:    4,
+		FilesWithIssues: 2,
+		FilesModified:   1,
+		FilesErrored:    1,
+		TotalIssues:     2,
+		BySeverity:      map[string]int{"error": 1, "warning": 1},
+	}, merged.Summary)
+
+	t.Run("different roots", func(t *testing.T) {
+		t.Parallel()
+
+		other := &reporter.JSONOutput{Root: "/other"}
+		assert.Empty(t, reporter.MergeJSON(first, other).Root)
+	})
+}
+
+func TestCheckShards(t *testing.T) {
+	t.Parallel()
+
+	shard := func(index, count, total int, paths ...string) *reporter.JSONOutput {
+		output := &reporter.JSONOutput{Shard: &reporter.JSONShard{Index: index, Count: count, TotalFiles: total}}
+		for _, path := range paths {
+			output.Files = append(output.Files, reporter.JSONFileResult{Path: path})
+		}
+		return output
+	}
+
+	tests := []struct {
+		name         string
+		outputs      []*reporter.JSONOutput
+		allowPartial bool
+		wantErr      string
+	}{
+		{name: "unsharded", outputs: []*reporter.JSONOutput{{}, {}}},
+		{name: "complete", outputs: []*reporter.JSONOutput{shard(2, 2, 3, "c.md"), shard(1, 2, 3, "a.md", "b.md")}},
+		{name: "missing shard", outputs: []*reporter.JSONOutput{shard(1, 3, 2, "a.md"), shard(3, 3, 2, "b.md")}, wantErr: "missing shards: 2/3"},
+		{name: "partial allowed", outputs: []*reporter.JSONOutput{shard(1, 3, 2, "a.md")}, allowPartial: true},
+		{name: "duplicate shard", outputs: []*reporter.JSONOutput{shard(1, 2, 2, "a.md"), shard(1, 2, 2, "b.md")}, wantErr: "appears more than once"},
+		{name: "overlap", outputs: []*reporter.JSONOutput{shard(1, 2, 2, "a.md"), shard(2, 2, 2, "a.md")}, wantErr: "a.md appears in shards 1 and 2"},
+		{name: "different runs", outputs: []*reporter.JSONOutput{shard(1, 2, 2, "a.md"), shard(2, 3, 2, "b.md")}, wantErr: "same run"},
+		{name: "missing files", outputs: []*reporter.JSONOutput{shard(1, 2, 3, "a.md"), shard(2, 2, 3, "b.md")}, wantErr: "contain 2 files"},
+		{name: "mixed", outputs: []*reporter.JSONOutput{shard(1, 1, 1, "a.md"), {}}, wantErr: "sharded and unsharded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := reporter.CheckShards(tt.allowPartial, tt.outputs...)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJSONReport_Shard(t *testing.T) {
+	t.Parallel()
+
+	result := readJSONResult("/repo")
+	result.Shard = &runner.ShardInfo{Index: 2, Count: 4, TotalFiles: 9}
+
+	report, err := reporter.ReadJSON(bytes.NewReader(renderJSON(t, "/repo", result)))
+	require.NoError(t, err)
+	assert.Equal(t, &reporter.JSONShard{Index: 2, Count: 4, TotalFiles: 9}, report.Shard)
+	assert.Equal(t, result.Shard, report.Result(nil).Shard)
+	assert.Nil(t, reporter.MergeJSON(report).Shard)
+}
@@ -0,0 +1,134 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// JSONLRecord is one line of jsonl output: a diagnostic, or a file that
+// could not be processed, in which case only Path and Error are set.
+type JSONLRecord struct {
+	// Path is the file path relative to the working directory.
+	Path string `json:"path"`
+
+	*JSONDiagnostic
+
+	// Rule describes the diagnostic's rule, if it is registered.
+	Rule *JSONLRule `json:"rule,omitempty"`
+
+	// Error is why the file could not be processed.
+	Error string `json:"error,omitempty"`
+}
+
+// JSONLRule is the rule metadata attached to jsonl diagnostics.
+type JSONLRule struct {
+	Description     string   `json:"description"`
+	Tags            []string `json:"tags,omitempty"`
+	DefaultSeverity string   `json:"defaultSeverity"`
+	Fixable         bool     `json:"fixable"`
+	HelpURI         string   `json:"helpUri,omitempty"`
+}
+
+// JSONLReporter writes one JSON object per diagnostic per line, as files
+// are reported, for jq and log shippers.
+type JSONLReporter struct {
+	opts     Options
+	out      io.Writer
+	registry *lint.Registry
+	rules    map[string]*JSONLRule
+}
+
+// NewJSONLReporter creates a new JSON Lines reporter.
+func NewJSONLReporter(opts Options) *JSONLReporter {
+	registry := opts.Registry
+	if registry == nil {
+		registry = lint.DefaultRegistry
+	}
+	return &JSONLReporter{
+		opts:     opts,
+		out:      opts.Writer,
+		registry: registry,
+		rules:    make(map[string]*JSONLRule),
+	}
+}
+
+// Report implements Reporter.
+func (r *JSONLReporter) Report(ctx context.Context, result *runner.Result) (int, error) {
+	return reportFiles(ctx, r, result)
+}
+
+// ReportFile implements FileReporter.
+func (r *JSONLReporter) ReportFile(_ context.Context, outcome runner.FileOutcome) (int, error) {
+	encoder := json.NewEncoder(r.out)
+	path := displayPath(outcome.Path, r.opts.WorkingDir)
+
+	if outcome.Error != nil {
+		if err := encoder.Encode(JSONLRecord{Path: path, Error: outcome.Error.Error()}); err != nil {
+			return 0, fmt.Errorf("encode JSON line: %w", err)
+		}
+		return 0, nil
+	}
+
+	if outcome.Result == nil || outcome.Result.FileResult == nil {
+		return 0, nil
+	}
+
+	for _, diag := range outcome.Result.Diagnostics {
+		jsonDiag := newJSONDiagnostic(diag)
+		record := JSONLRecord{Path: path, JSONDiagnostic: &jsonDiag, Rule: r.rule(diag.RuleID)}
+		if err := encoder.Encode(record); err != nil {
+			return 0, fmt.Errorf("encode JSON line: %w", err)
+		}
+	}
+	return len(outcome.Result.Diagnostics), nil
+}
+
+// Finish implements FileReporter. JSON Lines output has no trailer.
+func (r *JSONLReporter) Finish(_ context.Context, _ *runner.Result) (int, error) {
+	return 0, nil
+}
+
+// rule returns the metadata of a registered rule, or nil.
+func (r *JSONLReporter) rule(ruleID string) *JSONLRule {
+	if rule, ok := r.rules[ruleID]; ok {
+		return rule
+	}
+
+	var rule *JSONLRule
+	if registered, ok := r.registry.GetByID(ruleID); ok {
+		rule = &JSONLRule{
+			Description:     registered.Description(),
+			Tags:            registered.Tags(),
+			DefaultSeverity: string(registered.DefaultSeverity()),
+			Fixable:         registered.CanFix(),
+			HelpURI:         ruleHelpURI(ruleID),
+		}
+	}
+	r.rules[ruleID] = rule
+	return rule
+}
+
+// reportFiles implements Report for a FileReporter by reporting each file
+// of result and then finishing.
+func reportFiles(ctx context.Context, rep FileReporter, result *runner.Result) (int, error) {
+	if result == nil {
+		return rep.Finish(ctx, runner.NewResult())
+	}
+
+	total := 0
+	for _, file := range result.Files {
+		n, err := rep.ReportFile(ctx, file)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	n, err := rep.Finish(ctx, result)
+	return total + n, err
+}
@@ -0,0 +1,163 @@
+package reporter_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// decodeLines decodes every line of output into a T.
+func decodeLines[T any](t *testing.T, output []byte) []T {
+	t.Helper()
+
+	var records []T
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		var record T
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "line: %s", scanner.Text())
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func TestJSONLReporter(t *testing.T) {
+	t.Parallel()
+
+	content := "# T\n\ntext  \n"
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome("a.md", content, lint.Diagnostic{
+		RuleID: "MD009", RuleName: "no-trailing-spaces", Message: "Trailing spaces",
+		Severity: config.SeverityWarning, StartLine: 3, StartColumn: 5, EndLine: 3, EndColumn: 7,
+		FixEdits: []fix.TextEdit{{StartOffset: 9, EndOffset: 11}},
+	}))
+	result.Add(runner.FileOutcome{Path: "b.md", Error: errors.New("read failed")})
+
+	var buf bytes.Buffer
+	opts := reporter.DefaultOptions()
+	opts.Writer = &buf
+	rep, err := reporter.New(withFormat(opts, reporter.FormatJSONL))
+	require.NoError(t, err)
+
+	count, err := rep.Report(context.Background(), result)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	records := decodeLines[reporter.JSONLRecord](t, buf.Bytes())
+	require.Len(t, records, 2)
+
+	diag := records[0]
+	assert.Equal(t, "a.md", diag.Path)
+	require.NotNil(t, diag.JSONDiagnostic)
+	assert.Equal(t, "MD009", diag.RuleID)
+	assert.Equal(t, 3, diag.StartLine)
+	require.Len(t, diag.Fixes, 1)
+	assert.Equal(t, 9, diag.Fixes[0].StartOffset)
+	require.NotNil(t, diag.Rule)
+	assert.True(t, diag.Rule.Fixable)
+	assert.NotEmpty(t, diag.Rule.Description)
+	assert.Contains(t, diag.Rule.HelpURI, "md009")
+
+	assert.Equal(t, reporter.JSONLRecord{Path: "b.md", Error: "read failed"}, records[1])
+}
+
+func TestRDJSONLReporter(t *testing.T) {
+	t.Parallel()
+
+	// "é" is 2 bytes; reviewdog columns count bytes.
+	content := "# T\n\nCafé x  \n"
+	start := len("# T\n\nCafé x")
+
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome("docs/a.md", content,
+		lint.Diagnostic{
+			RuleID: "MD009", RuleName: "no-trailing-spaces", Message: "Trailing spaces",
+			Severity: config.SeverityError, StartLine: 3, StartColumn: 8, EndLine: 3, EndColumn: 10,
+			FixEdits: []fix.TextEdit{{StartOffset: start, EndOffset: start + 2}},
+		},
+		lint.Diagnostic{
+			RuleID: "MD047", RuleName: "single-trailing-newline", Message: "Missing newline",
+			Severity: config.SeverityInfo, StartLine: 1,
+			FixEdits: []fix.TextEdit{{StartOffset: len(content), EndOffset: len(content), NewText: "\n"}},
+		},
+	))
+
+	var buf, errBuf bytes.Buffer
+	opts := reporter.DefaultOptions()
+	opts.Writer = &buf
+	opts.ErrorWriter = &errBuf
+	result.Add(runner.FileOutcome{Path: "docs/b.md", Error: errors.New("read failed")})
+
+	rep, err := reporter.New(withFormat(opts, reporter.FormatRDJSONL))
+	require.NoError(t, err)
+	count, err := rep.Report(context.Background(), result)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Contains(t, errBuf.String(), "docs/b.md: error: read failed")
+
+	diags := decodeLines[reporter.RDDiagnostic](t, buf.Bytes())
+	require.Len(t, diags, 2)
+
+	trailing := diags[0]
+	assert.Equal(t, "Trailing spaces", trailing.Message)
+	assert.Equal(t, "docs/a.md", trailing.Location.Path)
+	assert.Equal(t, "ERROR", trailing.Severity)
+	require.NotNil(t, trailing.Code)
+	assert.Equal(t, "no-trailing-spaces", trailing.Code.Value)
+	require.NotNil(t, trailing.Source)
+	assert.Equal(t, "gomdlint", trailing.Source.Name)
+	require.Len(t, trailing.Suggestions, 1)
+	assert.Equal(t, reporter.RDSuggestion{
+		Range: reporter.RDRange{
+			Start: reporter.RDPosition{Line: 3, Column: 8},
+			End:   &reporter.RDPosition{Line: 3, Column: 10},
+		},
+	}, trailing.Suggestions[0])
+
+	// An insertion at the end of the file.
+	newline := diags[1]
+	assert.Equal(t, "INFO", newline.Severity)
+	require.Len(t, newline.Suggestions, 1)
+	assert.Equal(t, reporter.RDPosition{Line: 4, Column: 1}, newline.Suggestions[0].Range.Start)
+	assert.Equal(t, "\n", newline.Suggestions[0].Text)
+}
+
+func TestRDJSONLReporter_NoSourceOmitsSuggestions(t *testing.T) {
+	t.Parallel()
+
+	outcome := sarifFileOutcome("a.md", "", lint.Diagnostic{
+		RuleID: "MD009", Message: "Trailing spaces", StartLine: 1, StartColumn: 1,
+		FixEdits: []fix.TextEdit{{StartOffset: 4, EndOffset: 6}},
+	})
+	outcome.Result.Snapshot = nil
+	result := runner.NewResult()
+	result.Add(outcome)
+
+	var buf bytes.Buffer
+	opts := reporter.DefaultOptions()
+	opts.Writer = &buf
+	_, err := reporter.NewRDJSONLReporter(opts).Report(context.Background(), result)
+	require.NoError(t, err)
+
+	diags := decodeLines[reporter.RDDiagnostic](t, buf.Bytes())
+	require.Len(t, diags, 1)
+	assert.Empty(t, diags[0].Suggestions)
+	assert.Equal(t, "WARNING", diags[0].Severity)
+}
+
+func withFormat(opts reporter.Options, format reporter.Format) reporter.Options {
+	opts.Format = format
+	return opts
+}
@@ -0,0 +1,249 @@
+package reporter
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// JUnitGrouping controls what a JUnit testcase represents.
+type JUnitGrouping string
+
+const (
+	// JUnitByFile reports one testcase per checked file (the default).
+	JUnitByFile JUnitGrouping = "file"
+	// JUnitByRule reports one testcase per rule with findings.
+	JUnitByRule JUnitGrouping = "rule"
+)
+
+// IsValid returns true if the grouping is a known value.
+func (g JUnitGrouping) IsValid() bool {
+	return g == JUnitByFile || g == JUnitByRule
+}
+
+// junitSuiteName names the suite and the testcase classname.
+const junitSuiteName = "gomdlint"
+
+// JUnitTestSuites is the root element of a JUnit XML report.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite groups testcases.
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is a single file or rule.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Failure   *JUnitProblem `xml:"failure,omitempty"`
+	Error     *JUnitProblem `xml:"error,omitempty"`
+}
+
+// JUnitProblem is a failure (lint findings) or error (file not processed).
+type JUnitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// JUnitReporter formats results as JUnit XML for CI servers such as Jenkins.
+type JUnitReporter struct {
+	opts Options
+	out  io.Writer
+}
+
+// NewJUnitReporter creates a new JUnit XML reporter.
+func NewJUnitReporter(opts Options) *JUnitReporter {
+	return &JUnitReporter{
+		opts: opts,
+		out:  opts.Writer,
+	}
+}
+
+// Report implements Reporter.
+func (r *JUnitReporter) Report(_ context.Context, result *runner.Result) (int, error) {
+	var cases []JUnitTestCase
+	var count int
+	if r.opts.JUnitGroupBy == JUnitByRule {
+		cases, count = r.casesByRule(result)
+	} else {
+		cases, count = r.casesByFile(result)
+	}
+
+	suite := JUnitTestSuite{Name: junitSuiteName, Tests: len(cases), Cases: cases}
+	for _, tc := range cases {
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		if tc.Error != nil {
+			suite.Errors++
+		}
+	}
+
+	doc := JUnitTestSuites{
+		Name:     junitSuiteName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Suites:   []JUnitTestSuite{suite},
+	}
+
+	if err := writeXML(r.out, doc, r.opts.Compact); err != nil {
+		return 0, fmt.Errorf("encode JUnit: %w", err)
+	}
+
+	return count, nil
+}
+
+// casesByFile returns one testcase per file.
+func (r *JUnitReporter) casesByFile(result *runner.Result) ([]JUnitTestCase, int) {
+	if result == nil {
+		return nil, 0
+	}
+
+	var cases []JUnitTestCase
+	count := 0
+
+	for _, file := range result.Files {
+		path := displayPath(file.Path, r.opts.WorkingDir)
+		tc := JUnitTestCase{Name: path, ClassName: junitSuiteName, File: path}
+
+		if file.Error != nil {
+			tc.Error = &JUnitProblem{Message: file.Error.Error(), Type: "error"}
+		}
+
+		if file.Result != nil && file.Result.FileResult != nil && len(file.Result.Diagnostics) > 0 {
+			diags := file.Result.Diagnostics
+			lines := make([]string, 0, len(diags))
+			for _, diag := range diags {
+				lines = append(lines, fmt.Sprintf("%s %s %s",
+					junitPosition(diag), config.FormatRuleID(r.opts.RuleFormat, diag.RuleID, diag.RuleName), diag.Message))
+			}
+			tc.Failure = &JUnitProblem{
+				Message: issueCount(len(diags)),
+				Type:    string(highestSeverity(diags)),
+				Text:    strings.Join(lines, "\n"),
+			}
+			count += len(diags)
+		}
+
+		cases = append(cases, tc)
+	}
+
+	return cases, count
+}
+
+// casesByRule returns one testcase per rule with findings, plus one per
+// file that could not be processed.
+func (r *JUnitReporter) casesByRule(result *runner.Result) ([]JUnitTestCase, int) {
+	if result == nil {
+		return nil, 0
+	}
+
+	type ruleFindings struct {
+		name  string
+		diags []lint.Diagnostic
+		lines []string
+	}
+	byRule := make(map[string]*ruleFindings)
+	var errorCases []JUnitTestCase
+	count := 0
+
+	for _, file := range result.Files {
+		path := displayPath(file.Path, r.opts.WorkingDir)
+
+		if file.Error != nil {
+			errorCases = append(errorCases, JUnitTestCase{
+				Name:      path,
+				ClassName: junitSuiteName,
+				File:      path,
+				Error:     &JUnitProblem{Message: file.Error.Error(), Type: "error"},
+			})
+		}
+
+		if file.Result == nil || file.Result.FileResult == nil {
+			continue
+		}
+
+		for _, diag := range file.Result.Diagnostics {
+			findings, ok := byRule[diag.RuleID]
+			if !ok {
+				findings = &ruleFindings{name: diag.RuleName}
+				byRule[diag.RuleID] = findings
+			}
+			findings.diags = append(findings.diags, diag)
+			findings.lines = append(findings.lines, fmt.Sprintf("%s:%s %s", path, junitPosition(diag), diag.Message))
+			count++
+		}
+	}
+
+	ruleIDs := make([]string, 0, len(byRule))
+	for ruleID := range byRule {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	slices.Sort(ruleIDs)
+
+	cases := make([]JUnitTestCase, 0, len(ruleIDs)+len(errorCases))
+	for _, ruleID := range ruleIDs {
+		findings := byRule[ruleID]
+		cases = append(cases, JUnitTestCase{
+			Name:      config.FormatRuleID(r.opts.RuleFormat, ruleID, findings.name),
+			ClassName: junitSuiteName,
+			Failure: &JUnitProblem{
+				Message: issueCount(len(findings.diags)),
+				Type:    string(highestSeverity(findings.diags)),
+				Text:    strings.Join(findings.lines, "\n"),
+			},
+		})
+	}
+
+	return append(cases, errorCases...), count
+}
+
+// junitPosition formats a diagnostic position as line:column.
+func junitPosition(diag lint.Diagnostic) string {
+	return strconv.Itoa(diag.StartLine) + ":" + strconv.Itoa(diag.StartColumn)
+}
+
+// issueCount formats a number of issues.
+func issueCount(n int) string {
+	if n == 1 {
+		return "1 issue"
+	}
+	return strconv.Itoa(n) + " issues"
+}
+
+// highestSeverity returns the most severe severity among diagnostics.
+func highestSeverity(diags []lint.Diagnostic) config.Severity {
+	highest := config.SeverityInfo
+	for _, diag := range diags {
+		switch diag.Severity {
+		case config.SeverityError:
+			return config.SeverityError
+		case config.SeverityWarning, "":
+			highest = config.SeverityWarning
+		}
+	}
+	return highest
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
 )
 
 // Options configures reporter behavior.
@@ -25,6 +27,15 @@ type Options struct {
 	// ShowContext includes source line context in diagnostics.
 	ShowContext bool
 
+	// ContextBefore and ContextAfter are the number of source lines shown
+	// before and after a diagnostic's lines in text output.
+	ContextBefore int
+	ContextAfter  int
+
+	// FixReport explains the fix passes of each file in text output: the
+	// edits each rule applied and had skipped, and why.
+	FixReport bool
+
 	// ShowSummary displays aggregate statistics after results.
 	ShowSummary bool
 
@@ -46,6 +57,23 @@ type Options struct {
 	// WorkingDir is the directory to make paths relative to.
 	// If empty, paths are kept as-is (typically absolute).
 	WorkingDir string
+
+	// JUnitGroupBy controls whether JUnit testcases are files or rules.
+	// Empty means JUnitByFile.
+	JUnitGroupBy JUnitGrouping
+
+	// ColumnEncoding is the unit of the columns written: UTF-8 bytes,
+	// UTF-16 code units or Unicode code points. Empty means each format's
+	// default, which is UTF-16 for SARIF and UTF-8 for the others. Formats
+	// whose specification fixes the unit, such as rdjsonl, ignore it.
+	ColumnEncoding mdast.PositionEncoding
+
+	// ToolVersion is the gomdlint version recorded in SARIF output.
+	ToolVersion string
+
+	// Registry supplies rule metadata for SARIF and jsonl output.
+	// If nil, lint.DefaultRegistry is used.
+	Registry *lint.Registry
 }
 
 // DefaultOptions returns Options with sensible defaults.
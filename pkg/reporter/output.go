@@ -0,0 +1,184 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// StdoutPath is the Output path that refers to Options.Writer.
+const StdoutPath = "-"
+
+// outputDirPermissions is used for directories created for output files.
+const outputDirPermissions = 0o750
+
+// Output is a format written to a destination.
+type Output struct {
+	// Format is the output format.
+	Format Format
+
+	// Path is the file to write. Empty or StdoutPath means Options.Writer.
+	Path string
+}
+
+// IsStdout reports whether the output writes to Options.Writer.
+func (o Output) IsStdout() bool {
+	return o.Path == "" || o.Path == StdoutPath
+}
+
+// String returns the output in the form accepted by ParseOutput.
+func (o Output) String() string {
+	if o.IsStdout() {
+		return string(o.Format)
+	}
+	return string(o.Format) + "=" + o.Path
+}
+
+// ParseOutput parses an output specification of the form "format" or
+// "format=path". A path of "-" means standard output.
+func ParseOutput(spec string) (Output, error) {
+	formatStr, path, hasPath := strings.Cut(spec, "=")
+	if formatStr == "" {
+		return Output{}, fmt.Errorf("invalid output %q: missing format", spec)
+	}
+	if hasPath && path == "" {
+		return Output{}, fmt.Errorf("invalid output %q: missing path", spec)
+	}
+
+	format, err := ParseFormat(formatStr)
+	if err != nil {
+		return Output{}, fmt.Errorf("invalid output %q: %w", spec, err)
+	}
+
+	return Output{Format: format, Path: path}, nil
+}
+
+// Compile-time interface check for MultiReporter.
+var _ Reporter = (*MultiReporter)(nil)
+
+// MultiReporter fans a single result out to several reporters.
+type MultiReporter struct {
+	reporters     []Reporter
+	fileReporters []FileReporter
+}
+
+// NewMultiReporter creates a reporter that runs each of reporters in order.
+func NewMultiReporter(reporters ...Reporter) *MultiReporter {
+	return &MultiReporter{reporters: reporters}
+}
+
+// Report implements Reporter. Every reporter runs even if an earlier one
+// fails; the returned error joins all failures. The issue count is the
+// largest count reported, since all reporters see the same result.
+func (m *MultiReporter) Report(ctx context.Context, result *runner.Result) (int, error) {
+	count := 0
+	var errs []error
+
+	for _, rep := range m.reporters {
+		n, err := rep.Report(ctx, result)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		count = max(count, n)
+	}
+
+	return count, errors.Join(errs...)
+}
+
+// NewOutputs creates a reporter writing each output from one result.
+// Outputs with a path are written to that file, creating parent directories
+// as needed, and never use color; stdout outputs use opts as given.
+// The returned close function closes the files and must be called after
+// Report, even if it failed.
+func NewOutputs(opts Options, outputs []Output) (Reporter, func() error, error) {
+	if err := checkOutputs(outputs); err != nil {
+		return nil, nil, err
+	}
+
+	var files []*os.File
+	closeFiles := func() error {
+		var errs []error
+		for _, file := range files {
+			if err := file.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("close %s: %w", file.Name(), err))
+			}
+		}
+		files = nil
+		return errors.Join(errs...)
+	}
+
+	reporters := make([]Reporter, 0, len(outputs))
+	for _, output := range outputs {
+		outOpts := opts
+		outOpts.Format = output.Format
+
+		if !output.IsStdout() {
+			file, err := createOutputFile(output.Path)
+			if err != nil {
+				_ = closeFiles()
+				return nil, nil, err
+			}
+			files = append(files, file)
+			outOpts.Writer = file
+			outOpts.Color = "never"
+		}
+
+		rep, err := New(outOpts)
+		if err != nil {
+			_ = closeFiles()
+			return nil, nil, fmt.Errorf("output %s: %w", output, err)
+		}
+		reporters = append(reporters, rep)
+	}
+
+	if len(reporters) == 1 {
+		return reporters[0], closeFiles, nil
+	}
+	return NewMultiReporter(reporters...), closeFiles, nil
+}
+
+// checkOutputs rejects output sets whose destinations collide.
+func checkOutputs(outputs []Output) error {
+	if len(outputs) == 0 {
+		return errors.New("no outputs configured")
+	}
+
+	seen := make(map[string]Output, len(outputs))
+	for _, output := range outputs {
+		key := StdoutPath
+		if !output.IsStdout() {
+			key = filepath.Clean(output.Path)
+		}
+
+		if prev, ok := seen[key]; ok {
+			if key == StdoutPath {
+				return fmt.Errorf("outputs %s and %s both write to standard output", prev, output)
+			}
+			return fmt.Errorf("outputs %s and %s both write to %s", prev, output, output.Path)
+		}
+		seen[key] = output
+	}
+
+	return nil
+}
+
+// createOutputFile creates (or truncates) path and its parent directories.
+func createOutputFile(path string) (*os.File, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, outputDirPermissions); err != nil {
+			return nil, fmt.Errorf("create output directory: %w", err)
+		}
+	}
+
+	file, err := os.Create(path) //nolint:gosec // Output path is chosen by the user.
+	if err != nil {
+		return nil, fmt.Errorf("create output file: %w", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,137 @@
+package reporter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+func TestParseOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec    string
+		want    reporter.Output
+		wantErr bool
+	}{
+		{spec: "text", want: reporter.Output{Format: reporter.FormatText}},
+		{spec: "sarif=results.sarif", want: reporter.Output{Format: reporter.FormatSARIF, Path: "results.sarif"}},
+		{spec: "json=-", want: reporter.Output{Format: reporter.FormatJSON, Path: "-"}},
+		{spec: "json=out/a=b.json", want: reporter.Output{Format: reporter.FormatJSON, Path: "out/a=b.json"}},
+		{spec: "", wantErr: true},
+		{spec: "=report.json", wantErr: true},
+		{spec: "json=", wantErr: true},
+		{spec: "xml=report.xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := reporter.ParseOutput(tt.spec)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// stubReporter records calls and returns fixed values.
+type stubReporter struct {
+	count  int
+	err    error
+	called bool
+}
+
+func (s *stubReporter) Report(context.Context, *runner.Result) (int, error) {
+	s.called = true
+	return s.count, s.err
+}
+
+func TestMultiReporter(t *testing.T) {
+	t.Parallel()
+
+	errWrite := errors.New("disk full")
+	first := &stubReporter{count: 2, err: errWrite}
+	second := &stubReporter{count: 3}
+
+	count, err := reporter.NewMultiReporter(first, second).Report(context.Background(), nil)
+	require.ErrorIs(t, err, errWrite)
+	assert.True(t, first.called)
+	assert.True(t, second.called, "later reporters run after a failure")
+	assert.Equal(t, 3, count)
+}
+
+func TestNewOutputs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	result := createTestResult()
+
+	var stdout bytes.Buffer
+	rep, closeOutputs, err := reporter.NewOutputs(reporter.Options{Writer: &stdout}, []reporter.Output{
+		{Format: reporter.FormatText},
+		{Format: reporter.FormatJSON, Path: filepath.Join(dir, "reports", "report.json")},
+		{Format: reporter.FormatText, Path: filepath.Join(dir, "log.txt")},
+	})
+	require.NoError(t, err)
+
+	count, err := rep.Report(context.Background(), result)
+	require.NoError(t, err)
+	require.NoError(t, closeOutputs())
+	assert.Equal(t, 2, count)
+
+	assert.Contains(t, stdout.String(), "Hard tabs found")
+
+	data, err := os.ReadFile(filepath.Join(dir, "reports", "report.json"))
+	require.NoError(t, err)
+	assert.True(t, json.Valid(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "log.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Hard tabs found")
+}
+
+func TestNewOutputs_Collisions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		outputs []reporter.Output
+	}{
+		{name: "none"},
+		{
+			name:    "two stdout",
+			outputs: []reporter.Output{{Format: reporter.FormatText}, {Format: reporter.FormatJSON, Path: "-"}},
+		},
+		{
+			name: "same file",
+			outputs: []reporter.Output{
+				{Format: reporter.FormatJSON, Path: "out/report"},
+				{Format: reporter.FormatSARIF, Path: "out/../out/report"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := reporter.NewOutputs(reporter.Options{Writer: &bytes.Buffer{}}, tt.outputs)
+			require.Error(t, err)
+		})
+	}
+}
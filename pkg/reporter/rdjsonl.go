@@ -0,0 +1,190 @@
+package reporter
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// rdjsonSource names gomdlint as the source of reviewdog diagnostics.
+const rdjsonSource = "gomdlint"
+
+// RDDiagnostic is a diagnostic in reviewdog's Diagnostic Format (rdjson),
+// one per line in rdjsonl output.
+type RDDiagnostic struct {
+	Message     string         `json:"message"`
+	Location    RDLocation     `json:"location"`
+	Severity    string         `json:"severity,omitempty"`
+	Source      *RDSource      `json:"source,omitempty"`
+	Code        *RDCode        `json:"code,omitempty"`
+	Suggestions []RDSuggestion `json:"suggestions,omitempty"`
+}
+
+// RDLocation is the file and range of a diagnostic.
+type RDLocation struct {
+	Path  string   `json:"path"`
+	Range *RDRange `json:"range,omitempty"`
+}
+
+// RDRange is a range in a file. End is exclusive; without it the range is
+// empty.
+type RDRange struct {
+	Start RDPosition  `json:"start"`
+	End   *RDPosition `json:"end,omitempty"`
+}
+
+// RDPosition is a 1-based line and 1-based column, counted in UTF-8 bytes.
+type RDPosition struct {
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+// RDSource identifies the tool that produced a diagnostic.
+type RDSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// RDCode identifies the rule of a diagnostic.
+type RDCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// RDSuggestion replaces Range with Text.
+type RDSuggestion struct {
+	Range RDRange `json:"range"`
+	Text  string  `json:"text"`
+}
+
+// RDJSONLReporter writes reviewdog rdjsonl: one rdjson diagnostic per line,
+// with fix edits as suggestions so reviewdog can propose them on pull
+// requests. Files that could not be processed are reported on ErrorWriter.
+type RDJSONLReporter struct {
+	opts Options
+	out  io.Writer
+}
+
+// NewRDJSONLReporter creates a new reviewdog rdjsonl reporter.
+func NewRDJSONLReporter(opts Options) *RDJSONLReporter {
+	return &RDJSONLReporter{
+		opts: opts,
+		out:  opts.Writer,
+	}
+}
+
+// Report implements Reporter.
+func (r *RDJSONLReporter) Report(ctx context.Context, result *runner.Result) (int, error) {
+	return reportFiles(ctx, r, result)
+}
+
+// ReportFile implements FileReporter.
+func (r *RDJSONLReporter) ReportFile(_ context.Context, outcome runner.FileOutcome) (int, error) {
+	path := displayPath(outcome.Path, r.opts.WorkingDir)
+
+	if outcome.Error != nil {
+		if r.opts.ErrorWriter != nil {
+			fmt.Fprintf(r.opts.ErrorWriter, "%s: error: %v\n", path, outcome.Error)
+		}
+		return 0, nil
+	}
+
+	if outcome.Result == nil || outcome.Result.FileResult == nil {
+		return 0, nil
+	}
+
+	var content []byte
+	if outcome.Result.Snapshot != nil {
+		content = outcome.Result.Snapshot.Content
+	}
+	text := newSourceText(content, mdast.PositionEncodingUTF8)
+
+	encoder := json.NewEncoder(r.out)
+	for _, diag := range outcome.Result.Diagnostics {
+		if err := encoder.Encode(r.diagnostic(path, diag, text)); err != nil {
+			return 0, fmt.Errorf("encode rdjson: %w", err)
+		}
+	}
+	return len(outcome.Result.Diagnostics), nil
+}
+
+// Finish implements FileReporter. rdjsonl output has no trailer.
+func (r *RDJSONLReporter) Finish(_ context.Context, _ *runner.Result) (int, error) {
+	return 0, nil
+}
+
+// diagnostic converts a lint diagnostic to rdjson.
+func (r *RDJSONLReporter) diagnostic(path string, diag lint.Diagnostic, text *sourceText) RDDiagnostic {
+	rd := RDDiagnostic{
+		Message:  diag.Message,
+		Location: RDLocation{Path: path},
+		Severity: rdjsonSeverity(diag.Severity),
+		Source:   &RDSource{Name: rdjsonSource, URL: sarifInformationURI},
+		Code: &RDCode{
+			Value: config.FormatRuleID(r.opts.RuleFormat, diag.RuleID, diag.RuleName),
+			URL:   ruleHelpURI(diag.RuleID),
+		},
+	}
+
+	if diag.StartLine > 0 {
+		rd.Location.Range = &RDRange{Start: RDPosition{Line: diag.StartLine, Column: max(diag.StartColumn, 0)}}
+		if diag.EndLine >= diag.StartLine {
+			rd.Location.Range.End = &RDPosition{Line: diag.EndLine, Column: max(diag.EndColumn, 0)}
+		}
+	}
+
+	rd.Suggestions = rdjsonSuggestions(diag.FixEdits, text)
+	return rd
+}
+
+// rdjsonSuggestions converts fix edits, whose positions are byte offsets,
+// to suggestions with line and column ranges. Edits cannot be converted
+// without the file content, and are then omitted.
+func rdjsonSuggestions(edits []fix.TextEdit, text *sourceText) []RDSuggestion {
+	if len(edits) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(edits)
+	slices.SortFunc(sorted, func(a, b fix.TextEdit) int {
+		return cmp.Compare(a.StartOffset, b.StartOffset)
+	})
+
+	suggestions := make([]RDSuggestion, 0, len(sorted))
+	for _, edit := range sorted {
+		startLine, startCol, okStart := text.position(edit.StartOffset)
+		endLine, endCol, okEnd := text.position(edit.EndOffset)
+		if !okStart || !okEnd || edit.EndOffset < edit.StartOffset {
+			return nil
+		}
+		suggestions = append(suggestions, RDSuggestion{
+			Range: RDRange{
+				Start: RDPosition{Line: startLine, Column: startCol},
+				End:   &RDPosition{Line: endLine, Column: endCol},
+			},
+			Text: edit.NewText,
+		})
+	}
+	return suggestions
+}
+
+// rdjsonSeverity converts a severity to reviewdog's severity names.
+func rdjsonSeverity(severity config.Severity) string {
+	switch severity {
+	case config.SeverityError:
+		return "ERROR"
+	case config.SeverityInfo:
+		return "INFO"
+	default:
+		return "WARNING"
+	}
+}
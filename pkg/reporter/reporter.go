@@ -23,6 +23,9 @@ type Reporter interface {
 type reporterFacade struct {
 	renderer     Renderer
 	analysisOpts analysis.Options
+
+	// files collects outcomes when streaming.
+	files []runner.FileOutcome
 }
 
 // Report implements Reporter by analyzing the result and rendering it.
@@ -66,10 +69,32 @@ func New(opts Options) (Reporter, error) {
 	if !format.IsValid() {
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
+	if opts.ColumnEncoding != "" && !opts.ColumnEncoding.IsValid() {
+		return nil, fmt.Errorf("unsupported column encoding: %s", opts.ColumnEncoding)
+	}
+
+	rep, err := newFormatReporter(opts, format)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatText, FormatSARIF, FormatRDJSONL, FormatDiff:
+		// Text and SARIF convert columns themselves, rdjsonl columns are
+		// always bytes and diffs have none.
+		return rep, nil
+	default:
+		return withColumnEncoding(rep, opts.ColumnEncoding), nil
+	}
+}
 
+// newFormatReporter creates the reporter for a validated format.
+func newFormatReporter(opts Options, format Format) (Reporter, error) {
 	switch format {
 	case FormatJSON:
 		return NewJSONReporter(opts), nil
+	case FormatJSONL:
+		return NewJSONLReporter(opts), nil
 	case FormatSARIF:
 		return NewSARIFReporter(opts), nil
 	case FormatDiff:
@@ -80,6 +105,21 @@ func New(opts Options) (Reporter, error) {
 		return NewTextReporter(opts), nil
 	case FormatSummary:
 		return newRendererFacade(NewSummaryRenderer(opts), opts), nil
+	case FormatHTML:
+		return NewHTMLReporter(opts), nil
+	case FormatGitHub:
+		return NewGitHubReporter(opts), nil
+	case FormatGitLab:
+		return NewGitLabReporter(opts), nil
+	case FormatRDJSONL:
+		return NewRDJSONLReporter(opts), nil
+	case FormatJUnit:
+		if opts.JUnitGroupBy != "" && !opts.JUnitGroupBy.IsValid() {
+			return nil, fmt.Errorf("unsupported JUnit grouping: %s", opts.JUnitGroupBy)
+		}
+		return NewJUnitReporter(opts), nil
+	case FormatCheckstyle:
+		return NewCheckstyleReporter(opts), nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
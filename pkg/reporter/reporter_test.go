@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
 	"github.com/yaklabco/gomdlint/pkg/lint"
 	"github.com/yaklabco/gomdlint/pkg/reporter"
 	"github.com/yaklabco/gomdlint/pkg/runner"
@@ -29,6 +30,11 @@ func TestParseFormat(t *testing.T) {
 		{name: "diff", input: "diff", want: reporter.FormatDiff},
 		{name: "unknown format", input: "xml", wantErr: true},
 		{name: "sarif", input: "sarif", want: reporter.FormatSARIF},
+		{name: "html", input: "html", want: reporter.FormatHTML},
+		{name: "github", input: "github", want: reporter.FormatGitHub},
+		{name: "gitlab", input: "gitlab", want: reporter.FormatGitLab},
+		{name: "junit", input: "junit", want: reporter.FormatJUnit},
+		{name: "checkstyle", input: "checkstyle", want: reporter.FormatCheckstyle},
 	}
 
 	for _, tt := range tests {
@@ -74,6 +80,11 @@ func TestNew(t *testing.T) {
 		{name: "json reporter", format: reporter.FormatJSON},
 		{name: "sarif reporter", format: reporter.FormatSARIF},
 		{name: "diff reporter", format: reporter.FormatDiff},
+		{name: "github reporter", format: reporter.FormatGitHub},
+		{name: "gitlab reporter", format: reporter.FormatGitLab},
+		{name: "junit reporter", format: reporter.FormatJUnit},
+		{name: "checkstyle reporter", format: reporter.FormatCheckstyle},
+		{name: "html reporter", format: reporter.FormatHTML},
 		{name: "empty defaults to text", format: ""},
 		{name: "unknown format", format: "xml", wantErr: true},
 	}
@@ -156,6 +167,110 @@ func TestTextReporter_WithDiagnostics(t *testing.T) {
 	assert.Contains(t, output, "2 issues") // One-line summary format
 }
 
+func TestTextReporter_RenderingRejected(t *testing.T) {
+	for _, groupByFile := range []bool{true, false} {
+		var buf bytes.Buffer
+		rep := reporter.NewTextReporter(reporter.Options{
+			Writer:      &buf,
+			Color:       "never",
+			GroupByFile: groupByFile,
+		})
+
+		result := createTestResult()
+		result.Files[0].Result.RenderingRejected = []string{"MD001", "MD013"}
+
+		_, err := rep.Report(context.Background(), result)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(),
+			"warning: fixes not applied because they change the rendered output: MD001, MD013")
+	}
+}
+
+func TestTextReporter_FixReport(t *testing.T) {
+	trailing := fix.TextEdit{StartOffset: 40, EndOffset: 43, Origin: fix.Origin{RuleID: "MD009", Line: 12, Column: 40}}
+	wrap := fix.TextEdit{StartOffset: 41, EndOffset: 41, NewText: "\n", Origin: fix.Origin{RuleID: "MD013", Line: 12, Column: 1}}
+	dash := fix.TextEdit{StartOffset: 0, EndOffset: 1, NewText: "-", Origin: fix.Origin{RuleID: "MD004", Line: 3, Column: 1}}
+	star := fix.TextEdit{StartOffset: 0, EndOffset: 1, NewText: "*", Origin: fix.Origin{RuleID: "MD004", Line: 3, Column: 1}}
+	spaces := fix.TextEdit{StartOffset: 0, EndOffset: 1, NewText: "*", Origin: fix.Origin{RuleID: "MD030", Line: 3, Column: 1}}
+	passes := []lint.FixPass{
+		{
+			Rules: map[string]lint.EditCounts{"MD009": {Applied: 1}, "MD013": {Skipped: 1}, "MD004": {Applied: 1}},
+			Conflicts: []lint.EditConflict{
+				{Conflict: fix.Conflict{Skipped: wrap, Accepted: trailing}, Line: 12, Column: 40},
+			},
+		},
+		{
+			Rules:     map[string]lint.EditCounts{"MD030": {Applied: 1}},
+			Reversals: []lint.EditReversal{{Undone: dash, Undoing: spaces}},
+		},
+		{
+			Rules:     map[string]lint.EditCounts{"MD004": {Applied: 1}},
+			Reversals: []lint.EditReversal{{Undone: spaces, Undoing: star}},
+		},
+	}
+
+	for _, groupByFile := range []bool{true, false} {
+		var buf bytes.Buffer
+		rep := reporter.NewTextReporter(reporter.Options{
+			Writer:      &buf,
+			Color:       "never",
+			GroupByFile: groupByFile,
+			FixReport:   true,
+			RuleFormat:  config.RuleFormatName,
+		})
+
+		result := createTestResult()
+		result.Files[0].Result.Passes = passes
+
+		_, err := rep.Report(context.Background(), result)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "fix report, 3 passes")
+		assert.Contains(t, output, "pass 1: line-length 1 skipped; no-trailing-spaces 1 applied; unordered-list-style 1 applied")
+		assert.Contains(t, output, "line-length edit for 12:1 skipped: overlaps no-trailing-spaces edit at 12:40")
+		assert.Contains(t, output, "list-marker-space edit for 3:1 undoes the unordered-list-style edit for 3:1 of pass 1")
+		assert.Contains(t, output, "warning: unordered-list-style and list-marker-space undo each other's edits")
+		assert.Equal(t, 1, strings.Count(output, "undo each other's edits"))
+	}
+}
+
+func TestTextReporter_FixReportDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	rep := reporter.NewTextReporter(reporter.Options{
+		Writer: &buf,
+		Color:  "never",
+	})
+
+	result := createTestResult()
+	result.Files[0].Result.Passes = []lint.FixPass{{Rules: map[string]lint.EditCounts{"MD009": {Applied: 1}}}}
+
+	_, err := rep.Report(context.Background(), result)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "fix report")
+}
+
+func TestTextReporter_ExhaustedRules(t *testing.T) {
+	var buf bytes.Buffer
+	rep := reporter.NewTextReporter(reporter.Options{
+		Writer:      &buf,
+		Color:       "never",
+		GroupByFile: true,
+		RuleFormat:  config.RuleFormatName,
+	})
+
+	result := createTestResult()
+	result.Files[0].Exhausted = true
+	result.Files[0].FixPasses = 10
+	result.Files[0].RemainingEdits = 2
+	result.Files[0].Result.RemainingRules = []string{"MD004", "MD030"}
+
+	_, err := rep.Report(context.Background(), result)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(),
+		"warning: fix loop exhausted after 10 passes with 2 edits remaining from unordered-list-style, list-marker-space")
+}
+
 func TestJSONReporter_NilResult(t *testing.T) {
 	var buf bytes.Buffer
 	rep := reporter.NewJSONReporter(reporter.Options{
@@ -171,7 +286,7 @@ func TestJSONReporter_NilResult(t *testing.T) {
 	var output reporter.JSONOutput
 	err = json.Unmarshal(buf.Bytes(), &output)
 	require.NoError(t, err)
-	assert.Equal(t, "1.0.0", output.Version)
+	assert.Equal(t, reporter.JSONVersion, output.Version)
 	assert.Empty(t, output.Files)
 }
 
@@ -192,7 +307,7 @@ func TestJSONReporter_WithDiagnostics(t *testing.T) {
 	err = json.Unmarshal(buf.Bytes(), &output)
 	require.NoError(t, err)
 
-	assert.Equal(t, "1.0.0", output.Version)
+	assert.Equal(t, reporter.JSONVersion, output.Version)
 	assert.Len(t, output.Files, 1)
 	assert.Len(t, output.Files[0].Diagnostics, 2)
 	assert.Equal(t, 2, output.Summary.TotalIssues)
@@ -1,12 +1,18 @@
 package reporter
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 
 	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
 	"github.com/yaklabco/gomdlint/pkg/runner"
 )
 
@@ -16,6 +22,22 @@ const sarifVersion = "2.1.0"
 // SARIF schema URI.
 const sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
 
+// sarifInformationURI is the tool's home page.
+const sarifInformationURI = "https://github.com/yaklabco/gomdlint"
+
+// sarifColumnKinds declares how columns and character offsets are counted
+// in each position encoding. SARIF cannot declare byte columns; the run's
+// columnEncoding property records them instead.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var sarifColumnKinds = map[mdast.PositionEncoding]string{
+	mdast.PositionEncodingUTF16: "utf16CodeUnits",
+	mdast.PositionEncodingUTF32: "unicodeCodePoints",
+}
+
+// sarifFingerprintKey is the partialFingerprints key for line-content hashes.
+const sarifFingerprintKey = "lineContentHash/v1"
+
 // SARIFOutput represents the root SARIF document.
 type SARIFOutput struct {
 	Schema  string     `json:"$schema"`
@@ -25,8 +47,20 @@ type SARIFOutput struct {
 
 // SARIFRun represents a single analysis run.
 type SARIFRun struct {
-	Tool    SARIFTool     `json:"tool"`
-	Results []SARIFResult `json:"results"`
+	Tool               SARIFTool                        `json:"tool"`
+	Invocations        []SARIFInvocation                `json:"invocations,omitempty"`
+	OriginalURIBaseIDs map[string]SARIFArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []SARIFResult                    `json:"results"`
+	ColumnKind         string                           `json:"columnKind,omitempty"`
+	AutomationDetails  *SARIFAutomationDetails          `json:"automationDetails,omitempty"`
+	Properties         map[string]any                   `json:"properties,omitempty"`
+}
+
+// SARIFAutomationDetails identifies the run among related runs. GitHub
+// code scanning treats the part of ID before the last slash as the upload
+// category.
+type SARIFAutomationDetails struct {
+	ID string `json:"id"`
 }
 
 // SARIFTool describes the analysis tool.
@@ -37,7 +71,7 @@ type SARIFTool struct {
 // SARIFDriver contains tool metadata and rules.
 type SARIFDriver struct {
 	Name           string      `json:"name"`
-	Version        string      `json:"version"`
+	Version        string      `json:"version,omitempty"`
 	InformationURI string      `json:"informationUri"`
 	Rules          []SARIFRule `json:"rules"`
 }
@@ -46,7 +80,8 @@ type SARIFDriver struct {
 type SARIFRule struct {
 	ID               string               `json:"id"`
 	Name             string               `json:"name,omitempty"`
-	ShortDescription SARIFMultiformatText `json:"shortDescription,omitempty"`
+	ShortDescription SARIFMultiformatText `json:"shortDescription"`
+	HelpURI          string               `json:"helpUri,omitempty"`
 	DefaultConfig    *SARIFRuleConfig     `json:"defaultConfiguration,omitempty"`
 	Properties       map[string]any       `json:"properties,omitempty"`
 }
@@ -58,16 +93,39 @@ type SARIFMultiformatText struct {
 
 // SARIFRuleConfig contains rule configuration.
 type SARIFRuleConfig struct {
-	Level string `json:"level"`
+	Enabled *bool  `json:"enabled,omitempty"`
+	Level   string `json:"level"`
+}
+
+// SARIFInvocation describes the execution of the tool.
+type SARIFInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []SARIFNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+// SARIFNotification reports a problem encountered while running the tool.
+type SARIFNotification struct {
+	Level          string                    `json:"level"`
+	Message        SARIFMessage              `json:"message"`
+	Locations      []SARIFLocation           `json:"locations,omitempty"`
+	AssociatedRule *SARIFReportingDescriptor `json:"associatedRule,omitempty"`
+}
+
+// SARIFReportingDescriptor references a rule in the driver's rule list.
+type SARIFReportingDescriptor struct {
+	ID    string `json:"id"`
+	Index *int   `json:"index,omitempty"`
 }
 
 // SARIFResult represents a single diagnostic result.
 type SARIFResult struct {
-	RuleID    string          `json:"ruleId"`
-	Level     string          `json:"level"`
-	Message   SARIFMessage    `json:"message"`
-	Locations []SARIFLocation `json:"locations"`
-	Fixes     []SARIFFix      `json:"fixes,omitempty"`
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             SARIFMessage      `json:"message"`
+	Locations           []SARIFLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Fixes               []SARIFFix        `json:"fixes,omitempty"`
 }
 
 // SARIFMessage contains the result message.
@@ -83,25 +141,32 @@ type SARIFLocation struct {
 // SARIFPhysicalLocation contains file path and region.
 type SARIFPhysicalLocation struct {
 	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
-	Region           SARIFRegion           `json:"region"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
 }
 
-// SARIFArtifactLocation contains the file URI.
+// SARIFArtifactLocation contains the file URI, relative to URIBaseID when set.
 type SARIFArtifactLocation struct {
-	URI string `json:"uri"`
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
 }
 
-// SARIFRegion describes the affected text region.
+// SARIFRegion describes the affected text region. Line and column regions
+// are used for result locations; offset regions for fix replacements.
+// Offsets are pointers because zero is a valid offset and length.
 type SARIFRegion struct {
-	StartLine   int `json:"startLine"`
-	StartColumn int `json:"startColumn,omitempty"`
-	EndLine     int `json:"endLine,omitempty"`
-	EndColumn   int `json:"endColumn,omitempty"`
+	StartLine   int  `json:"startLine,omitempty"`
+	StartColumn int  `json:"startColumn,omitempty"`
+	EndLine     int  `json:"endLine,omitempty"`
+	EndColumn   int  `json:"endColumn,omitempty"`
+	CharOffset  *int `json:"charOffset,omitempty"`
+	CharLength  *int `json:"charLength,omitempty"`
+	ByteOffset  *int `json:"byteOffset,omitempty"`
+	ByteLength  *int `json:"byteLength,omitempty"`
 }
 
 // SARIFFix represents a proposed fix.
 type SARIFFix struct {
-	Description     SARIFMessage          `json:"description"`
+	Description     *SARIFMessage         `json:"description,omitempty"`
 	ArtifactChanges []SARIFArtifactChange `json:"artifactChanges"`
 }
 
@@ -152,110 +217,260 @@ func (r *SARIFReporter) Report(_ context.Context, result *runner.Result) (int, e
 	return len(output.Runs[0].Results), nil
 }
 
+// readsSource implements sourceReporter: the source is read for the snippets of the results.
+func (r *SARIFReporter) readsSource() bool {
+	return true
+}
+
+// sarifBuilder accumulates the rules, results and notifications of a run.
+type sarifBuilder struct {
+	registry  *lint.Registry
+	uris      *sarifURIResolver
+	run       *SARIFRun
+	ruleIndex map[string]int
+	encoding  mdast.PositionEncoding
+}
+
 func (r *SARIFReporter) buildOutput(result *runner.Result) *SARIFOutput {
-	output := &SARIFOutput{
+	registry := r.opts.Registry
+	if registry == nil {
+		registry = lint.DefaultRegistry
+	}
+
+	uris := newSARIFURIResolver(r.opts.WorkingDir)
+
+	run := SARIFRun{
+		Tool: SARIFTool{
+			Driver: SARIFDriver{
+				Name:           "gomdlint",
+				Version:        r.opts.ToolVersion,
+				InformationURI: sarifInformationURI,
+				Rules:          make([]SARIFRule, 0),
+			},
+		},
+		OriginalURIBaseIDs: uris.baseIDs(),
+		Results:            make([]SARIFResult, 0),
+	}
+
+	// GitHub code scanning expects UTF-16 code units.
+	encoding := cmp.Or(r.opts.ColumnEncoding, mdast.PositionEncodingUTF16)
+	if kind, ok := sarifColumnKinds[encoding]; ok {
+		run.ColumnKind = kind
+	} else {
+		run.Properties = map[string]any{"columnEncoding": string(encoding)}
+	}
+
+	builder := &sarifBuilder{
+		registry:  registry,
+		uris:      uris,
+		run:       &run,
+		ruleIndex: make(map[string]int),
+		encoding:  encoding,
+	}
+
+	var notifications []SARIFNotification
+	if result != nil {
+		for _, file := range result.Files {
+			notifications = append(notifications, builder.addFile(file)...)
+		}
+		for _, err := range result.Errors {
+			notifications = append(notifications, SARIFNotification{
+				Level:   "error",
+				Message: SARIFMessage{Text: err.Error()},
+			})
+		}
+	}
+
+	// Each shard is its own category, so uploading one shard's results
+	// does not close the alerts found by the others.
+	if result != nil && result.Shard != nil {
+		run.AutomationDetails = &SARIFAutomationDetails{
+			ID: fmt.Sprintf("gomdlint/shard-%d-of-%d/", result.Shard.Index, result.Shard.Count),
+		}
+		if run.Properties == nil {
+			run.Properties = make(map[string]any)
+		}
+		run.Properties["shard"] = map[string]int{
+			"index":      result.Shard.Index,
+			"count":      result.Shard.Count,
+			"totalFiles": result.Shard.TotalFiles,
+		}
+	}
+
+	run.Invocations = []SARIFInvocation{{
+		ExecutionSuccessful:        len(notifications) == 0,
+		ToolExecutionNotifications: notifications,
+	}}
+
+	return &SARIFOutput{
 		Schema:  sarifSchemaURI,
 		Version: sarifVersion,
-		Runs: []SARIFRun{{
-			Tool: SARIFTool{
-				Driver: SARIFDriver{
-					Name:           "gomdlint",
-					Version:        "0.1.0",
-					InformationURI: "https://github.com/yaklabco/gomdlint",
-					Rules:          make([]SARIFRule, 0),
-				},
+		Runs:    []SARIFRun{run},
+	}
+}
+
+// addFile adds the results of one file and returns notifications for
+// processing and rule errors.
+func (b *sarifBuilder) addFile(file runner.FileOutcome) []SARIFNotification {
+	var notifications []SARIFNotification
+
+	if file.Error != nil {
+		notifications = append(notifications, SARIFNotification{
+			Level:     "error",
+			Message:   SARIFMessage{Text: file.Error.Error()},
+			Locations: []SARIFLocation{{PhysicalLocation: SARIFPhysicalLocation{ArtifactLocation: b.uris.location(file.Path)}}},
+		})
+	}
+
+	if file.Result == nil || file.Result.FileResult == nil {
+		return notifications
+	}
+
+	var content []byte
+	if file.Result.Snapshot != nil {
+		content = file.Result.Snapshot.Content
+	}
+	text := newSourceText(content, b.encoding)
+	fingerprints := newLineFingerprinter()
+
+	for _, diag := range file.Result.Diagnostics {
+		b.run.Results = append(b.run.Results, b.buildResult(diag, text, fingerprints))
+	}
+
+	// Sort rule IDs so notifications are deterministic.
+	ruleIDs := make([]string, 0, len(file.Result.RuleErrors))
+	for ruleID := range file.Result.RuleErrors {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	slices.Sort(ruleIDs)
+
+	for _, ruleID := range ruleIDs {
+		index := b.addRule(ruleID, "")
+		notifications = append(notifications, SARIFNotification{
+			Level:     "error",
+			Message:   SARIFMessage{Text: fmt.Sprintf("rule %s failed: %v", ruleID, file.Result.RuleErrors[ruleID])},
+			Locations: []SARIFLocation{{PhysicalLocation: SARIFPhysicalLocation{ArtifactLocation: b.uris.location(file.Path)}}},
+			AssociatedRule: &SARIFReportingDescriptor{
+				ID:    ruleID,
+				Index: &index,
+			},
+		})
+	}
+
+	return notifications
+}
+
+// buildResult converts a diagnostic into a SARIF result.
+func (b *sarifBuilder) buildResult(diag lint.Diagnostic, text *sourceText, fingerprints *lineFingerprinter) SARIFResult {
+	artifact := b.uris.location(diag.FilePath)
+
+	sarifResult := SARIFResult{
+		RuleID:    diag.RuleID,
+		RuleIndex: b.addRule(diag.RuleID, diag.RuleName),
+		Level:     severityToSARIFLevel(diag.Severity),
+		Message: SARIFMessage{
+			Text: diag.Message,
+		},
+		Locations: []SARIFLocation{{
+			PhysicalLocation: SARIFPhysicalLocation{
+				ArtifactLocation: artifact,
+				Region:           text.region(diag),
 			},
-			Results: make([]SARIFResult, 0),
 		}},
+		PartialFingerprints: map[string]string{
+			sarifFingerprintKey: fingerprints.fingerprint(diag, text),
+		},
 	}
 
-	if result == nil {
-		return output
+	if len(diag.FixEdits) > 0 {
+		sarifResult.Fixes = []SARIFFix{buildSARIFFix(diag, artifact, text)}
 	}
 
-	// Track rules we've already added
-	rulesSeen := make(map[string]bool)
+	return sarifResult
+}
 
-	for _, file := range result.Files {
-		if file.Result == nil || file.Result.FileResult == nil {
-			continue
+// buildSARIFFix converts a diagnostic's edits into a single fix with one
+// artifact change holding every replacement.
+func buildSARIFFix(diag lint.Diagnostic, artifact SARIFArtifactLocation, text *sourceText) SARIFFix {
+	change := SARIFArtifactChange{
+		ArtifactLocation: artifact,
+		Replacements:     make([]SARIFReplacement, 0, len(diag.FixEdits)),
+	}
+
+	edits := slices.Clone(diag.FixEdits)
+	slices.SortStableFunc(edits, func(a, b fix.TextEdit) int {
+		return a.StartOffset - b.StartOffset
+	})
+
+	for _, edit := range edits {
+		replacement := SARIFReplacement{DeletedRegion: text.offsetRegion(edit)}
+		if edit.NewText != "" {
+			replacement.InsertedContent = &SARIFInsertedContent{Text: edit.NewText}
 		}
+		change.Replacements = append(change.Replacements, replacement)
+	}
+
+	sarifFix := SARIFFix{ArtifactChanges: []SARIFArtifactChange{change}}
+	if diag.Suggestion != "" {
+		sarifFix.Description = &SARIFMessage{Text: diag.Suggestion}
+	}
+	return sarifFix
+}
+
+// addRule adds a rule to the driver if not yet present and returns its index.
+// Metadata comes from the registry; rules unknown to the registry (such as
+// project-level checks) fall back to the diagnostic's rule name.
+func (b *sarifBuilder) addRule(ruleID, ruleName string) int {
+	if index, ok := b.ruleIndex[ruleID]; ok {
+		return index
+	}
 
-		for _, diag := range file.Result.Diagnostics {
-			// Add rule if not already seen
-			if !rulesSeen[diag.RuleID] {
-				rule := SARIFRule{
-					ID:   diag.RuleID,
-					Name: diag.RuleName,
-					ShortDescription: SARIFMultiformatText{
-						Text: diag.Message,
-					},
-					DefaultConfig: &SARIFRuleConfig{
-						Level: severityToSARIFLevel(diag.Severity),
-					},
-				}
-				output.Runs[0].Tool.Driver.Rules = append(output.Runs[0].Tool.Driver.Rules, rule)
-				rulesSeen[diag.RuleID] = true
-			}
-
-			// Create result
-			sarifResult := SARIFResult{
-				RuleID: diag.RuleID,
-				Level:  severityToSARIFLevel(diag.Severity),
-				Message: SARIFMessage{
-					Text: diag.Message,
-				},
-				Locations: []SARIFLocation{{
-					PhysicalLocation: SARIFPhysicalLocation{
-						ArtifactLocation: SARIFArtifactLocation{
-							URI: diag.FilePath,
-						},
-						Region: SARIFRegion{
-							StartLine:   diag.StartLine,
-							StartColumn: diag.StartColumn,
-							EndLine:     diag.EndLine,
-							EndColumn:   diag.EndColumn,
-						},
-					},
-				}},
-			}
-
-			// Add fixes if available
-			if len(diag.FixEdits) > 0 && diag.Suggestion != "" {
-				fix := SARIFFix{
-					Description: SARIFMessage{
-						Text: diag.Suggestion,
-					},
-					ArtifactChanges: make([]SARIFArtifactChange, 0, len(diag.FixEdits)),
-				}
-
-				for _, edit := range diag.FixEdits {
-					change := SARIFArtifactChange{
-						ArtifactLocation: SARIFArtifactLocation{
-							URI: diag.FilePath,
-						},
-						Replacements: []SARIFReplacement{{
-							DeletedRegion: SARIFRegion{
-								// Note: SARIF uses byte offsets differently
-								// This is a simplified representation
-								StartLine: diag.StartLine,
-							},
-							InsertedContent: &SARIFInsertedContent{
-								Text: edit.NewText,
-							},
-						}},
-					}
-					fix.ArtifactChanges = append(fix.ArtifactChanges, change)
-				}
-
-				sarifResult.Fixes = append(sarifResult.Fixes, fix)
-			}
-
-			output.Runs[0].Results = append(output.Runs[0].Results, sarifResult)
+	rule := SARIFRule{
+		ID:   ruleID,
+		Name: ruleName,
+		ShortDescription: SARIFMultiformatText{
+			Text: cmp.Or(ruleName, ruleID),
+		},
+		HelpURI: ruleHelpURI(ruleID),
+		DefaultConfig: &SARIFRuleConfig{
+			Level: severityToSARIFLevel(config.SeverityWarning),
+		},
+	}
+
+	if registered, ok := b.registry.GetByID(ruleID); ok {
+		enabled := registered.DefaultEnabled()
+		rule.Name = registered.Name()
+		rule.ShortDescription.Text = registered.Description()
+		rule.DefaultConfig = &SARIFRuleConfig{
+			Enabled: &enabled,
+			Level:   severityToSARIFLevel(registered.DefaultSeverity()),
+		}
+		rule.Properties = map[string]any{
+			"fixable": registered.CanFix(),
+		}
+		if tags := registered.Tags(); len(tags) > 0 {
+			rule.Properties["tags"] = tags
 		}
 	}
 
-	return output
+	index := len(b.run.Tool.Driver.Rules)
+	b.run.Tool.Driver.Rules = append(b.run.Tool.Driver.Rules, rule)
+	b.ruleIndex[ruleID] = index
+	return index
+}
+
+// ruleHelpURI returns documentation for a rule. MD rules follow markdownlint,
+// whose documentation describes them; gomdlint's own rules are documented
+// in the rules package.
+func ruleHelpURI(ruleID string) string {
+	switch {
+	case strings.HasPrefix(ruleID, "MDL"):
+		return "https://pkg.go.dev/github.com/yaklabco/gomdlint/pkg/lint/rules"
+	case strings.HasPrefix(ruleID, "MD"):
+		return "https://github.com/DavidAnson/markdownlint/blob/main/doc/" + strings.ToLower(ruleID) + ".md"
+	default:
+		return ""
+	}
 }
 
 // severityToSARIFLevel converts gomdlint severity to SARIF level.
@@ -0,0 +1,59 @@
+package reporter
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// sarifSourceRootID is the uriBaseId for artifact URIs relative to the
+// source root.
+const sarifSourceRootID = "%SRCROOT%"
+
+// sarifURIResolver maps file paths to SARIF artifact locations. Files under
+// the source root (the repository containing the working directory, or the
+// working directory itself) get URIs relative to %SRCROOT%.
+type sarifURIResolver struct {
+	repoPaths
+}
+
+func newSARIFURIResolver(workDir string) *sarifURIResolver {
+	return &sarifURIResolver{repoPaths: newRepoPaths(workDir)}
+}
+
+// baseIDs returns the originalUriBaseIds of the run.
+func (u *sarifURIResolver) baseIDs() map[string]SARIFArtifactLocation {
+	if u.root == "" {
+		return nil
+	}
+	return map[string]SARIFArtifactLocation{
+		sarifSourceRootID: {URI: fileURI(u.root) + "/"},
+	}
+}
+
+// location returns the artifact location of a file path.
+func (u *sarifURIResolver) location(path string) SARIFArtifactLocation {
+	if u.root == "" {
+		return SARIFArtifactLocation{URI: relativeURI(path)}
+	}
+
+	abs, rel, ok := rootRelative(u.root, u.workDir, path)
+	if !ok {
+		return SARIFArtifactLocation{URI: fileURI(abs)}
+	}
+	return SARIFArtifactLocation{URI: relativeURI(rel), URIBaseID: sarifSourceRootID}
+}
+
+// fileURI returns the file:// URI of an absolute path.
+func fileURI(path string) string {
+	slashed := filepath.ToSlash(path)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed // Windows drive letter
+	}
+	return (&url.URL{Scheme: "file", Path: slashed}).String()
+}
+
+// relativeURI returns a percent-encoded relative URI reference for a path.
+func relativeURI(path string) string {
+	return (&url.URL{Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,323 @@
+package reporter_test
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	_ "github.com/yaklabco/gomdlint/pkg/lint/rules" // Register built-in rules
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+//go:embed testdata/sarif-schema-2.1.0.json
+var sarifSchemaJSON []byte
+
+// validateSARIF checks output against the SARIF 2.1.0 JSON schema.
+func validateSARIF(t *testing.T, output []byte) {
+	t.Helper()
+
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(sarifSchemaJSON))
+	require.NoError(t, err)
+
+	compiler := jsonschema.NewCompiler()
+	require.NoError(t, compiler.AddResource("sarif-schema-2.1.0.json", schemaDoc))
+	schema, err := compiler.Compile("sarif-schema-2.1.0.json")
+	require.NoError(t, err)
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(output))
+	require.NoError(t, err)
+	require.NoError(t, schema.Validate(doc))
+}
+
+// sarifFileOutcome builds a file outcome with content and diagnostics.
+func sarifFileOutcome(path, content string, diags ...lint.Diagnostic) runner.FileOutcome {
+	for i := range diags {
+		diags[i].FilePath = path
+	}
+	return runner.FileOutcome{
+		Path: path,
+		Result: &lint.PipelineResult{
+			Path: path,
+			FileResult: &lint.FileResult{
+				Snapshot:    &mdast.FileSnapshot{Path: path, Content: []byte(content)},
+				Diagnostics: diags,
+				RuleErrors:  map[string]error{},
+			},
+		},
+	}
+}
+
+func renderSARIF(t *testing.T, opts reporter.Options, result *runner.Result) (*reporter.SARIFOutput, []byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	opts.Writer = &buf
+	rep := reporter.NewSARIFReporter(opts)
+	_, err := rep.Report(context.Background(), result)
+	require.NoError(t, err)
+
+	var output reporter.SARIFOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	require.Len(t, output.Runs, 1)
+	return &output, buf.Bytes()
+}
+
+func TestSARIFReporter_SchemaValid(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	path := filepath.Join(workDir, "docs", "a b.md")
+	content := "# Title\n\nCafé 😀 text   \n"
+
+	trailing := lint.Diagnostic{
+		RuleID: "MD009", RuleName: "no-trailing-spaces", Message: "Trailing whitespace",
+		Severity: config.SeverityWarning, StartLine: 3, StartColumn: 16, EndLine: 3, EndColumn: 19,
+		Suggestion: "Remove trailing whitespace",
+		FixEdits:   []fix.TextEdit{{StartOffset: 24, EndOffset: 27}},
+	}
+	outcome := sarifFileOutcome(path, content, trailing)
+	outcome.Result.RuleErrors["MD001"] = errors.New("boom")
+
+	result := runner.NewResult()
+	result.Add(outcome)
+	result.Add(runner.FileOutcome{Path: filepath.Join(workDir, "bad.md"), Error: errors.New("read failed")})
+	result.Errors = append(result.Errors, errors.New("discovery warning"))
+
+	opts := reporter.DefaultOptions()
+	opts.WorkingDir = workDir
+	opts.ToolVersion = "1.2.3"
+	_, raw := renderSARIF(t, opts, result)
+
+	validateSARIF(t, raw)
+}
+
+func TestSARIFReporter_RuleMetadata(t *testing.T) {
+	t.Parallel()
+
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome("test.md", "line   \n", lint.Diagnostic{
+		RuleID: "MD009", RuleName: "no-trailing-spaces", Message: "Trailing whitespace",
+		StartLine: 1, StartColumn: 5, EndLine: 1, EndColumn: 8,
+	}, lint.Diagnostic{
+		RuleID: "MDL012", RuleName: "orphaned-page", Message: "Page is not reachable",
+		StartLine: 1, StartColumn: 1,
+	}))
+
+	opts := reporter.DefaultOptions()
+	opts.ToolVersion = "1.2.3"
+	output, _ := renderSARIF(t, opts, result)
+
+	driver := output.Runs[0].Tool.Driver
+	assert.Equal(t, "1.2.3", driver.Version)
+	require.Len(t, driver.Rules, 2)
+
+	rule := driver.Rules[0]
+	assert.Equal(t, "MD009", rule.ID)
+	assert.Equal(t, "no-trailing-spaces", rule.Name)
+	assert.Equal(t, "Lines should not have trailing spaces", rule.ShortDescription.Text)
+	assert.Equal(t, "https://github.com/DavidAnson/markdownlint/blob/main/doc/md009.md", rule.HelpURI)
+	require.NotNil(t, rule.DefaultConfig)
+	assert.Equal(t, "warning", rule.DefaultConfig.Level)
+	assert.Equal(t, true, rule.Properties["fixable"])
+	assert.Contains(t, rule.Properties["tags"], "whitespace")
+
+	// Rules outside the registry fall back to the diagnostic's rule name.
+	assert.Equal(t, "orphaned-page", driver.Rules[1].ShortDescription.Text)
+	assert.Equal(t, 1, output.Runs[0].Results[1].RuleIndex)
+}
+
+func TestSARIFReporter_FixRegions(t *testing.T) {
+	t.Parallel()
+
+	// "é" is 2 bytes and "😀" is 4 bytes but 2 UTF-16 code units.
+	content := "# T\n\nCafé 😀 x  \n"
+	start := len("# T\n\nCafé 😀 x")
+
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome("test.md", content, lint.Diagnostic{
+		RuleID: "MD009", Message: "Trailing whitespace",
+		StartLine: 3, StartColumn: 13, EndLine: 3, EndColumn: 15,
+		FixEdits: []fix.TextEdit{
+			{StartOffset: start, EndOffset: start + 2},
+			{StartOffset: 0, EndOffset: 0, NewText: "<!-- x -->\n"},
+		},
+	}))
+
+	output, _ := renderSARIF(t, reporter.DefaultOptions(), result)
+	sarifResult := output.Runs[0].Results[0]
+
+	region := sarifResult.Locations[0].PhysicalLocation.Region
+	require.NotNil(t, region)
+	assert.Equal(t, 3, region.StartLine)
+	assert.Equal(t, 10, region.StartColumn)
+	assert.Equal(t, 12, region.EndColumn)
+
+	require.Len(t, sarifResult.Fixes, 1)
+	require.Len(t, sarifResult.Fixes[0].ArtifactChanges, 1)
+	replacements := sarifResult.Fixes[0].ArtifactChanges[0].Replacements
+	require.Len(t, replacements, 2)
+
+	// Replacements are ordered by offset.
+	insert := replacements[0]
+	assert.Equal(t, 0, *insert.DeletedRegion.ByteOffset)
+	assert.Equal(t, 0, *insert.DeletedRegion.ByteLength)
+	require.NotNil(t, insert.InsertedContent)
+	assert.Equal(t, "<!-- x -->\n", insert.InsertedContent.Text)
+
+	deletion := replacements[1]
+	assert.Equal(t, start, *deletion.DeletedRegion.ByteOffset)
+	assert.Equal(t, 2, *deletion.DeletedRegion.ByteLength)
+	assert.Equal(t, start-3, *deletion.DeletedRegion.CharOffset)
+	assert.Equal(t, 2, *deletion.DeletedRegion.CharLength)
+	assert.Nil(t, deletion.InsertedContent)
+
+	// Fixes without a suggestion are still emitted.
+	assert.Nil(t, sarifResult.Fixes[0].Description)
+}
+
+func TestSARIFReporter_PartialFingerprints(t *testing.T) {
+	t.Parallel()
+
+	diag := func(line int) lint.Diagnostic {
+		return lint.Diagnostic{RuleID: "MD009", Message: "Trailing whitespace", StartLine: line, StartColumn: 1}
+	}
+	fingerprints := func(content string, diags ...lint.Diagnostic) []string {
+		result := runner.NewResult()
+		result.Add(sarifFileOutcome("test.md", content, diags...))
+		output, _ := renderSARIF(t, reporter.DefaultOptions(), result)
+
+		var prints []string
+		for _, r := range output.Runs[0].Results {
+			prints = append(prints, r.PartialFingerprints["lineContentHash/v1"])
+		}
+		return prints
+	}
+
+	before := fingerprints("same \nsame \n", diag(1), diag(2))
+	// Lines moved down and re-indented: fingerprints are unchanged.
+	after := fingerprints("# New heading\n\n  same \nsame\t\n", diag(3), diag(4))
+
+	require.Len(t, before, 2)
+	assert.Equal(t, before, after)
+	assert.NotEqual(t, before[0], before[1], "identical lines are disambiguated")
+	assert.Regexp(t, `^[0-9a-f]{16}:1$`, before[0])
+	assert.Regexp(t, `^[0-9a-f]{16}:2$`, before[1])
+
+	other := fingerprints("same \n", lint.Diagnostic{RuleID: "MD010", StartLine: 1})
+	assert.NotEqual(t, before[0], other[0], "fingerprints depend on the rule")
+}
+
+func TestSARIFReporter_URIs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0o755))
+	workDir := filepath.Join(root, "sub")
+	require.NoError(t, os.Mkdir(workDir, 0o755))
+	outside := t.TempDir()
+
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome(filepath.Join(workDir, "my doc.md"), "x\n",
+		lint.Diagnostic{RuleID: "MD001", StartLine: 1}))
+	result.Add(sarifFileOutcome("rel.md", "x\n", lint.Diagnostic{RuleID: "MD001", StartLine: 1}))
+	result.Add(sarifFileOutcome(filepath.Join(outside, "x.md"), "x\n",
+		lint.Diagnostic{RuleID: "MD001", StartLine: 1}))
+
+	opts := reporter.DefaultOptions()
+	opts.WorkingDir = workDir
+	output, _ := renderSARIF(t, opts, result)
+	run := output.Runs[0]
+
+	base, ok := run.OriginalURIBaseIDs["%SRCROOT%"]
+	require.True(t, ok)
+	assert.Equal(t, "file://"+filepath.ToSlash(root)+"/", base.URI)
+
+	locations := make([]reporter.SARIFArtifactLocation, 0, len(run.Results))
+	for _, r := range run.Results {
+		locations = append(locations, r.Locations[0].PhysicalLocation.ArtifactLocation)
+	}
+	assert.Equal(t, reporter.SARIFArtifactLocation{URI: "sub/my%20doc.md", URIBaseID: "%SRCROOT%"}, locations[0])
+	assert.Equal(t, reporter.SARIFArtifactLocation{URI: "sub/rel.md", URIBaseID: "%SRCROOT%"}, locations[1])
+	assert.Equal(t, "file://"+filepath.ToSlash(outside)+"/x.md", locations[2].URI)
+	assert.Empty(t, locations[2].URIBaseID)
+}
+
+func TestSARIFReporter_Invocations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful", func(t *testing.T) {
+		t.Parallel()
+
+		result := runner.NewResult()
+		result.Add(sarifFileOutcome("test.md", "x\n"))
+		output, _ := renderSARIF(t, reporter.DefaultOptions(), result)
+
+		require.Len(t, output.Runs[0].Invocations, 1)
+		assert.True(t, output.Runs[0].Invocations[0].ExecutionSuccessful)
+		assert.Empty(t, output.Runs[0].Invocations[0].ToolExecutionNotifications)
+	})
+
+	t.Run("rule and file errors", func(t *testing.T) {
+		t.Parallel()
+
+		outcome := sarifFileOutcome("test.md", "x\n")
+		outcome.Result.RuleErrors["MD013"] = errors.New("boom")
+
+		result := runner.NewResult()
+		result.Add(outcome)
+		result.Add(runner.FileOutcome{Path: "gone.md", Error: errors.New("read gone.md: no such file")})
+		output, _ := renderSARIF(t, reporter.DefaultOptions(), result)
+
+		invocation := output.Runs[0].Invocations[0]
+		assert.False(t, invocation.ExecutionSuccessful)
+		require.Len(t, invocation.ToolExecutionNotifications, 2)
+
+		ruleErr := invocation.ToolExecutionNotifications[0]
+		assert.Equal(t, "error", ruleErr.Level)
+		assert.Equal(t, "rule MD013 failed: boom", ruleErr.Message.Text)
+		require.NotNil(t, ruleErr.AssociatedRule)
+		assert.Equal(t, "MD013", ruleErr.AssociatedRule.ID)
+		assert.Equal(t, "test.md", ruleErr.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+		// The failing rule is described in the driver so the index resolves.
+		rules := output.Runs[0].Tool.Driver.Rules
+		require.NotNil(t, ruleErr.AssociatedRule.Index)
+		assert.Equal(t, "MD013", rules[*ruleErr.AssociatedRule.Index].ID)
+
+		fileErr := invocation.ToolExecutionNotifications[1]
+		assert.Equal(t, "gone.md", fileErr.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	})
+}
+
+func TestSARIFReporter_Shard(t *testing.T) {
+	t.Parallel()
+
+	result := runner.NewResult()
+	result.Add(sarifFileOutcome("test.md", "x\n"))
+
+	output, _ := renderSARIF(t, reporter.DefaultOptions(), result)
+	assert.Nil(t, output.Runs[0].AutomationDetails)
+	assert.Nil(t, output.Runs[0].Properties)
+
+	result.Shard = &runner.ShardInfo{Index: 3, Count: 8, TotalFiles: 40}
+	output, raw := renderSARIF(t, reporter.DefaultOptions(), result)
+	validateSARIF(t, raw)
+
+	require.NotNil(t, output.Runs[0].AutomationDetails)
+	assert.Equal(t, "gomdlint/shard-3-of-8/", output.Runs[0].AutomationDetails.ID)
+	assert.Equal(t, map[string]any{"index": 3.0, "count": 8.0, "totalFiles": 40.0}, output.Runs[0].Properties["shard"])
+}
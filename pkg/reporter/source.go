@@ -0,0 +1,200 @@
+package reporter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yaklabco/gomdlint/pkg/fix"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+)
+
+// vcsRootMarkers identify the root of a repository.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var vcsRootMarkers = []string{".git", ".hg", ".svn"}
+
+// findSourceRoot returns the nearest ancestor of dir containing a VCS
+// marker, or dir if there is none.
+func findSourceRoot(dir string) string {
+	for current := dir; ; {
+		for _, marker := range vcsRootMarkers {
+			if _, err := os.Stat(filepath.Join(current, marker)); err == nil {
+				return current
+			}
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// rootRelative resolves path against workDir and returns the absolute path
+// and the slash-separated path relative to root. ok is false for paths
+// outside root.
+func rootRelative(root, workDir, path string) (abs, rel string, ok bool) {
+	abs = path
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(workDir, abs)
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return abs, "", false
+	}
+	return abs, filepath.ToSlash(rel), true
+}
+
+// sourceText converts byte-based positions in a file's content to columns
+// and character offsets in another encoding, such as the UTF-16 code units
+// SARIF consumers expect. Without content (for example, results not
+// produced by parsing a file), positions are passed through unchanged.
+type sourceText struct {
+	content    []byte
+	lineStarts []int
+	encoding   mdast.PositionEncoding
+}
+
+func newSourceText(content []byte, encoding mdast.PositionEncoding) *sourceText {
+	text := &sourceText{content: content, encoding: encoding}
+	if content == nil {
+		return text
+	}
+	text.lineStarts = []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			text.lineStarts = append(text.lineStarts, i+1)
+		}
+	}
+	return text
+}
+
+// line returns the content of a 1-based line without its line ending.
+func (t *sourceText) line(n int) []byte {
+	if n < 1 || n > len(t.lineStarts) {
+		return nil
+	}
+	start := t.lineStarts[n-1]
+	end := len(t.content)
+	if n < len(t.lineStarts) {
+		end = t.lineStarts[n] - 1
+	}
+	return bytes.TrimSuffix(t.content[start:end], []byte("\r"))
+}
+
+// position converts a byte offset to a 1-based line and 1-based byte
+// column. ok is false without content or for offsets outside it.
+func (t *sourceText) position(offset int) (line, col int, ok bool) {
+	if t.content == nil || offset < 0 || offset > len(t.content) {
+		return 0, 0, false
+	}
+	line, _ = slices.BinarySearch(t.lineStarts, offset+1)
+	return line, offset - t.lineStarts[line-1] + 1, true
+}
+
+// column converts a 1-based byte column on a line to the text's encoding.
+func (t *sourceText) column(line, col int) int {
+	if t.content == nil {
+		return col
+	}
+	return t.encoding.Column(t.line(line), col)
+}
+
+// region returns the result region of a diagnostic.
+func (t *sourceText) region(diag lint.Diagnostic) *SARIFRegion {
+	region := &SARIFRegion{
+		StartLine:   max(diag.StartLine, 1),
+		StartColumn: t.column(diag.StartLine, diag.StartColumn),
+	}
+	if diag.EndLine >= region.StartLine {
+		region.EndLine = diag.EndLine
+		region.EndColumn = t.column(diag.EndLine, diag.EndColumn)
+	}
+	if region.StartColumn < 1 {
+		region.StartColumn = 0
+	}
+	if region.EndColumn < 1 {
+		region.EndColumn = 0
+	}
+	return region
+}
+
+// offsetRegion returns the deleted region of an edit, with byte offsets and,
+// when the content is known and the encoding does not count bytes,
+// character offsets.
+func (t *sourceText) offsetRegion(edit fix.TextEdit) SARIFRegion {
+	byteOffset := edit.StartOffset
+	byteLength := edit.EndOffset - edit.StartOffset
+	region := SARIFRegion{ByteOffset: &byteOffset, ByteLength: &byteLength}
+
+	if t.content != nil && t.encoding != mdast.PositionEncodingUTF8 &&
+		edit.StartOffset >= 0 && edit.EndOffset <= len(t.content) && byteLength >= 0 {
+		charOffset := t.encoding.Len(t.content[:edit.StartOffset])
+		charLength := t.encoding.Len(t.content[edit.StartOffset:edit.EndOffset])
+		region.CharOffset = &charOffset
+		region.CharLength = &charLength
+	}
+	return region
+}
+
+// lineFingerprinter computes partial fingerprints that survive unrelated
+// edits: a hash of the rule and the whitespace-normalized content of the
+// diagnostic's line, plus an occurrence counter for identical lines.
+type lineFingerprinter struct {
+	seen map[string]int
+}
+
+func newLineFingerprinter() *lineFingerprinter {
+	return &lineFingerprinter{seen: make(map[string]int)}
+}
+
+func (f *lineFingerprinter) fingerprint(diag lint.Diagnostic, text *sourceText) string {
+	normalized := diag.Message
+	if text.content != nil {
+		normalized = strings.Join(strings.Fields(string(text.line(diag.StartLine))), " ")
+	}
+
+	sum := sha256.Sum256([]byte(diag.RuleID + "\x00" + normalized))
+	hash := hex.EncodeToString(sum[:8])
+
+	f.seen[hash]++
+	return fmt.Sprintf("%s:%d", hash, f.seen[hash])
+}
+
+// repoPaths formats file paths relative to the source root, as CI systems
+// expect for annotations and reports.
+type repoPaths struct {
+	workDir string
+	root    string
+}
+
+func newRepoPaths(workDir string) repoPaths {
+	if workDir == "" {
+		return repoPaths{}
+	}
+	if abs, err := filepath.Abs(workDir); err == nil {
+		workDir = abs
+	}
+	return repoPaths{workDir: workDir, root: findSourceRoot(workDir)}
+}
+
+// rel returns path relative to the source root with forward slashes, or the
+// slash-separated path unchanged if it lies outside the root.
+func (p repoPaths) rel(path string) string {
+	if p.root == "" {
+		return filepath.ToSlash(path)
+	}
+	abs, rel, ok := rootRelative(p.root, p.workDir, path)
+	if !ok {
+		return filepath.ToSlash(abs)
+	}
+	return rel
+}
@@ -0,0 +1,166 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+// FileReporter is implemented by reporters that can write results one file
+// at a time while a run is in progress (see runner.Runner.Stream).
+type FileReporter interface {
+	Reporter
+
+	// ReportFile handles the outcome of one file. Files arrive in path
+	// order. It returns the number of issues reported.
+	ReportFile(ctx context.Context, outcome runner.FileOutcome) (int, error)
+
+	// Finish writes whatever follows the files, such as a summary. result
+	// holds the statistics of the run; its Files are not populated.
+	Finish(ctx context.Context, result *runner.Result) (int, error)
+}
+
+// Compile-time interface checks for streaming reporters.
+var (
+	_ FileReporter = (*bufferedReporter)(nil)
+	_ FileReporter = (*columnReporter)(nil)
+	_ FileReporter = (*MultiReporter)(nil)
+	_ FileReporter = (*reporterFacade)(nil)
+	_ FileReporter = (*TextReporter)(nil)
+	_ FileReporter = (*JSONLReporter)(nil)
+	_ FileReporter = (*RDJSONLReporter)(nil)
+)
+
+// NewStream returns rep as a FileReporter. Reporters that cannot write
+// incrementally, because their output describes the whole run (such as
+// JSON, SARIF or HTML), collect the outcomes and write them on Finish.
+func NewStream(rep Reporter) FileReporter {
+	if stream, ok := rep.(FileReporter); ok {
+		return stream
+	}
+	source, ok := rep.(sourceReporter)
+	return &bufferedReporter{Reporter: rep, keepSource: ok && source.readsSource()}
+}
+
+// sourceReporter is implemented by reporters that read the source of the
+// files, such as for snippets or fingerprints. Other reporters are handed
+// outcomes without it when they are collected.
+type sourceReporter interface {
+	readsSource() bool
+}
+
+// bufferedReporter collects outcomes for a reporter that needs the whole
+// result.
+type bufferedReporter struct {
+	Reporter
+	files []runner.FileOutcome
+
+	// keepSource is set if the reporter reads the source of the files.
+	keepSource bool
+}
+
+// ReportFile implements FileReporter. Issues are counted on Finish.
+func (b *bufferedReporter) ReportFile(_ context.Context, outcome runner.FileOutcome) (int, error) {
+	b.files = append(b.files, compactOutcome(outcome, b.keepSource))
+	return 0, nil
+}
+
+// Finish implements FileReporter by reporting the collected outcomes.
+func (b *bufferedReporter) Finish(ctx context.Context, result *runner.Result) (int, error) {
+	collected := *result
+	collected.Files = b.files
+	b.files = nil
+	return b.Report(ctx, &collected)
+}
+
+// ReportFile implements FileReporter for aggregating renderers, which only
+// need the diagnostics: the outcome is kept without its source.
+func (f *reporterFacade) ReportFile(_ context.Context, outcome runner.FileOutcome) (int, error) {
+	f.files = append(f.files, compactOutcome(outcome, false))
+	return 0, nil
+}
+
+// Finish implements FileReporter by rendering the aggregated outcomes.
+func (f *reporterFacade) Finish(ctx context.Context, result *runner.Result) (int, error) {
+	collected := *result
+	collected.Files = f.files
+	f.files = nil
+	return f.Report(ctx, &collected)
+}
+
+// ReportFile implements FileReporter by passing the outcome to every
+// reporter.
+func (m *MultiReporter) ReportFile(ctx context.Context, outcome runner.FileOutcome) (int, error) {
+	count := 0
+	var errs []error
+
+	for _, stream := range m.streams() {
+		n, err := stream.ReportFile(ctx, outcome)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		count = max(count, n)
+	}
+
+	return count, errors.Join(errs...)
+}
+
+// Finish implements FileReporter by finishing every reporter.
+func (m *MultiReporter) Finish(ctx context.Context, result *runner.Result) (int, error) {
+	count := 0
+	var errs []error
+
+	for _, stream := range m.streams() {
+		n, err := stream.Finish(ctx, result)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		count = max(count, n)
+	}
+
+	return count, errors.Join(errs...)
+}
+
+// streams returns the reporters as FileReporters, creating the adapters on
+// first use.
+func (m *MultiReporter) streams() []FileReporter {
+	if m.fileReporters == nil {
+		m.fileReporters = make([]FileReporter, 0, len(m.reporters))
+		for _, rep := range m.reporters {
+			m.fileReporters = append(m.fileReporters, NewStream(rep))
+		}
+	}
+	return m.fileReporters
+}
+
+// compactOutcome returns a copy of outcome without the data no reporter
+// uses once the file is done: the token stream, the syntax tree and the
+// fixed content. With keepSource unset the source text is dropped as well.
+func compactOutcome(outcome runner.FileOutcome, keepSource bool) runner.FileOutcome {
+	if outcome.Result == nil {
+		return outcome
+	}
+
+	pipelineResult := *outcome.Result
+	pipelineResult.ModifiedContent = nil
+	if pipelineResult.FileResult != nil && pipelineResult.Snapshot != nil {
+		fileResult := *pipelineResult.FileResult
+		if keepSource {
+			fileResult.Snapshot = &mdast.FileSnapshot{
+				Path:    fileResult.Snapshot.Path,
+				Content: fileResult.Snapshot.Content,
+				Lines:   fileResult.Snapshot.Lines,
+			}
+		} else {
+			fileResult.Snapshot = nil
+		}
+		pipelineResult.FileResult = &fileResult
+	}
+
+	outcome.Result = &pipelineResult
+	return outcome
+}
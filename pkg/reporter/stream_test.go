@@ -0,0 +1,153 @@
+package reporter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/mdast"
+	"github.com/yaklabco/gomdlint/pkg/reporter"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+func streamResult() *runner.Result {
+	result := runner.NewResult()
+	for _, path := range []string{"a.md", "b.md", "c.md"} {
+		outcome := sarifFileOutcome(path, "# Title  \n\ntext\n", lint.Diagnostic{
+			RuleID: "MD009", RuleName: "no-trailing-spaces", Message: "Trailing spaces",
+			Severity: config.SeverityWarning, StartLine: 1, StartColumn: 8, EndLine: 1, EndColumn: 10,
+		})
+		outcome.Result.Snapshot.Lines = mdast.BuildLines(outcome.Result.Snapshot.Content)
+		outcome.Result.Snapshot.Root = &mdast.Node{Kind: mdast.NodeDocument}
+		result.Add(outcome)
+	}
+	return result
+}
+
+// streamReport reports result one file at a time through rep.
+func streamReport(t *testing.T, rep reporter.Reporter, result *runner.Result) {
+	t.Helper()
+
+	stream := reporter.NewStream(rep)
+	for _, file := range result.Files {
+		_, err := stream.ReportFile(context.Background(), file)
+		require.NoError(t, err)
+	}
+	stats := *result
+	stats.Files = nil
+	_, err := stream.Finish(context.Background(), &stats)
+	require.NoError(t, err)
+}
+
+func TestNewStream_MatchesReport(t *testing.T) {
+	t.Parallel()
+
+	formats := []reporter.Format{
+		reporter.FormatText, reporter.FormatJSON, reporter.FormatSARIF,
+		reporter.FormatSummary, reporter.FormatCheckstyle, reporter.FormatJSONL,
+		reporter.FormatRDJSONL, reporter.FormatGitLab, reporter.FormatHTML,
+	}
+
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			newReporter := func(buf *bytes.Buffer) reporter.Reporter {
+				opts := reporter.DefaultOptions()
+				opts.Writer = buf
+				opts.Format = format
+				opts.Color = "never"
+				opts.ShowContext = true
+				rep, err := reporter.New(opts)
+				require.NoError(t, err)
+				return rep
+			}
+
+			var buffered, streamed bytes.Buffer
+			_, err := newReporter(&buffered).Report(context.Background(), streamResult())
+			require.NoError(t, err)
+			streamReport(t, newReporter(&streamed), streamResult())
+
+			assert.Equal(t, buffered.String(), streamed.String())
+		})
+	}
+}
+
+// recordingReporter records the result it reports.
+type recordingReporter struct {
+	result *runner.Result
+}
+
+func (r *recordingReporter) Report(_ context.Context, result *runner.Result) (int, error) {
+	r.result = result
+	return 0, nil
+}
+
+func TestNewStream_DropsUnreadSource(t *testing.T) {
+	t.Parallel()
+
+	rec := &recordingReporter{}
+	result := streamResult()
+	streamReport(t, rec, result)
+
+	require.Len(t, rec.result.Files, 3)
+	for _, file := range rec.result.Files {
+		assert.Nil(t, file.Result.Snapshot, "source of %s", file.Path)
+		assert.Len(t, file.Result.Diagnostics, 1)
+	}
+}
+
+func TestNewStream_TextWritesIncrementally(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	opts := reporter.DefaultOptions()
+	opts.Writer = &buf
+	opts.Color = "never"
+	stream := reporter.NewStream(reporter.NewTextReporter(opts))
+
+	result := streamResult()
+	n, err := stream.ReportFile(context.Background(), result.Files[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Contains(t, buf.String(), "a.md")
+	assert.NotContains(t, buf.String(), "b.md")
+}
+
+func TestMultiReporter_Stream(t *testing.T) {
+	t.Parallel()
+
+	var text, jsonOut bytes.Buffer
+	textOpts := reporter.DefaultOptions()
+	textOpts.Writer = &text
+	textOpts.Color = "never"
+	jsonOpts := reporter.DefaultOptions()
+	jsonOpts.Writer = &jsonOut
+
+	multi := reporter.NewMultiReporter(reporter.NewTextReporter(textOpts), reporter.NewJSONReporter(jsonOpts))
+
+	result := streamResult()
+	_, err := multi.ReportFile(context.Background(), result.Files[0])
+	require.NoError(t, err)
+	assert.Contains(t, text.String(), "a.md")
+	assert.Empty(t, jsonOut.String(), "JSON is written on Finish")
+
+	for _, file := range result.Files[1:] {
+		_, err := multi.ReportFile(context.Background(), file)
+		require.NoError(t, err)
+	}
+	stats := *result
+	stats.Files = nil
+	n, err := multi.Finish(context.Background(), &stats)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	report, err := reporter.ReadJSON(&jsonOut)
+	require.NoError(t, err)
+	assert.Len(t, report.Files, 3)
+}
@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/yaklabco/gomdlint/internal/ui/pretty"
+	"github.com/yaklabco/gomdlint/pkg/config"
 	"github.com/yaklabco/gomdlint/pkg/runner"
 )
 
-// TextReporter formats results as styled terminal output.
+// TextReporter formats results as styled terminal output, with a code
+// frame of each diagnostic's source when ShowContext is set.
 type TextReporter struct {
 	opts   Options
 	styles *pretty.Styles
+	frame  pretty.FrameOptions
 	out    io.Writer
 }
 
@@ -22,7 +26,12 @@ func NewTextReporter(opts Options) *TextReporter {
 	return &TextReporter{
 		opts:   opts,
 		styles: pretty.NewStyles(colorEnabled),
-		out:    opts.Writer,
+		frame: pretty.FrameOptions{
+			ContextBefore: opts.ContextBefore,
+			ContextAfter:  opts.ContextAfter,
+			Encoding:      opts.ColumnEncoding,
+		},
+		out: opts.Writer,
 	}
 }
 
@@ -31,6 +40,7 @@ func (r *TextReporter) Report(ctx context.Context, result *runner.Result) (int,
 	if result == nil || len(result.Files) == 0 {
 		if r.opts.ShowSummary {
 			fmt.Fprintln(r.out, r.styles.Success.Render("No files to check."))
+			r.writeShard(result)
 		}
 		return 0, nil
 	}
@@ -45,135 +55,193 @@ func (r *TextReporter) Report(ctx context.Context, result *runner.Result) (int,
 
 	if r.opts.ShowSummary {
 		fmt.Fprint(r.out, r.styles.FormatSummaryOneLine(result.Stats))
+		r.writeShard(result)
 	}
 
 	return totalIssues, nil
 }
 
+// writeShard notes which partition of the files a sharded run covered.
+func (r *TextReporter) writeShard(result *runner.Result) {
+	if result == nil || result.Shard == nil {
+		return
+	}
+	fmt.Fprintln(r.out, r.styles.Dim.Render(fmt.Sprintf("Shard %d/%d: %d of %d files",
+		result.Shard.Index, result.Shard.Count, result.Stats.FilesDiscovered, result.Shard.TotalFiles)))
+}
+
+// ReportFile implements FileReporter by writing one file's diagnostics.
+func (r *TextReporter) ReportFile(_ context.Context, outcome runner.FileOutcome) (int, error) {
+	if r.opts.GroupByFile {
+		return r.reportGroupedFile(outcome), nil
+	}
+	return r.reportFlatFile(outcome), nil
+}
+
+// Finish implements FileReporter by writing the summary.
+func (r *TextReporter) Finish(_ context.Context, result *runner.Result) (int, error) {
+	if !r.opts.ShowSummary {
+		return 0, nil
+	}
+	if result == nil || result.Stats.FilesDiscovered == 0 {
+		fmt.Fprintln(r.out, r.styles.Success.Render("No files to check."))
+	} else {
+		fmt.Fprint(r.out, r.styles.FormatSummaryOneLine(result.Stats))
+	}
+	r.writeShard(result)
+	return 0, nil
+}
+
 // reportGrouped writes diagnostics grouped by file.
 func (r *TextReporter) reportGrouped(_ context.Context, result *runner.Result) int {
 	var total int
-
 	for _, file := range result.Files {
-		// Handle file errors
-		if file.Error != nil {
-			fmt.Fprintf(r.out, "%s: %s\n",
-				r.styles.FilePath.Render(file.Path),
-				r.styles.Error.Render(fmt.Sprintf("error: %v", file.Error)),
-			)
-			continue
-		}
+		total += r.reportGroupedFile(file)
+	}
+	return total
+}
 
-		if file.Result == nil || file.Result.FileResult == nil {
-			continue
-		}
+// reportGroupedFile writes the diagnostics of one file under a header.
+func (r *TextReporter) reportGroupedFile(file runner.FileOutcome) int {
+	// Handle file errors
+	if file.Error != nil {
+		fmt.Fprintf(r.out, "%s: %s\n",
+			r.styles.FilePath.Render(file.Path),
+			r.styles.Error.Render(fmt.Sprintf("error: %v", file.Error)),
+		)
+		return 0
+	}
 
-		diagnostics := file.Result.Diagnostics
-		if len(diagnostics) == 0 {
-			continue
-		}
+	if file.Result == nil || file.Result.FileResult == nil {
+		return 0
+	}
 
-		// File header
-		fmt.Fprintln(r.out, r.styles.FormatFileHeader(file.Path, len(diagnostics)))
+	r.writeFixReport(file)
 
-		for _, diag := range diagnostics {
-			// Get source line for context if enabled
-			var sourceLine string
-			if r.opts.ShowContext && file.Result.Snapshot != nil {
-				sourceLine = r.getSourceLine(file.Result.Snapshot.Content, diag.StartLine)
-			}
+	diagnostics := file.Result.Diagnostics
+	if len(diagnostics) == 0 {
+		return 0
+	}
 
-			fmt.Fprint(r.out, r.styles.FormatDiagnosticWithFormat(&diag, r.opts.ShowContext, sourceLine, r.opts.RuleFormat))
-			total++
-		}
+	// File header
+	fmt.Fprintln(r.out, r.styles.FormatFileHeader(file.Path, len(diagnostics)))
 
-		// Warn if fix loop was exhausted
-		if file.Exhausted {
-			fmt.Fprintf(r.out, "  %s\n",
-				r.styles.Warning.Render(fmt.Sprintf("warning: fix loop exhausted after %d passes with %d edits remaining",
-					file.FixPasses, file.RemainingEdits)))
-		}
+	src := r.frameSource(file)
+	var total int
+	for _, diag := range diagnostics {
+		fmt.Fprint(r.out, r.styles.FormatSourceFrame(&diag, src, r.opts.ShowContext, r.opts.RuleFormat, r.frame))
+		total++
+	}
+
+	// Warn if fix loop was exhausted
+	if file.Exhausted {
+		fmt.Fprintf(r.out, "  %s\n", r.styles.Warning.Render(r.exhaustedWarning(file)))
+	}
 
-		// Blank line between files
-		fmt.Fprintln(r.out)
+	// Warn about fixes rejected for changing the rendered document
+	if rejected := renderingRejected(file, r.opts.RuleFormat); rejected != "" {
+		fmt.Fprintf(r.out, "  %s\n", r.styles.Warning.Render(rejected))
 	}
 
+	// Blank line between files
+	fmt.Fprintln(r.out)
+
 	return total
 }
 
 // reportFlat writes diagnostics without grouping.
 func (r *TextReporter) reportFlat(_ context.Context, result *runner.Result) int {
 	var total int
-
 	for _, file := range result.Files {
-		// Handle file errors
-		if file.Error != nil {
-			fmt.Fprintf(r.out, "%s: %s\n",
-				r.styles.FilePath.Render(file.Path),
-				r.styles.Error.Render(fmt.Sprintf("error: %v", file.Error)),
-			)
-			continue
-		}
+		total += r.reportFlatFile(file)
+	}
+	return total
+}
 
-		if file.Result == nil || file.Result.FileResult == nil {
-			continue
-		}
+// reportFlatFile writes the diagnostics of one file, one per line.
+func (r *TextReporter) reportFlatFile(file runner.FileOutcome) int {
+	// Handle file errors
+	if file.Error != nil {
+		fmt.Fprintf(r.out, "%s: %s\n",
+			r.styles.FilePath.Render(file.Path),
+			r.styles.Error.Render(fmt.Sprintf("error: %v", file.Error)),
+		)
+		return 0
+	}
 
-		for _, diag := range file.Result.Diagnostics {
-			// Get source line for context if enabled
-			var sourceLine string
-			if r.opts.ShowContext && file.Result.Snapshot != nil {
-				sourceLine = r.getSourceLine(file.Result.Snapshot.Content, diag.StartLine)
-			}
+	if file.Result == nil || file.Result.FileResult == nil {
+		return 0
+	}
 
-			fmt.Fprint(r.out, r.styles.FormatDiagnosticWithFormat(&diag, r.opts.ShowContext, sourceLine, r.opts.RuleFormat))
-			total++
-		}
+	r.writeFixReport(file)
 
-		// Warn if fix loop was exhausted
-		if file.Exhausted {
-			fmt.Fprintf(r.out, "%s: %s\n",
-				r.styles.FilePath.Render(file.Path),
-				r.styles.Warning.Render(fmt.Sprintf("warning: fix loop exhausted after %d passes with %d edits remaining",
-					file.FixPasses, file.RemainingEdits)))
-		}
+	src := r.frameSource(file)
+	var total int
+	for _, diag := range file.Result.Diagnostics {
+		fmt.Fprint(r.out, r.styles.FormatSourceFrame(&diag, src, r.opts.ShowContext, r.opts.RuleFormat, r.frame))
+		total++
+	}
+
+	// Warn if fix loop was exhausted
+	if file.Exhausted {
+		fmt.Fprintf(r.out, "%s: %s\n", r.styles.FilePath.Render(file.Path), r.styles.Warning.Render(r.exhaustedWarning(file)))
+	}
+
+	// Warn about fixes rejected for changing the rendered document
+	if rejected := renderingRejected(file, r.opts.RuleFormat); rejected != "" {
+		fmt.Fprintf(r.out, "%s: %s\n", r.styles.FilePath.Render(file.Path), r.styles.Warning.Render(rejected))
 	}
 
 	return total
 }
 
-// getSourceLine extracts a specific line from content (1-based line number).
-func (r *TextReporter) getSourceLine(content []byte, lineNum int) string {
-	if lineNum < 1 || len(content) == 0 {
-		return ""
+// exhaustedWarning returns the warning for a file whose fix loop ran out of
+// passes, naming the rules still producing edits.
+func (r *TextReporter) exhaustedWarning(file runner.FileOutcome) string {
+	warning := fmt.Sprintf("warning: fix loop exhausted after %d passes with %d edits remaining",
+		file.FixPasses, file.RemainingEdits)
+	if file.Result == nil || len(file.Result.RemainingRules) == 0 {
+		return warning
+	}
+	rules := make([]string, 0, len(file.Result.RemainingRules))
+	for _, id := range file.Result.RemainingRules {
+		rules = append(rules, r.ruleLabel(id))
 	}
+	return warning + " from " + strings.Join(rules, ", ")
+}
 
-	lines := splitLines(content)
-	if lineNum > len(lines) {
+// renderingRejected returns the warning for the fixes of a file that were
+// not applied because they changed its rendered output, or "" if there are
+// none.
+func renderingRejected(file runner.FileOutcome, ruleFormat config.RuleFormat) string {
+	if file.Result == nil || len(file.Result.RenderingRejected) == 0 {
 		return ""
 	}
 
-	return lines[lineNum-1]
+	names := make(map[string]string)
+	for _, diag := range file.Result.Diagnostics {
+		names[diag.RuleID] = diag.RuleName
+	}
+	rules := make([]string, 0, len(file.Result.RenderingRejected))
+	for _, id := range file.Result.RenderingRejected {
+		rules = append(rules, config.FormatRuleID(ruleFormat, id, names[id]))
+	}
+	return "warning: fixes not applied because they change the rendered output: " + strings.Join(rules, ", ")
 }
 
-// splitLines splits content into lines.
-func splitLines(content []byte) []string {
-	var lines []string
-	var line []byte
-
-	for _, b := range content {
-		if b == '\n' {
-			lines = append(lines, string(line))
-			line = nil
-		} else {
-			line = append(line, b)
-		}
+// frameSource returns the source of file split into lines for the code
+// frames of its diagnostics, or nil if they need no source.
+func (r *TextReporter) frameSource(file runner.FileOutcome) *pretty.FrameSource {
+	if !r.opts.ShowContext && r.frame.Encoding == "" {
+		return nil
 	}
+	return pretty.NewFrameSource(fileContent(file))
+}
 
-	// Handle last line without newline
-	if len(line) > 0 {
-		lines = append(lines, string(line))
+// fileContent returns the source of a file, or nil if it is not available.
+func fileContent(file runner.FileOutcome) []byte {
+	if file.Result == nil || file.Result.Snapshot == nil {
+		return nil
 	}
-
-	return lines
+	return file.Result.Snapshot.Content
 }
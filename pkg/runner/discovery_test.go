@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/yaklabco/gomdlint/pkg/config"
 	"github.com/yaklabco/gomdlint/pkg/runner"
 )
 
@@ -597,6 +598,46 @@ func TestDefaultExtensions(t *testing.T) {
 	}
 }
 
+func TestDiscover_FlavorExtensions(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	for _, name := range []string{"doc.md", "page.mdx"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("# Test"), 0644); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	tests := []struct {
+		flavor config.Flavor
+		want   int
+	}{
+		{config.FlavorCommonMark, 1},
+		{config.FlavorGFM, 1},
+		{config.FlavorMDX, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.flavor), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.NewConfig()
+			cfg.Flavor = tt.flavor
+
+			discovered, err := runner.Discover(context.Background(), runner.Options{
+				WorkingDir: tmpDir,
+				Config:     cfg,
+			})
+			if err != nil {
+				t.Fatalf("Discover failed: %v", err)
+			}
+			if len(discovered) != tt.want {
+				t.Errorf("expected %d files, got %d: %v", tt.want, len(discovered), discovered)
+			}
+		})
+	}
+}
+
 // hasPrefix checks if path starts with prefix as a path component.
 func hasPrefix(path, prefix string) bool {
 	path = filepath.ToSlash(path)
@@ -1,7 +1,10 @@
 // Package runner provides multi-file linting orchestration.
 package runner
 
-import "github.com/yaklabco/gomdlint/pkg/config"
+import (
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+)
 
 // Options controls multi-file linting behavior.
 type Options struct {
@@ -14,7 +17,8 @@ type Options struct {
 	WorkingDir string
 
 	// Extensions is the set of file extensions (lowercase, with leading dot)
-	// considered Markdown. Defaults to [".md", ".markdown"] via DefaultExtensions().
+	// considered Markdown. Defaults to the configured flavor's extensions
+	// (see DefaultExtensionsForFlavor), or [".md", ".markdown"] without a config.
 	Extensions []string
 
 	// IncludeGlobs are additional glob patterns to include, relative to WorkingDir.
@@ -32,8 +36,17 @@ type Options struct {
 	// 0 or negative means "auto" (runtime.NumCPU()).
 	Jobs int
 
+	// Shard restricts the run to one partition of the discovered files
+	// (see Partition). The zero value processes all files.
+	Shard Shard
+
 	// Config is the resolved configuration for this run.
 	Config *config.Config
+
+	// Reviewer, if set, chooses the fixes applied to each file (see
+	// lint.PipelineOptions.Reviewer). Files are then processed one at a
+	// time, in path order, so that reviews do not interleave.
+	Reviewer lint.FixReviewer
 }
 
 // DefaultExtensions returns the default set of Markdown file extensions.
@@ -41,12 +54,21 @@ func DefaultExtensions() []string {
 	return []string{".md", ".markdown"}
 }
 
+// DefaultExtensionsForFlavor returns the default file extensions for a flavor.
+// For example, the MDX flavor also discovers ".mdx" files.
+func DefaultExtensionsForFlavor(flavor config.Flavor) []string {
+	return flavor.FileExtensions()
+}
+
 // effectiveExtensions returns the extensions to use, defaulting if empty.
 func (o Options) effectiveExtensions() []string {
-	if len(o.Extensions) == 0 {
-		return DefaultExtensions()
+	if len(o.Extensions) > 0 {
+		return o.Extensions
+	}
+	if o.Config != nil {
+		return DefaultExtensionsForFlavor(o.Config.Flavor)
 	}
-	return o.Extensions
+	return DefaultExtensions()
 }
 
 // effectivePaths returns the paths to process, defaulting to "." if empty.
@@ -55,6 +55,15 @@ type Stats struct {
 
 	// DiagnosticsFixed is the total number of issues fixed across all files.
 	DiagnosticsFixed int
+
+	// FilesConverted is the number of files written back as UTF-8 because
+	// the encoding policy converts them.
+	FilesConverted int
+
+	// FixesHeldBack maps fix applicability levels to the number of fixes
+	// not applied because their level was not enabled. It is nil if there
+	// are none.
+	FixesHeldBack map[lint.FixApplicability]int
 }
 
 // Result is the overall runner result.
@@ -68,6 +77,10 @@ type Result struct {
 
 	// Errors contains any non-file-specific errors encountered.
 	Errors []error
+
+	// Shard describes the partition covered when the run was sharded.
+	// It is nil for unsharded runs.
+	Shard *ShardInfo
 }
 
 // HasFailures reports whether any diagnostics with error severity occurred.
@@ -86,6 +99,18 @@ func (r *Result) HasIssues() bool {
 	return r.Stats.DiagnosticsTotal > 0
 }
 
+// NewResult creates an empty Result for outcomes produced outside Run,
+// such as project-level checks.
+func NewResult() *Result {
+	return &Result{Stats: newStats()}
+}
+
+// Add appends a file outcome and updates the statistics.
+func (r *Result) Add(outcome FileOutcome) {
+	r.Stats.FilesDiscovered++
+	r.accumulate(outcome)
+}
+
 // newStats creates a new Stats with initialized maps.
 func newStats() Stats {
 	return Stats{
@@ -93,10 +118,14 @@ func newStats() Stats {
 	}
 }
 
-// accumulate updates the result with a file outcome.
+// accumulate appends a file outcome and updates the statistics.
 func (r *Result) accumulate(outcome FileOutcome) {
 	r.Files = append(r.Files, outcome)
+	r.count(outcome)
+}
 
+// count updates the statistics with a file outcome.
+func (r *Result) count(outcome FileOutcome) {
 	if outcome.Error != nil {
 		r.Stats.FilesErrored++
 		return
@@ -114,6 +143,9 @@ func (r *Result) accumulate(outcome FileOutcome) {
 
 	if outcome.Result.Written {
 		r.Stats.FilesModified++
+		if outcome.Result.Converted {
+			r.Stats.FilesConverted++
+		}
 	}
 
 	// Track total edits applied (issues fixed).
@@ -128,6 +160,13 @@ func (r *Result) accumulate(outcome FileOutcome) {
 			r.Stats.FilesWithIssues++
 		}
 
+		for applicability, count := range outcome.Result.HeldBackFixes {
+			if r.Stats.FixesHeldBack == nil {
+				r.Stats.FixesHeldBack = make(map[lint.FixApplicability]int)
+			}
+			r.Stats.FixesHeldBack[applicability] += count
+		}
+
 		for _, diag := range outcome.Result.Diagnostics {
 			severity := string(diag.Severity)
 			if severity == "" {
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/fsutil"
 	"github.com/yaklabco/gomdlint/pkg/lint"
 )
 
+// streamWindowPerJob bounds how many files may be in flight or waiting for
+// an earlier file per worker, which bounds memory when one file is slow.
+const streamWindowPerJob = 4
+
 // Runner orchestrates multi-file linting using a lint.Pipeline.
 type Runner struct {
 	// Pipeline handles per-file processing with safety guarantees.
@@ -26,21 +32,69 @@ func New(pipeline *lint.Pipeline) *Runner {
 //
 // The runner:
 //   - Discovers files matching the options criteria
+//   - Keeps only the files of opts.Shard, if set
 //   - Processes files concurrently using a worker pool
 //   - Aggregates results into a single Result with statistics
 //   - Respects context cancellation
 func (r *Runner) Run(ctx context.Context, opts Options) (*Result, error) {
+	return r.run(ctx, opts, true, nil)
+}
+
+// Stream is like Run, but passes each outcome to emit as soon as it and all
+// files before it are done, instead of collecting them. Outcomes arrive in
+// the same path order as Run's Files, and are not retained afterwards, so
+// snapshots can be released once emit returns. The returned Result has the
+// statistics of the run but no Files.
+//
+// If emit returns an error the run is cancelled and the error returned.
+func (r *Runner) Stream(ctx context.Context, opts Options, emit func(FileOutcome) error) (*Result, error) {
+	return r.run(ctx, opts, false, emit)
+}
+
+// indexedPath is a file to process and its position in discovery order.
+type indexedPath struct {
+	index int
+	path  string
+}
+
+// indexedOutcome is a file outcome and its position in discovery order.
+type indexedOutcome struct {
+	index   int
+	outcome FileOutcome
+}
+
+// run processes the discovered files and delivers their outcomes in order:
+// they are kept in the result's Files if keep is set, and passed to emit if
+// it is non-nil.
+func (r *Runner) run(ctx context.Context, opts Options, keep bool, emit func(FileOutcome) error) (*Result, error) {
 	// Discover files.
 	files, err := Discover(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	// Keep only this shard's files.
+	var shard *ShardInfo
+	if opts.Shard.Enabled() {
+		workDir, err := resolveWorkDir(opts.WorkingDir)
+		if err != nil {
+			return nil, fmt.Errorf("resolve working directory: %w", err)
+		}
+		shard = &ShardInfo{Index: opts.Shard.Index, Count: opts.Shard.Count, TotalFiles: len(files)}
+		files, err = Partition(files, opts.Shard, workDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	result := &Result{
-		Files: make([]FileOutcome, 0, len(files)),
 		Stats: newStats(),
+		Shard: shard,
 	}
 	result.Stats.FilesDiscovered = len(files)
+	if keep {
+		result.Files = make([]FileOutcome, 0, len(files))
+	}
 
 	if len(files) == 0 {
 		return result, nil
@@ -55,13 +109,33 @@ func (r *Runner) Run(ctx context.Context, opts Options) (*Result, error) {
 	if jobs > len(files) {
 		jobs = len(files)
 	}
+	// Review one file at a time.
+	if opts.Reviewer != nil {
+		jobs = 1
+	}
 
 	// Get pipeline options from config.
 	pipelineOpts := lint.PipelineOptionsFromConfig(opts.Config)
+	pipelineOpts.Reviewer = opts.Reviewer
+
+	// Back up all files of the run into one snapshot.
+	if pipelineOpts.Backup.Enabled && pipelineOpts.Backup.Mode == fsutil.BackupModeDirectory {
+		workDir, err := resolveWorkDir(opts.WorkingDir)
+		if err != nil {
+			return nil, fmt.Errorf("resolve working directory: %w", err)
+		}
+		pipelineOpts.Backup.Run = fsutil.NewBackupRun(workDir, time.Now())
+	}
 
-	// Create channels.
-	workCh := make(chan string)
-	outCh := make(chan FileOutcome)
+	// Stop the workers early if emit fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Create channels. The window holds a token for every file that has
+	// been handed out but not yet emitted.
+	workCh := make(chan indexedPath)
+	outCh := make(chan indexedOutcome)
+	window := make(chan struct{}, jobs*streamWindowPerJob)
 
 	var wg sync.WaitGroup
 
@@ -77,11 +151,16 @@ func (r *Runner) Run(ctx context.Context, opts Options) (*Result, error) {
 	// Feed work in a separate goroutine.
 	go func() {
 		defer close(workCh)
-		for _, path := range files {
+		for index, path := range files {
+			select {
+			case <-ctx.Done():
+				return
+			case window <- struct{}{}:
+			}
 			select {
 			case <-ctx.Done():
 				return
-			case workCh <- path:
+			case workCh <- indexedPath{index: index, path: path}:
 			}
 		}
 	}()
@@ -92,21 +171,51 @@ func (r *Runner) Run(ctx context.Context, opts Options) (*Result, error) {
 		close(outCh)
 	}()
 
-	// Collect results.
-	// Use a map to maintain order since workers may complete out of order.
-	outcomes := make(map[string]FileOutcome, len(files))
+	// Collect results, emitting them in discovery order since workers
+	// may complete out of order.
+	pending := make(map[int]FileOutcome)
+	next := 0
+	var emitErr error
+	deliver := func(outcome FileOutcome) {
+		if keep {
+			result.accumulate(outcome)
+		} else {
+			result.count(outcome)
+		}
+		if emit != nil && emitErr == nil {
+			if err := emit(outcome); err != nil {
+				emitErr = err
+				cancel()
+			}
+		}
+	}
 
-	for outcome := range outCh {
-		outcomes[outcome.Path] = outcome
+	for out := range outCh {
+		pending[out.index] = out.outcome
+		for {
+			outcome, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			deliver(outcome)
+			<-window
+		}
 	}
 
-	// Build result in deterministic order.
-	for _, path := range files {
-		if outcome, ok := outcomes[path]; ok {
-			result.accumulate(outcome)
+	// After cancellation, emit what completed, still in order.
+	for ; next < len(files) && len(pending) > 0; next++ {
+		if outcome, ok := pending[next]; ok {
+			delete(pending, next)
+			deliver(outcome)
 		}
 	}
 
+	if emitErr != nil {
+		return result, emitErr
+	}
+
 	// Check for context error.
 	if ctx.Err() != nil {
 		return result, fmt.Errorf("run cancelled: %w", ctx.Err())
@@ -118,21 +227,21 @@ func (r *Runner) Run(ctx context.Context, opts Options) (*Result, error) {
 // worker processes files from workCh and sends outcomes to outCh.
 func (r *Runner) worker(
 	ctx context.Context,
-	workCh <-chan string,
-	outCh chan<- FileOutcome,
+	workCh <-chan indexedPath,
+	outCh chan<- indexedOutcome,
 	cfg *config.Config,
 	opts lint.PipelineOptions,
 ) {
-	for path := range workCh {
+	for work := range workCh {
 		select {
 		case <-ctx.Done():
 			return
 		default:
 		}
 
-		outcome := FileOutcome{Path: path}
+		outcome := FileOutcome{Path: work.path}
 
-		pr, err := r.Pipeline.ProcessFile(ctx, path, cfg, opts)
+		pr, err := r.Pipeline.ProcessFile(ctx, work.path, cfg, opts)
 		if err != nil {
 			outcome.Error = err
 		} else {
@@ -145,7 +254,7 @@ func (r *Runner) worker(
 		select {
 		case <-ctx.Done():
 			return
-		case outCh <- outcome:
+		case outCh <- indexedOutcome{index: work.index, outcome: outcome}:
 		}
 	}
 }
@@ -661,3 +661,79 @@ func TestResult_HasIssues(t *testing.T) {
 		})
 	}
 }
+
+func TestRunner_Stream(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for i := range 20 {
+		path := filepath.Join(dir, "doc"+string(rune('a'+i))+".md")
+		if err := os.WriteFile(path, []byte("# Doc\n"), 0644); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	registry := lint.NewRegistry()
+	registry.Register(&diagnosticRule{
+		BaseRule: lint.NewBaseRule("TEST001", "test-rule", "Test rule", nil, false),
+		diags: []lint.Diagnostic{{
+			RuleID:   "TEST001",
+			Message:  "test",
+			Severity: config.SeverityWarning,
+		}},
+	})
+	lintRunner := runner.New(lint.NewPipeline(lint.NewEngine(&mockParser{}, registry)))
+
+	opts := runner.Options{
+		Paths:      []string{"."},
+		WorkingDir: dir,
+		Jobs:       4,
+		Config:     config.NewConfig(),
+	}
+
+	want, err := lintRunner.Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var paths []string
+	got, err := lintRunner.Stream(context.Background(), opts, func(outcome runner.FileOutcome) error {
+		paths = append(paths, outcome.Path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	if len(got.Files) != 0 {
+		t.Errorf("Stream() kept %d files, want 0", len(got.Files))
+	}
+	if len(paths) != len(want.Files) {
+		t.Fatalf("emitted %d outcomes, want %d", len(paths), len(want.Files))
+	}
+	for i, file := range want.Files {
+		if paths[i] != file.Path {
+			t.Errorf("outcome %d = %s, want %s", i, paths[i], file.Path)
+		}
+	}
+	if got.Stats.DiagnosticsTotal != want.Stats.DiagnosticsTotal || got.Stats.FilesProcessed != want.Stats.FilesProcessed {
+		t.Errorf("Stream() stats = %+v, want %+v", got.Stats, want.Stats)
+	}
+
+	t.Run("emit error stops the run", func(t *testing.T) {
+		t.Parallel()
+
+		errStop := errors.New("stop")
+		calls := 0
+		_, err := lintRunner.Stream(context.Background(), opts, func(runner.FileOutcome) error {
+			calls++
+			return errStop
+		})
+		if !errors.Is(err, errStop) {
+			t.Errorf("Stream() error = %v, want %v", err, errStop)
+		}
+		if calls != 1 {
+			t.Errorf("emit called %d times after failing, want 1", calls)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package runner
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidShard is returned for shard specifications outside 1..count.
+var ErrInvalidShard = errors.New("invalid shard")
+
+// Shard selects one of Count partitions of the discovered files, so that a
+// run can be split across CI jobs. The zero value selects all files.
+type Shard struct {
+	// Index is the 1-based number of the partition to process.
+	Index int
+
+	// Count is the number of partitions.
+	Count int
+}
+
+// ParseShard parses a shard specification of the form "index/count",
+// such as "2/8".
+func ParseShard(spec string) (Shard, error) {
+	indexText, countText, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Shard{}, fmt.Errorf("%w %q: expected index/count, e.g. 2/8", ErrInvalidShard, spec)
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(indexText))
+	if err != nil {
+		return Shard{}, fmt.Errorf("%w %q: index is not a number", ErrInvalidShard, spec)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(countText))
+	if err != nil {
+		return Shard{}, fmt.Errorf("%w %q: count is not a number", ErrInvalidShard, spec)
+	}
+
+	shard := Shard{Index: index, Count: count}
+	if err := shard.Validate(); err != nil {
+		return Shard{}, err
+	}
+	return shard, nil
+}
+
+// Enabled reports whether the shard selects a partition.
+func (s Shard) Enabled() bool {
+	return s.Count > 0
+}
+
+// Validate checks that the index is within 1..Count. The zero value is valid.
+func (s Shard) Validate() error {
+	if s == (Shard{}) {
+		return nil
+	}
+	if s.Count < 1 || s.Index < 1 || s.Index > s.Count {
+		return fmt.Errorf("%w %s: index must be between 1 and count", ErrInvalidShard, s)
+	}
+	return nil
+}
+
+// String returns the shard as "index/count".
+func (s Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// ShardInfo describes the partition a sharded result covers.
+type ShardInfo struct {
+	// Index is the 1-based number of the partition.
+	Index int
+
+	// Count is the number of partitions.
+	Count int
+
+	// TotalFiles is the number of files discovered across all partitions.
+	TotalFiles int
+}
+
+// shardFile is a discovered file with the data used to partition it.
+type shardFile struct {
+	path string
+	key  string
+	hash uint64
+	size int64
+}
+
+// Partition returns the files of the given shard, in their original order.
+//
+// Files are spread over the shards so that each receives roughly the same
+// number of bytes: they are assigned largest first to the least loaded
+// shard. Files of equal size are ordered by a hash of their path relative
+// to workDir, so the partition depends only on the file set, not on where
+// the checkout lives. Every shard of the same file set therefore gets a
+// disjoint slice, and together the slices cover all files.
+func Partition(files []string, shard Shard, workDir string) ([]string, error) {
+	if err := shard.Validate(); err != nil {
+		return nil, err
+	}
+	if !shard.Enabled() || shard.Count == 1 {
+		return files, nil
+	}
+
+	items := make([]shardFile, 0, len(files))
+	for _, path := range files {
+		key := path
+		if rel, err := filepath.Rel(workDir, path); err == nil {
+			key = filepath.ToSlash(rel)
+		}
+
+		hasher := fnv.New64a()
+		_, _ = hasher.Write([]byte(key))
+
+		// Unreadable files are assigned as empty; processing reports them.
+		var size int64
+		if info, err := os.Stat(path); err == nil {
+			size = info.Size()
+		}
+
+		items = append(items, shardFile{path: path, key: key, hash: hasher.Sum64(), size: size})
+	}
+
+	slices.SortFunc(items, func(a, b shardFile) int {
+		return cmp.Or(
+			cmp.Compare(b.size, a.size),
+			cmp.Compare(a.hash, b.hash),
+			cmp.Compare(a.key, b.key),
+		)
+	})
+
+	loads := make([]int64, shard.Count)
+	selected := make(map[string]struct{}, len(files)/shard.Count+1)
+	for _, item := range items {
+		target := 0
+		for i := 1; i < len(loads); i++ {
+			if loads[i] < loads[target] {
+				target = i
+			}
+		}
+		// Count one byte per file so empty files are spread as well.
+		loads[target] += item.size + 1
+
+		if target == shard.Index-1 {
+			selected[item.path] = struct{}{}
+		}
+	}
+
+	partition := make([]string, 0, len(selected))
+	for _, path := range files {
+		if _, ok := selected[path]; ok {
+			partition = append(partition, path)
+		}
+	}
+	return partition, nil
+}
@@ -0,0 +1,204 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/yaklabco/gomdlint/pkg/config"
+	"github.com/yaklabco/gomdlint/pkg/lint"
+	"github.com/yaklabco/gomdlint/pkg/runner"
+)
+
+func TestParseShard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec    string
+		want    runner.Shard
+		wantErr bool
+	}{
+		{spec: "1/1", want: runner.Shard{Index: 1, Count: 1}},
+		{spec: "2/8", want: runner.Shard{Index: 2, Count: 8}},
+		{spec: " 3 / 4 ", want: runner.Shard{Index: 3, Count: 4}},
+		{spec: "0/4", wantErr: true},
+		{spec: "5/4", wantErr: true},
+		{spec: "1/0", wantErr: true},
+		{spec: "2", wantErr: true},
+		{spec: "a/b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := runner.ParseShard(tt.spec)
+			if tt.wantErr {
+				if !errors.Is(err, runner.ErrInvalidShard) {
+					t.Fatalf("ParseShard(%q) error = %v, want ErrInvalidShard", tt.spec, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseShard(%q) error = %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseShard(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+			if got.String() != strings.ReplaceAll(tt.spec, " ", "") {
+				t.Errorf("String() = %q", got.String())
+			}
+		})
+	}
+}
+
+// writeShardFiles creates count files of varying size and returns their
+// sorted paths.
+func writeShardFiles(t *testing.T, dir string, count int) []string {
+	t.Helper()
+
+	files := make([]string, 0, count)
+	for i := range count {
+		path := filepath.Join(dir, "docs", fmt.Sprintf("page-%03d.md", i))
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+		content := "# Page\n" + strings.Repeat("text\n", (i*37)%101)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+		files = append(files, path)
+	}
+	slices.Sort(files)
+	return files
+}
+
+func TestPartition_CompleteAndDisjoint(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := writeShardFiles(t, dir, 60)
+
+	const count = 4
+	seen := make(map[string]int)
+	var sizes [count]int64
+	for index := 1; index <= count; index++ {
+		part, err := runner.Partition(files, runner.Shard{Index: index, Count: count}, dir)
+		if err != nil {
+			t.Fatalf("Partition() error = %v", err)
+		}
+		if !slices.IsSorted(part) {
+			t.Errorf("shard %d is not in discovery order", index)
+		}
+		for _, path := range part {
+			seen[path]++
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sizes[index-1] += info.Size()
+		}
+	}
+
+	for _, path := range files {
+		if seen[path] != 1 {
+			t.Errorf("%s is in %d shards, want 1", path, seen[path])
+		}
+	}
+
+	// Greedy assignment keeps shards within one file of each other.
+	lightest, heaviest := slices.Min(sizes[:]), slices.Max(sizes[:])
+	if heaviest-lightest > 600 {
+		t.Errorf("unbalanced shards: sizes %v", sizes)
+	}
+}
+
+func TestPartition_IndependentOfCheckoutLocation(t *testing.T) {
+	t.Parallel()
+
+	dirA, dirB := t.TempDir(), t.TempDir()
+	filesA := writeShardFiles(t, dirA, 25)
+	filesB := writeShardFiles(t, dirB, 25)
+
+	shard := runner.Shard{Index: 2, Count: 3}
+	partA, err := runner.Partition(filesA, shard, dirA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partB, err := runner.Partition(filesB, shard, dirB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relative := func(dir string, paths []string) []string {
+		out := make([]string, 0, len(paths))
+		for _, path := range paths {
+			rel, _ := filepath.Rel(dir, path)
+			out = append(out, rel)
+		}
+		return out
+	}
+	if !slices.Equal(relative(dirA, partA), relative(dirB, partB)) {
+		t.Errorf("partitions differ between checkouts:\n%v\n%v", relative(dirA, partA), relative(dirB, partB))
+	}
+}
+
+func TestPartition_Unsharded(t *testing.T) {
+	t.Parallel()
+
+	files := []string{"/a.md", "/b.md"}
+	for _, shard := range []runner.Shard{{}, {Index: 1, Count: 1}} {
+		part, err := runner.Partition(files, shard, "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(part, files) {
+			t.Errorf("Partition(%v) = %v, want all files", shard, part)
+		}
+	}
+
+	if _, err := runner.Partition(files, runner.Shard{Index: 3, Count: 2}, "/"); !errors.Is(err, runner.ErrInvalidShard) {
+		t.Errorf("Partition() error = %v, want ErrInvalidShard", err)
+	}
+}
+
+func TestRunner_Run_Shard(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := writeShardFiles(t, dir, 10)
+
+	engine := lint.NewEngine(&mockParser{}, lint.NewRegistry())
+	lintRunner := runner.New(lint.NewPipeline(engine))
+
+	total := 0
+	for index := 1; index <= 3; index++ {
+		result, err := lintRunner.Run(context.Background(), runner.Options{
+			Paths:      []string{"."},
+			WorkingDir: dir,
+			Config:     config.NewConfig(),
+			Shard:      runner.Shard{Index: index, Count: 3},
+		})
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+
+		want := runner.ShardInfo{Index: index, Count: 3, TotalFiles: len(files)}
+		if result.Shard == nil || *result.Shard != want {
+			t.Errorf("Shard = %v, want %v", result.Shard, want)
+		}
+		if result.Stats.FilesDiscovered != len(result.Files) {
+			t.Errorf("FilesDiscovered = %d, want %d", result.Stats.FilesDiscovered, len(result.Files))
+		}
+		total += len(result.Files)
+	}
+
+	if total != len(files) {
+		t.Errorf("shards processed %d files, want %d", total, len(files))
+	}
+}